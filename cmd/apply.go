@@ -1,27 +1,62 @@
 package cmd
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/chmouel/gh-prreview/pkg/ai"
 	"github.com/chmouel/gh-prreview/pkg/applier"
+	"github.com/chmouel/gh-prreview/pkg/config"
 	"github.com/chmouel/gh-prreview/pkg/github"
+	"github.com/chmouel/gh-prreview/pkg/log"
+	"github.com/chmouel/gh-prreview/pkg/state"
+	"github.com/chmouel/gh-prreview/pkg/ui"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 var (
 	applyAll          bool
-	applyFile         string
+	applyFiles        []string
 	applyShowResolved bool
-	applyDebug        bool
 	applyAIAuto       bool
 	applyAIProvider   string
 	applyAIModel      string
 	applyAITemplate   string
 	applyAIToken      string
+	applyAITemp       float64
+	applyAIMaxTokens  int
+	// applyAIGeneration holds --ai-temperature/--ai-max-tokens, checked in setupAIProvider
+	applyAIGeneration ai.GenerationConfig
+	applyFollowRename bool
+	applyExcludeMe    bool
+	applyListModels   bool
+	applyStage        bool
+	applyCommentIDs   []int64
+	applyAuthor       string
+	applyWordDiff     bool
+	applyForce        bool
+	applyFromJSON     string
+	applyWorkDir      string
+	applyOutdated     bool
+	applyRecheck      bool
+	applyReview       int64
+	applyCommit       bool
+	applyCommitSquash bool
+	applyDryRun       bool
+	applyNotify       bool
+	applyNoResolve    bool
+	applyYes          bool
+	applyFormatAfter  bool
+	applyLog          string
+	applyWeb          bool
 )
 
 var applyCmd = &cobra.Command{
@@ -33,106 +68,460 @@ var applyCmd = &cobra.Command{
 
 func init() {
 	applyCmd.Flags().BoolVar(&applyAll, "all", false, "Apply all suggestions without prompting")
-	applyCmd.Flags().StringVar(&applyFile, "file", "", "Only apply suggestions for a specific file")
+	applyCmd.Flags().StringArrayVar(&applyFiles, "file", nil, "Only apply suggestions for this file or glob pattern, e.g. 'pkg/**/*.go' (repeatable)")
+	applyCmd.Flags().StringVar(&applyAuthor, "author", "", "Only apply suggestions from this reviewer (e.g. Copilot)")
+	applyCmd.Flags().Int64SliceVar(&applyCommentIDs, "comment-id", nil, "Only apply the suggestion of this review comment ID (repeatable)")
+	applyCmd.Flags().Int64Var(&applyReview, "review", 0, "Only apply suggestions submitted with this review ID (see 'gh prreview reviews')")
 	applyCmd.Flags().BoolVar(&applyShowResolved, "include-resolved", false, "Include resolved/done suggestions")
-	applyCmd.Flags().BoolVar(&applyDebug, "debug", false, "Enable debug output")
+	applyCmd.Flags().BoolVar(&applyOutdated, "include-outdated", false, "Include suggestions on outdated code, which usually no longer match the file")
+	applyCmd.Flags().BoolVar(&applyRecheck, "recheck-outdated", false, "Decide which suggestions are outdated from the local files instead of GitHub's diff, e.g. after a rebase")
+	applyCmd.Flags().BoolVar(&applyExcludeMe, "exclude-me", false, "Skip suggestions authored by the current user")
+	applyCmd.Flags().BoolVar(&applyStage, "stage", false, "Stage each file with 'git add' after a suggestion is applied to it")
+	applyCmd.Flags().BoolVar(&applyFormatAfter, "format-after", false, "Run the formatter of each modified file (gofmt, prettier, or the formatters of the config) after applying a suggestion to it")
+	applyCmd.Flags().BoolVar(&applyCommit, "commit", false, "Commit each applied suggestion on its own, with a message referencing the review comment")
+	applyCmd.Flags().BoolVar(&applyCommitSquash, "commit-squash", false, "Commit all applied suggestions together in one commit at the end of the run")
+	applyCmd.Flags().BoolVar(&applyDryRun, "dry-run", false, "With --all, only report which suggestions would apply, and the commits --commit or --commit-squash would make")
+	applyCmd.Flags().BoolVarP(&applyYes, "yes", "y", false, "With --all or --ai-auto, skip the confirmation listing the files that will be modified")
+	applyCmd.Flags().BoolVar(&applyForce, "force", false, "Also apply suggestions to files matching the protected_files patterns of the config, or on a branch other than the PR head")
+	applyCmd.Flags().StringVar(&applyLog, "log", "", "Append a JSON line per processed suggestion (comment ID, path, line, author, outcome, error, resolved) to this file")
+	applyCmd.Flags().BoolVar(&applyWordDiff, "word-diff", false, "Highlight the changed words of modified lines in diffs")
+	applyCmd.Flags().BoolVar(&applyWeb, "web", false, "Open the pull request in the browser instead of applying its suggestions")
+	applyCmd.Flags().StringVar(&applyWorkDir, "workdir", "", "Apply the suggestions to the checkout in this directory, e.g. a git worktree, instead of the current one")
+	applyCmd.Flags().StringVar(&applyFromJSON, "from-json", "", "Read review comments from a 'list --json' dump (file or - for stdin) instead of GitHub")
+	applyCmd.Flags().BoolVar(&applyFollowRename, "follow-renames", false, "Apply suggestions to the new location of files renamed since the review")
 
 	// AI flags
 	applyCmd.Flags().BoolVar(&applyAIAuto, "ai-auto", false, "Automatically apply all suggestions using AI")
-	applyCmd.Flags().StringVar(&applyAIProvider, "ai-provider", "", "AI provider to use (gemini) - defaults to env or 'gemini'")
+	applyCmd.Flags().BoolVar(&applyNoResolve, "no-resolve-prompt", false, "Leave review threads open: don't ask to resolve them after applying, nor auto-resolve them with --ai-auto")
+	applyCmd.Flags().BoolVar(&applyNotify, "notify", false, "With --ai-auto, ring the bell and send a desktop notification when the batch finishes")
+	applyCmd.Flags().StringVar(&applyAIProvider, "ai-provider", "", "AI provider to use (gemini, openai, anthropic), or a comma-separated fallback chain like 'gemini,openai' - defaults to env or 'gemini'")
 	applyCmd.Flags().StringVar(&applyAIModel, "ai-model", "", "AI model to use (provider-specific)")
 	applyCmd.Flags().StringVar(&applyAITemplate, "ai-template", "", "Custom AI prompt template file")
+	applyCmd.Flags().BoolVar(&applyListModels, "list-models", false, "List the known models of each AI provider and exit")
 	applyCmd.Flags().StringVar(&applyAIToken, "ai-token", "", "AI API token/key (alternative to environment variable)")
+	applyCmd.Flags().Float64Var(&applyAITemp, "ai-temperature", 0, "AI sampling temperature, 0 to 2 (Anthropic accepts up to 1); near 0 for reproducible patches (default: provider's)")
+	applyCmd.Flags().IntVar(&applyAIMaxTokens, "ai-max-tokens", 0, "Maximum number of tokens the AI may answer with (default: provider's)")
 }
 
 func runApply(cmd *cobra.Command, args []string) error {
-	// Check if there are uncommitted changes
-	if err := checkCleanWorkingDirectory(); err != nil {
-		return err
+	if applyListModels {
+		listAIModels()
+		return nil
+	}
+	if applyCommit && applyCommitSquash {
+		return fmt.Errorf("--commit cannot be combined with --commit-squash")
+	}
+	if applyDryRun && !applyAll {
+		return fmt.Errorf("--dry-run can only be used with --all")
+	}
+	if applyDryRun && applyAIAuto {
+		return fmt.Errorf("--dry-run cannot be combined with --ai-auto")
+	}
+	if applyDryRun && applyLog != "" {
+		return fmt.Errorf("--log cannot be combined with --dry-run")
+	}
+	if applyNotify && !applyAIAuto {
+		return fmt.Errorf("--notify can only be used with --ai-auto")
 	}
+	if applyRecheck && applyOutdated {
+		return fmt.Errorf("--recheck-outdated cannot be combined with --include-outdated")
+	}
+	generation, genErr := aiGenerationFromFlags(cmd)
+	if genErr != nil {
+		return genErr
+	}
+	applyAIGeneration = generation
+	if applyWorkDir != "" {
+		if info, err := os.Stat(applyWorkDir); err != nil || !info.IsDir() {
+			return fmt.Errorf("--workdir %s is not a directory", applyWorkDir)
+		}
+	}
+	defer redirectStdout()()
 
-	client := github.NewClient()
-	client.SetDebug(applyDebug)
-	if repoFlag != "" {
-		client.SetRepo(repoFlag)
+	// Check if there are uncommitted changes; a dry run changes nothing
+	if !applyDryRun {
+		if err := checkCleanWorkingDirectory(applyWorkDir); err != nil {
+			return err
+		}
 	}
 
-	prNumber, err := getPRNumberWithSelection(args, client)
-	if err != nil {
-		return err
+	var client *github.Client
+	var comments []*github.ReviewComment
+	var prNumber int
+	var err error
+	if applyFromJSON != "" {
+		// Offline mode: no client, so threads are never resolved
+		if len(args) > 0 {
+			return fmt.Errorf("PR_NUMBER cannot be used with --from-json")
+		}
+		if applyExcludeMe {
+			return fmt.Errorf("--exclude-me cannot be used with --from-json")
+		}
+		if applyWeb {
+			return fmt.Errorf("--web cannot be used with --from-json")
+		}
+		comments, err = readCommentsJSON(applyFromJSON)
+		if err != nil {
+			return err
+		}
+	} else {
+		client = github.NewClient()
+		if repoFlag != "" {
+			client.SetRepo(repoFlag)
+		}
+
+		prNumber, err = getPRNumberWithSelection(args, client)
+		if err != nil {
+			return err
+		}
+		if applyWeb {
+			return openPRInBrowser(client, prNumber)
+		}
+
+		if err := checkPRBranch(client, prNumber); err != nil {
+			return err
+		}
+
+		comments, err = fetchFileReviewComments(client, prNumber, literalFile(applyFiles))
+		if err != nil {
+			return fmt.Errorf("failed to fetch review comments: %w", err)
+		}
+
+		comments, err = excludeOwnComments(client, comments, applyExcludeMe)
+		if err != nil {
+			return err
+		}
 	}
 
-	comments, err := client.FetchReviewComments(prNumber)
-	if err != nil {
-		return fmt.Errorf("failed to fetch review comments: %w", err)
+	if len(applyCommentIDs) > 0 {
+		comments, err = selectCommentsByID(comments, applyCommentIDs)
+		if err != nil {
+			return err
+		}
 	}
 
-	// Filter comments with suggestions and not resolved (unless --include-resolved)
-	suggestions := make([]*github.ReviewComment, 0)
-	for _, comment := range comments {
-		if comment.HasSuggestion {
-			// Skip resolved suggestions unless explicitly requested
-			if !applyShowResolved && comment.IsResolved() {
-				continue
-			}
-			if applyFile == "" || comment.Path == applyFile {
-				suggestions = append(suggestions, comment)
+	if applyReview != 0 {
+		comments = github.FilterByReview(comments, applyReview)
+	}
+	suggestions := filterSuggestions(comments, applyFiles, applyAuthor, applyShowResolved)
+
+	// Opened before the exclusions below so that they are in the trail too
+	var resultLog io.Writer
+	if applyLog != "" {
+		f, err := os.OpenFile(applyLog, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			return fmt.Errorf("failed to open result log: %w", err)
+		}
+		defer f.Close()
+		resultLog = f
+	}
+
+	if applyRecheck {
+		for _, suggestion := range applier.RecheckOutdated(suggestions, applyWorkDir) {
+			if suggestion.IsOutdated {
+				log.Infof("Note: %s is outdated in the working tree", suggestion.LocationLabel())
+			} else {
+				log.Infof("Note: %s is current in the working tree", suggestion.LocationLabel())
 			}
 		}
 	}
 
+	// Suggestions left out below still count as skipped in the summary
+	var skipped []skippedSuggestion
+	suggestions, fileLevel := excludeFileLevel(suggestions)
+	for _, suggestion := range fileLevel {
+		fmt.Printf("%sSkipping suggestion in %s (ID %d): it is not attached to any line\n",
+			ui.EmojiText("⏭️  ", "SKIP: "), suggestion.LocationDescription(), suggestion.ID)
+		skipped = append(skipped, skippedSuggestion{suggestion, "not attached to any line"})
+	}
+
+	// Comments picked by ID are attempted even when outdated
+	suggestions, outdated := excludeOutdated(suggestions, applyOutdated || len(applyCommentIDs) > 0)
+	if len(outdated) > 0 {
+		fmt.Printf("%sSkipping %d outdated suggestion(s) (use --include-outdated to try them)\n",
+			ui.EmojiText("⏭️  ", "SKIP: "), len(outdated))
+	}
+	for _, suggestion := range outdated {
+		skipped = append(skipped, skippedSuggestion{suggestion, "outdated"})
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	suggestions, protected := excludeProtected(suggestions, cfg, applyForce)
+	for _, suggestion := range protected {
+		pattern, _ := cfg.ProtectedPattern(suggestion.Path)
+		fmt.Printf("%sSkipping suggestion on protected file %s (matches %q, use --force to apply)\n",
+			ui.EmojiText("🔒 ", ""), suggestion.LocationLabel(), pattern)
+		skipped = append(skipped, skippedSuggestion{suggestion, fmt.Sprintf("protected file (matches %q)", pattern)})
+	}
+	for _, s := range skipped {
+		logSkipped(resultLog, s.comment, s.reason)
+	}
+
 	if len(suggestions) == 0 {
-		if applyFile != "" {
-			fmt.Printf("No unresolved suggestions found for file: %s\n", applyFile)
-		} else {
+		if len(protected) > 0 || len(outdated) > 0 || len(fileLevel) > 0 {
+			summary := skippedSummary(applier.NewSummary(0), skipped)
+			return printSummary(summary, func() {})
+		}
+		switch {
+		case len(applyFiles) > 0 && applyAuthor != "":
+			fmt.Printf("No unresolved suggestions from @%s found for file: %s\n", applyAuthor, strings.Join(applyFiles, ", "))
+		case len(applyFiles) > 0:
+			fmt.Printf("No unresolved suggestions found for file: %s\n", strings.Join(applyFiles, ", "))
+		case applyAuthor != "":
+			fmt.Printf("No unresolved suggestions from @%s found in review comments.\n", applyAuthor)
+		case applyReview != 0:
+			fmt.Printf("No unresolved suggestions found in review %d.\n", applyReview)
+		default:
 			fmt.Println("No unresolved suggestions found in review comments.")
 		}
 		if !applyShowResolved {
 			fmt.Println("Use --include-resolved to show resolved suggestions.")
 		}
-		return nil
+		return printSummary(applier.NewSummary(0), func() {})
 	}
 
 	fmt.Printf("Found %d suggestion(s) to apply\n\n", len(suggestions))
 
 	app := applier.New()
-	app.SetDebug(applyDebug)
-	app.SetGitHubClient(client) // Pass GitHub client for resolving threads
+	app.SetWorkDir(applyWorkDir)
+	app.SetFollowRenames(applyFollowRename)
+	app.SetStage(applyStage)
+	app.SetNotify(applyNotify)
+	app.SetResolvePrompting(!applyNoResolve)
+	if applyFormatAfter {
+		app.SetFormatter(cfg.FormatCommand)
+	}
+	switch {
+	case applyCommit:
+		app.SetCommit(applier.CommitEach)
+	case applyCommitSquash:
+		app.SetCommit(applier.CommitSquash)
+	}
+	app.SetWordDiff(applyWordDiff)
+	if store, err := state.DefaultAppliedStore(); err == nil {
+		app.SetAppliedStore(store)
+	} else {
+		log.Infof("Note: applied suggestions will not be recorded: %v", err)
+	}
+	if store, err := state.DefaultOutcomeStore(); err == nil {
+		app.SetOutcomeStore(store)
+	} else {
+		log.Infof("Note: apply outcomes will not be recorded: %v", err)
+	}
+	app.SetGitHubClient(client) // Pass GitHub client for resolving threads (nil offline)
+	app.SetPRNumber(prNumber)
+	if resultLog != nil {
+		app.SetResultSink(resultLog)
+	}
 
 	// Setup AI provider if needed (for interactive or --ai-auto)
 	if applyAIAuto || (!applyAll) {
 		provider, err := setupAIProvider()
 		if err != nil {
+			var settingsErr *aiGenerationError
+			if errors.As(err, &settingsErr) {
+				// Asked for explicitly, not worth a mere note
+				return err
+			}
 			if applyAIAuto {
 				// AI is required for --ai-auto
 				return fmt.Errorf("AI provider required for --ai-auto: %w", err)
 			}
 			// In interactive mode, just warn that AI won't be available
-			if applyDebug {
-				fmt.Fprintf(os.Stderr, "Note: AI features not available: %v\n", err)
-			}
+			log.Infof("Note: AI features not available: %v", err)
 		} else {
 			app.SetAIProvider(provider)
-			if applyDebug {
-				fmt.Fprintf(os.Stderr, "AI provider configured: %s\n", provider.Name())
+			log.Infof("AI provider configured: %s", describeAIProvider(provider))
+		}
+	}
+
+	if (applyAll && !applyDryRun) || applyAIAuto {
+		confirmed, err := confirmBatchApply(stdinReader, suggestions, applyYes)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			fmt.Println(ui.Colorize(ui.ColorGray, "Operation cancelled"))
+			return nil
+		}
+	}
+
+	var summary *applier.Summary
+	switch {
+	case applyDryRun:
+		app.DryRun(suggestions)
+		return nil
+	case applyAIAuto:
+		summary, err = app.ApplyAllWithAI(suggestions)
+	case applyAll:
+		summary, err = app.ApplyAll(suggestions)
+	default:
+		summary, err = app.ApplyInteractive(suggestions)
+	}
+	if err != nil {
+		return err
+	}
+	summary = skippedSummary(summary, skipped)
+	return printSummary(summary, summary.Print)
+}
+
+// readCommentsJSON decodes the review comments dumped by 'list --json' from
+// path, or from stdin when path is "-"
+func readCommentsJSON(path string) ([]*github.ReviewComment, error) {
+	if path == "-" {
+		return github.ParseCommentsJSON(os.Stdin)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	return github.ParseCommentsJSON(f)
+}
+
+// filterSuggestions keeps the comments carrying a suggestion, optionally
+// limited to files (see matchesFile) and one author. Resolved suggestions are
+// skipped unless includeResolved is set.
+func filterSuggestions(comments []*github.ReviewComment, files []string, author string, includeResolved bool) []*github.ReviewComment {
+	suggestions := make([]*github.ReviewComment, 0)
+	for _, comment := range comments {
+		if !comment.HasSuggestion {
+			continue
+		}
+		if !includeResolved && comment.IsResolved() {
+			continue
+		}
+		if len(files) > 0 && !matchesFile(files, comment.Path) {
+			continue
+		}
+		if author != "" && !comment.IsAuthoredBy(author) {
+			continue
+		}
+		suggestions = append(suggestions, comment)
+	}
+	return suggestions
+}
+
+// matchesFile reports whether path is one of files. An entry with glob
+// characters is a pattern as in protected_files ("**" spans directories, and
+// "*.go" matches in any directory); other entries must be the exact path.
+func matchesFile(files []string, path string) bool {
+	for _, file := range files {
+		if isGlob(file) {
+			if config.MatchGlob(file, path) {
+				return true
 			}
+			continue
+		}
+		if filepath.ToSlash(filepath.Clean(file)) == path {
+			return true
+		}
+	}
+	return false
+}
+
+// literalFile returns the path of files when it names a single file without
+// a pattern, so only that file's comments need to be fetched, or ""
+func literalFile(files []string) string {
+	if len(files) != 1 || isGlob(files[0]) {
+		return ""
+	}
+	return filepath.ToSlash(filepath.Clean(files[0]))
+}
+
+func isGlob(file string) bool {
+	return strings.ContainsAny(file, "*?[")
+}
+
+// excludeOutdated splits off the suggestions on outdated code, unless include
+// is set
+func excludeOutdated(suggestions []*github.ReviewComment, include bool) ([]*github.ReviewComment, []*github.ReviewComment) {
+	if include {
+		return suggestions, nil
+	}
+
+	var current, outdated []*github.ReviewComment
+	for _, suggestion := range suggestions {
+		if suggestion.IsOutdated {
+			outdated = append(outdated, suggestion)
+		} else {
+			current = append(current, suggestion)
 		}
 	}
+	return current, outdated
+}
+
+// excludeFileLevel splits off the suggestions made in file-level comments,
+// which have no lines to replace
+func excludeFileLevel(suggestions []*github.ReviewComment) ([]*github.ReviewComment, []*github.ReviewComment) {
+	var placed, fileLevel []*github.ReviewComment
+	for _, suggestion := range suggestions {
+		if suggestion.IsFileLevel {
+			fileLevel = append(fileLevel, suggestion)
+		} else {
+			placed = append(placed, suggestion)
+		}
+	}
+	return placed, fileLevel
+}
+
+// excludeProtected splits off the suggestions on files matching the protected
+// patterns of cfg, unless force is set
+func excludeProtected(suggestions []*github.ReviewComment, cfg *config.Config, force bool) ([]*github.ReviewComment, []*github.ReviewComment) {
+	if force {
+		return suggestions, nil
+	}
 
-	if applyAIAuto {
-		return app.ApplyAllWithAI(suggestions)
+	var allowed, protected []*github.ReviewComment
+	for _, suggestion := range suggestions {
+		if _, ok := cfg.ProtectedPattern(suggestion.Path); ok {
+			protected = append(protected, suggestion)
+			continue
+		}
+		allowed = append(allowed, suggestion)
 	}
+	return allowed, protected
+}
 
-	if applyAll {
-		return app.ApplyAll(suggestions)
+// selectCommentsByID returns the comments with the given IDs, in the order the
+// IDs were given. Every ID must match a comment that carries a suggestion.
+func selectCommentsByID(comments []*github.ReviewComment, ids []int64) ([]*github.ReviewComment, error) {
+	byID := make(map[int64]*github.ReviewComment, len(comments))
+	for _, comment := range comments {
+		byID[comment.ID] = comment
 	}
 
-	return app.ApplyInteractive(suggestions)
+	selected := make([]*github.ReviewComment, 0, len(ids))
+	seen := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		comment, ok := byID[id]
+		if !ok {
+			return nil, fmt.Errorf("review comment %d not found in this pull request", id)
+		}
+		if !comment.HasSuggestion {
+			return nil, fmt.Errorf("review comment %d has no suggestion to apply", id)
+		}
+		selected = append(selected, comment)
+	}
+	return selected, nil
 }
 
-// checkCleanWorkingDirectory checks if the git working directory is clean
-func checkCleanWorkingDirectory() error {
+// checkCleanWorkingDirectory checks if the git working directory in dir, or
+// the current one when empty, is clean
+func checkCleanWorkingDirectory(dir string) error {
 	cmd := exec.Command("git", "status", "--porcelain")
+	cmd.Dir = dir
 	output, err := cmd.Output()
 	if err != nil {
 		// If git status fails, we're probably not in a git repo
@@ -147,6 +536,208 @@ func checkCleanWorkingDirectory() error {
 	return nil
 }
 
+// checkPRBranch makes sure suggestions are applied on the PR's head branch,
+// since on any other branch they land in unrelated code. When the branches
+// differ, apply goes on only with --force or after confirmation.
+func checkPRBranch(client *github.Client, prNumber int) error {
+	pr, err := client.GetPR(prNumber)
+	if err != nil {
+		log.Infof("Note: cannot check the PR branch: %v", err)
+		return nil
+	}
+	branch, err := currentBranch(applyWorkDir)
+	if err != nil {
+		log.Infof("Note: cannot check the PR branch: %v", err)
+		return nil
+	}
+	return confirmBranch(stdinReader, branch, pr.HeadRefName, prNumber, applyForce)
+}
+
+// confirmBranch warns when branch is not the PR head branch and asks whether
+// to continue, unless force is set
+func confirmBranch(in *bufio.Reader, branch, head string, prNumber int, force bool) error {
+	if head == "" || branch == head {
+		return nil
+	}
+
+	current := branch
+	if current == "" {
+		current = "a detached HEAD"
+	}
+	fmt.Fprintf(os.Stderr, "%s\n", ui.Colorize(ui.ColorRed, fmt.Sprintf(
+		"%sYou are on %s, but PR #%d is from branch %s",
+		ui.EmojiText("⚠️  ", "Warning: "), current, prNumber, head)))
+	if force {
+		return nil
+	}
+	fmt.Fprintf(os.Stderr, "Suggestions may land in unrelated code. Check it out with: gh pr checkout %d\n", prNumber)
+	if !confirmPrompt(in, ui.Colorize(ui.ColorYellow, "Apply anyway? [y/N]:")+" ") {
+		return fmt.Errorf("not on the PR head branch %s (use --force to apply anyway)", head)
+	}
+	return nil
+}
+
+// stdinIsTerminal reports whether a confirmation can be read from stdin,
+// replaced in tests
+var stdinIsTerminal = func() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// skippedSuggestion is a suggestion left out before applying, with the reason
+type skippedSuggestion struct {
+	comment *github.ReviewComment
+	reason  string
+}
+
+// skippedSummary adds the suggestions left out before applying to summary as
+// skipped, so --format json reports them like the ones the applier skipped.
+func skippedSummary(summary *applier.Summary, skipped []skippedSuggestion) *applier.Summary {
+	for _, s := range skipped {
+		summary.AddSkipped(s.comment, s.reason)
+	}
+	return summary
+}
+
+// logSkipped writes a skipped record for suggestion to the --log file, if
+// any. Write errors are only logged, as in the applier.
+func logSkipped(w io.Writer, suggestion *github.ReviewComment, reason string) {
+	if w == nil {
+		return
+	}
+	if err := applier.WriteSkipped(w, suggestion, reason); err != nil {
+		log.Debugf("Failed to write result of suggestion %d: %v", suggestion.ID, err)
+	}
+}
+
+// confirmBatchApply shows the files a batch apply is about to modify and asks
+// before going on, unless yes is set. Without a terminal to answer on, it
+// fails rather than cancelling so scripted runs don't silently do nothing.
+func confirmBatchApply(in *bufio.Reader, suggestions []*github.ReviewComment, yes bool) (bool, error) {
+	if yes {
+		return true, nil
+	}
+	if !stdinIsTerminal() {
+		return false, fmt.Errorf("cannot ask for confirmation without a terminal, use --yes to apply %d suggestion(s)", len(suggestions))
+	}
+	summary, files := formatBatchSummary(suggestions)
+	fmt.Print(summary)
+	return confirmPrompt(in, fmt.Sprintf("\n%s ", ui.Colorize(ui.ColorYellow,
+		fmt.Sprintf("Apply %d suggestion(s) to %d file(s)? [y/N]:", len(suggestions), files)))), nil
+}
+
+// formatBatchSummary lists the distinct files of suggestions, sorted, with the
+// number of suggestions for each, and returns how many files there are
+func formatBatchSummary(suggestions []*github.ReviewComment) (string, int) {
+	counts := map[string]int{}
+	for _, suggestion := range suggestions {
+		counts[suggestion.Path]++
+	}
+	paths := make([]string, 0, len(counts))
+	for path := range counts {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var b strings.Builder
+	b.WriteString("Files to modify:\n")
+	for _, path := range paths {
+		fmt.Fprintf(&b, "  %s (%d)\n", ui.Colorize(ui.ColorCyan, path), counts[path])
+	}
+	return b.String(), len(paths)
+}
+
+// currentBranch returns the branch checked out in dir (the current directory
+// when empty), or an empty string on a detached HEAD
+func currentBranch(dir string) (string, error) {
+	cmd := exec.Command("git", "branch", "--show-current")
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get the current branch: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// listAIModels prints the known models of each AI provider
+func listAIModels() {
+	for _, name := range ai.ProviderNames() {
+		meta, _ := ai.GetProviderMetadata(name)
+		fmt.Printf("%s (%s, key from %s)\n", ui.Colorize(ui.ColorCyan, name), meta.Label, strings.Join(meta.EnvVars, " or "))
+		for _, model := range meta.SupportedModels {
+			if model == meta.DefaultModel {
+				fmt.Printf("  %s %s\n", model, ui.Colorize(ui.ColorGray, "(default)"))
+			} else {
+				fmt.Printf("  %s\n", model)
+			}
+		}
+	}
+}
+
+// describeAIProvider names the provider, listing the whole chain of a
+// fallback chain
+func describeAIProvider(provider ai.AIProvider) string {
+	chain, ok := provider.(*ai.ChainProvider)
+	if !ok {
+		return provider.Name()
+	}
+	names := make([]string, 0, len(chain.Providers()))
+	for _, p := range chain.Providers() {
+		names = append(names, p.Name())
+	}
+	return strings.Join(names, " -> ")
+}
+
+// aiGenerationFromFlags returns the sampling settings given with
+// --ai-temperature and --ai-max-tokens; flags left out keep the provider
+// defaults
+func aiGenerationFromFlags(cmd *cobra.Command) (ai.GenerationConfig, error) {
+	var generation ai.GenerationConfig
+	if cmd.Flags().Changed("ai-temperature") {
+		temperature := applyAITemp
+		generation.Temperature = &temperature
+	}
+	if cmd.Flags().Changed("ai-max-tokens") {
+		if applyAIMaxTokens <= 0 {
+			return generation, fmt.Errorf("--ai-max-tokens must be a positive number")
+		}
+		generation.MaxTokens = applyAIMaxTokens
+	}
+	return generation, nil
+}
+
+// aiGenerationError is returned by setupAIProvider for sampling settings out
+// of the range of the providers, which fails apply even in interactive mode
+type aiGenerationError struct {
+	msg string
+}
+
+func (e *aiGenerationError) Error() string {
+	return e.msg
+}
+
+// validateAIGeneration checks the sampling settings are in the range every
+// provider of the chain accepts, before any request is made
+func validateAIGeneration(generation ai.GenerationConfig, providers []string) error {
+	if t := generation.Temperature; t != nil {
+		limit, label := 2.0, ""
+		for _, name := range providers {
+			if meta, ok := ai.GetProviderMetadata(name); ok && meta.MaxTemperature > 0 && meta.MaxTemperature < limit {
+				limit, label = meta.MaxTemperature, meta.Label
+			}
+		}
+		if *t < 0 || *t > limit {
+			if label != "" {
+				return &aiGenerationError{fmt.Sprintf("--ai-temperature must be between 0 and %g for %s", limit, label)}
+			}
+			return &aiGenerationError{fmt.Sprintf("--ai-temperature must be between 0 and %g", limit)}
+		}
+	}
+	if generation.MaxTokens < 0 {
+		return &aiGenerationError{"--ai-max-tokens must be a positive number"}
+	}
+	return nil
+}
+
 // setupAIProvider creates and configures an AI provider based on flags and environment
 func setupAIProvider() (ai.AIProvider, error) {
 	// Start with config from environment
@@ -165,17 +756,43 @@ func setupAIProvider() (ai.AIProvider, error) {
 	if applyAIToken != "" {
 		config.APIKey = applyAIToken
 	}
+	config.Generation = applyAIGeneration
+
+	// With a fallback chain (e.g. gemini,openai), --ai-model and --ai-token
+	// apply to the first provider
+	primary := config.Provider
+	names := ai.ProviderChain(config.Provider)
+	if len(names) > 0 {
+		primary = names[0]
+	}
+	if err := validateAIGeneration(config.Generation, names); err != nil {
+		return nil, err
+	}
+	if applyAIProvider != "" && applyAIToken == "" {
+		// LoadConfigFromEnv loaded the key of GH_PRREVIEW_AI_PROVIDER, reload
+		// it for the provider given on the command line
+		config.APIKey = ai.APIKeyFromEnv(primary)
+	}
+
+	// Warn about unknown models early rather than failing deep in the API call.
+	// Unknown models are still allowed since the known list goes stale.
+	if config.Model != "" && !ai.IsKnownModel(primary, config.Model) {
+		if meta, ok := ai.GetProviderMetadata(primary); ok {
+			fmt.Fprintf(os.Stderr, "%sModel %q is not a known %s model (known: %s). Trying it anyway.\n",
+				ui.EmojiText("⚠️  ", "Warning: "), config.Model, meta.Label, strings.Join(meta.SupportedModels, ", "))
+		}
+	}
 
 	// Validate we have an API key
 	if config.APIKey == "" {
-		meta, ok := ai.GetProviderMetadata(config.Provider)
+		meta, ok := ai.GetProviderMetadata(primary)
 		if !ok || len(meta.EnvVars) == 0 {
-			return nil, fmt.Errorf("AI API key not found for provider %q. Use --ai-token flag or set the appropriate environment variable", config.Provider)
+			return nil, fmt.Errorf("AI API key not found for provider %q. Use --ai-token flag or set the appropriate environment variable", primary)
 		}
 
 		providerLabel := meta.Label
 		if providerLabel == "" {
-			providerLabel = strings.ToUpper(config.Provider)
+			providerLabel = strings.ToUpper(primary)
 		}
 
 		return nil, fmt.Errorf("%s API key not found. Set %s or use --ai-token flag",
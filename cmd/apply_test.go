@@ -0,0 +1,346 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/chmouel/gh-prreview/pkg/ai"
+	"github.com/chmouel/gh-prreview/pkg/applier"
+	"github.com/chmouel/gh-prreview/pkg/config"
+	"github.com/chmouel/gh-prreview/pkg/github"
+	"github.com/chmouel/gh-prreview/pkg/ui"
+)
+
+func TestSelectCommentsByID(t *testing.T) {
+	comments := []*github.ReviewComment{
+		{ID: 1, HasSuggestion: true},
+		{ID: 2},
+		{ID: 3, HasSuggestion: true},
+	}
+
+	tests := []struct {
+		name    string
+		ids     []int64
+		want    []int64
+		wantErr string
+	}{
+		{"single id", []int64{3}, []int64{3}, ""},
+		{"keeps requested order", []int64{3, 1}, []int64{3, 1}, ""},
+		{"ignores duplicates", []int64{1, 1}, []int64{1}, ""},
+		{"unknown id", []int64{1, 42}, nil, "review comment 42 not found"},
+		{"comment without suggestion", []int64{2}, nil, "review comment 2 has no suggestion"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := selectCommentsByID(comments, tt.ids)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("selectCommentsByID(%v) error = %v, want %q", tt.ids, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("selectCommentsByID(%v) error = %v", tt.ids, err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("selectCommentsByID(%v) returned %d comments, want %d", tt.ids, len(got), len(tt.want))
+			}
+			for i, id := range tt.want {
+				if got[i].ID != id {
+					t.Errorf("selectCommentsByID(%v)[%d].ID = %d, want %d", tt.ids, i, got[i].ID, id)
+				}
+			}
+		})
+	}
+}
+
+func TestFilterSuggestions(t *testing.T) {
+	comments := []*github.ReviewComment{
+		{ID: 1, Author: "Copilot", Path: "main.go", HasSuggestion: true},
+		{ID: 2, Author: "alice", Path: "main.go", HasSuggestion: true},
+		{ID: 3, Author: "Copilot", Path: "util.go", HasSuggestion: true},
+		{ID: 4, Author: "Copilot", Path: "main.go"},
+		{ID: 5, Author: "Copilot", Path: "main.go", HasSuggestion: true, SubjectType: "resolved"},
+		{ID: 6, Author: "alice", Path: "pkg/api/types.go", HasSuggestion: true},
+		{ID: 7, Author: "alice", Path: "docs/README.md", HasSuggestion: true},
+	}
+
+	tests := []struct {
+		name            string
+		files           []string
+		author          string
+		includeResolved bool
+		want            []int64
+	}{
+		{"all unresolved suggestions", nil, "", false, []int64{1, 2, 3, 6, 7}},
+		{"only the given author", nil, "Copilot", false, []int64{1, 3}},
+		{"author with bot suffix", nil, "Copilot[bot]", false, []int64{1, 3}},
+		{"author and file", []string{"main.go"}, "Copilot", false, []int64{1}},
+		{"author including resolved", nil, "copilot", true, []int64{1, 3, 5}},
+		{"unknown author", nil, "bob", false, nil},
+		{"several files", []string{"util.go", "./docs/README.md"}, "", false, []int64{3, 7}},
+		{"exact path does not match in subdirectories", []string{"types.go"}, "", false, nil},
+		{"glob across directories", []string{"pkg/**/*.go"}, "", false, []int64{6}},
+		{"glob on the file name", []string{"*.go"}, "alice", false, []int64{2, 6}},
+		{"glob and path", []string{"*.md", "util.go"}, "", false, []int64{3, 7}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := filterSuggestions(comments, tt.files, tt.author, tt.includeResolved)
+			if len(got) != len(tt.want) {
+				t.Fatalf("filterSuggestions() returned %d suggestions, want %d", len(got), len(tt.want))
+			}
+			for i, id := range tt.want {
+				if got[i].ID != id {
+					t.Errorf("filterSuggestions()[%d].ID = %d, want %d", i, got[i].ID, id)
+				}
+			}
+		})
+	}
+}
+
+func TestExcludeProtected(t *testing.T) {
+	cfg := &config.Config{ProtectedFiles: []string{"*.pb.go", "**/generated/**"}}
+	suggestions := []*github.ReviewComment{
+		{ID: 1, Path: "main.go"},
+		{ID: 2, Path: "api/service.pb.go"},
+		{ID: 3, Path: "pkg/generated/types.go"},
+	}
+
+	allowed, protected := excludeProtected(suggestions, cfg, false)
+	if len(allowed) != 1 || allowed[0].ID != 1 {
+		t.Errorf("excludeProtected() allowed = %v, want only comment 1", commentIDs(allowed))
+	}
+	if len(protected) != 2 || protected[0].ID != 2 || protected[1].ID != 3 {
+		t.Errorf("excludeProtected() protected = %v, want comments 2 and 3", commentIDs(protected))
+	}
+
+	allowed, protected = excludeProtected(suggestions, cfg, true)
+	if len(allowed) != 3 || len(protected) != 0 {
+		t.Errorf("excludeProtected() with force = %v, %v; want everything allowed", commentIDs(allowed), commentIDs(protected))
+	}
+
+	allowed, _ = excludeProtected(suggestions, &config.Config{}, false)
+	if len(allowed) != 3 {
+		t.Errorf("excludeProtected() without patterns allowed %v, want everything", commentIDs(allowed))
+	}
+}
+
+func TestSkippedSummaryJSON(t *testing.T) {
+	previousFormat, previousOut := outputFormat, summaryOut
+	defer func() { outputFormat, summaryOut = previousFormat, previousOut }()
+
+	var out bytes.Buffer
+	outputFormat = formatJSON
+	summaryOut = &out
+
+	skipped := []skippedSuggestion{
+		{&github.ReviewComment{ID: 1, Path: "main.go", SubjectType: "file"}, "not attached to any line"},
+		{&github.ReviewComment{ID: 2, Path: "main.go", Line: 8, IsOutdated: true}, "outdated"},
+		{&github.ReviewComment{ID: 3, Path: "api.pb.go", Line: 3}, `protected file (matches "*.pb.go")`},
+	}
+	if err := printSummary(skippedSummary(applier.NewSummary(0), skipped), func() {}); err != nil {
+		t.Fatalf("printSummary() error = %v", err)
+	}
+
+	var got applier.Summary
+	if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if got.Total != 3 || got.Skipped != 3 || got.Applied != 0 || got.Failed != 0 {
+		t.Errorf("summary = %d total, %d skipped, %d applied, %d failed; want 3 total and 3 skipped",
+			got.Total, got.Skipped, got.Applied, got.Failed)
+	}
+	if len(got.Suggestions) != 3 || got.Suggestions[1].Error != "outdated" ||
+		got.Suggestions[2].Error != `protected file (matches "*.pb.go")` {
+		t.Errorf("suggestions = %+v, want each skipped with its reason", got.Suggestions)
+	}
+}
+
+func TestExcludeOutdated(t *testing.T) {
+	suggestions := []*github.ReviewComment{
+		{ID: 1, Path: "main.go"},
+		{ID: 2, Path: "main.go", IsOutdated: true},
+		{ID: 3, Path: "util.go"},
+	}
+
+	current, outdated := excludeOutdated(suggestions, false)
+	if !slices.Equal(commentIDs(current), []int64{1, 3}) {
+		t.Errorf("excludeOutdated() current = %v, want [1 3]", commentIDs(current))
+	}
+	if !slices.Equal(commentIDs(outdated), []int64{2}) {
+		t.Errorf("excludeOutdated() outdated = %v, want [2]", commentIDs(outdated))
+	}
+
+	current, outdated = excludeOutdated(suggestions, true)
+	if len(current) != 3 || len(outdated) != 0 {
+		t.Errorf("excludeOutdated() with include = %v, %v; want everything kept", commentIDs(current), commentIDs(outdated))
+	}
+}
+
+func TestExcludeFileLevel(t *testing.T) {
+	suggestions := []*github.ReviewComment{
+		{ID: 1, Path: "main.go", Line: 4},
+		{ID: 2, Path: "go.mod", IsFileLevel: true},
+		{ID: 3, Path: "util.go", Line: 9},
+	}
+
+	placed, fileLevel := excludeFileLevel(suggestions)
+	if !slices.Equal(commentIDs(placed), []int64{1, 3}) {
+		t.Errorf("excludeFileLevel() placed = %v, want [1 3]", commentIDs(placed))
+	}
+	if !slices.Equal(commentIDs(fileLevel), []int64{2}) {
+		t.Errorf("excludeFileLevel() file-level = %v, want [2]", commentIDs(fileLevel))
+	}
+}
+
+func commentIDs(comments []*github.ReviewComment) []int64 {
+	ids := make([]int64, 0, len(comments))
+	for _, comment := range comments {
+		ids = append(ids, comment.ID)
+	}
+	return ids
+}
+
+func TestConfirmBranch(t *testing.T) {
+	tests := []struct {
+		name    string
+		branch  string
+		head    string
+		force   bool
+		input   string
+		wantErr bool
+	}{
+		{"same branch", "fix-it", "fix-it", false, "", false},
+		{"unknown head branch", "main", "", false, "", false},
+		{"other branch with --force", "main", "fix-it", true, "", false},
+		{"other branch confirmed", "main", "fix-it", false, "y\n", false},
+		{"other branch declined", "main", "fix-it", false, "n\n", true},
+		{"other branch without an answer", "main", "fix-it", false, "", true},
+		{"detached HEAD declined", "", "fix-it", false, "\n", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := bufio.NewReader(strings.NewReader(tt.input))
+			err := confirmBranch(in, tt.branch, tt.head, 7, tt.force)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("confirmBranch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFormatBatchSummary(t *testing.T) {
+	suggestions := []*github.ReviewComment{
+		{ID: 1, Path: "pkg/b.go"},
+		{ID: 2, Path: "a.go"},
+		{ID: 3, Path: "pkg/b.go"},
+	}
+	originalEnabled := ui.ColorsEnabled()
+	ui.SetColorEnabled(false)
+	t.Cleanup(func() { ui.SetColorEnabled(originalEnabled) })
+
+	got, files := formatBatchSummary(suggestions)
+	want := "Files to modify:\n  a.go (1)\n  pkg/b.go (2)\n"
+	if got != want {
+		t.Errorf("formatBatchSummary() = %q, want %q", got, want)
+	}
+	if files != 2 {
+		t.Errorf("formatBatchSummary() files = %d, want 2", files)
+	}
+}
+
+func TestConfirmBatchApply(t *testing.T) {
+	suggestions := []*github.ReviewComment{{ID: 1, Path: "a.go"}}
+	tests := []struct {
+		name     string
+		yes      bool
+		terminal bool
+		input    string
+		want     bool
+		wantErr  bool
+	}{
+		{"--yes", true, false, "", true, false},
+		{"confirmed", false, true, "y\n", true, false},
+		{"declined", false, true, "n\n", false, false},
+		{"default answer", false, true, "\n", false, false},
+		{"without an answer", false, true, "", false, false},
+		{"without a terminal", false, false, "y\n", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orig := stdinIsTerminal
+			stdinIsTerminal = func() bool { return tt.terminal }
+			defer func() { stdinIsTerminal = orig }()
+
+			in := bufio.NewReader(strings.NewReader(tt.input))
+			got, err := confirmBatchApply(in, suggestions, tt.yes)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("confirmBatchApply() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("confirmBatchApply() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLiteralFile(t *testing.T) {
+	tests := []struct {
+		files []string
+		want  string
+	}{
+		{nil, ""},
+		{[]string{"./pkg/main.go"}, "pkg/main.go"},
+		{[]string{"pkg/*.go"}, ""},
+		{[]string{"a.go", "b.go"}, ""},
+	}
+
+	for _, tt := range tests {
+		if got := literalFile(tt.files); got != tt.want {
+			t.Errorf("literalFile(%q) = %q, want %q", tt.files, got, tt.want)
+		}
+	}
+}
+
+func TestValidateAIGeneration(t *testing.T) {
+	temp := func(v float64) *float64 { return &v }
+	tests := []struct {
+		name       string
+		generation ai.GenerationConfig
+		providers  []string
+		wantErr    string
+	}{
+		{"defaults", ai.GenerationConfig{}, []string{"anthropic"}, ""},
+		{"in range", ai.GenerationConfig{Temperature: temp(0), MaxTokens: 2048}, []string{"gemini"}, ""},
+		{"upper bound", ai.GenerationConfig{Temperature: temp(2)}, []string{"openai"}, ""},
+		{"negative temperature", ai.GenerationConfig{Temperature: temp(-0.1)}, []string{"gemini"}, "--ai-temperature"},
+		{"temperature too high", ai.GenerationConfig{Temperature: temp(2.5)}, []string{"gemini"}, "--ai-temperature must be between 0 and 2"},
+		{"above the Anthropic range", ai.GenerationConfig{Temperature: temp(1.5)}, []string{"anthropic"}, "between 0 and 1 for Anthropic"},
+		{"Anthropic as a fallback", ai.GenerationConfig{Temperature: temp(1.5)}, []string{"gemini", "claude"}, "between 0 and 1 for Anthropic"},
+		{"Anthropic upper bound", ai.GenerationConfig{Temperature: temp(1)}, []string{"anthropic"}, ""},
+		{"negative max tokens", ai.GenerationConfig{MaxTokens: -1}, []string{"gemini"}, "--ai-max-tokens"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAIGeneration(tt.generation, tt.providers)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validateAIGeneration() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validateAIGeneration() error = %v, want it to mention %s", err, tt.wantErr)
+			}
+		})
+	}
+}
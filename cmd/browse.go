@@ -1,15 +1,18 @@
 package cmd
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
-	"os/exec"
+	"os"
 	"regexp"
-	"runtime"
 	"strconv"
 	"strings"
 
+	"github.com/chmouel/gh-prreview/pkg/applier"
+	"github.com/chmouel/gh-prreview/pkg/config"
 	"github.com/chmouel/gh-prreview/pkg/github"
+	"github.com/chmouel/gh-prreview/pkg/state"
 	"github.com/chmouel/gh-prreview/pkg/ui"
 	"github.com/spf13/cobra"
 )
@@ -20,7 +23,12 @@ var (
 	markdownLinkRe  = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
 )
 
-var browseDebug bool
+var (
+	browseExcludeMe   bool
+	browseJSON        bool
+	browseSuggestions bool
+	browseDiscussion  bool
+)
 
 var browseCmd = &cobra.Command{
 	Use:   "browse [PR_NUMBER] [COMMENT_ID]",
@@ -29,29 +37,40 @@ var browseCmd = &cobra.Command{
 
 When no arguments are provided, PR is inferred from the current branch and you can interactively select a comment.
 When one argument is provided, it's treated as COMMENT_ID and PR is inferred from the current branch.
-When two arguments are provided, the first is PR_NUMBER and the second is COMMENT_ID.`,
+When two arguments are provided, the first is PR_NUMBER and the second is COMMENT_ID.
+A comment URL copied from the browser (...pull/123#discussion_r456) can be given instead of COMMENT_ID; the PR and repository come from the URL.`,
 	Args: cobra.MaximumNArgs(2),
 	RunE: runBrowse,
 }
 
 func init() {
-	browseCmd.Flags().BoolVar(&browseDebug, "debug", false, "Enable debug output")
+	browseCmd.Flags().BoolVar(&browseExcludeMe, "exclude-me", false, "Hide comments authored by the current user")
+	browseCmd.Flags().BoolVar(&browseSuggestions, "suggestions-only", false, "Only show comments with a suggestion")
+	browseCmd.Flags().BoolVar(&browseDiscussion, "discussion-only", false, "Only show comments without a suggestion")
+	browseCmd.Flags().BoolVar(&browseJSON, "json", false, "Print the comment tree (files with their comments) as JSON instead of starting the browser")
 }
 
 func runBrowse(cmd *cobra.Command, args []string) error {
-	// Enable UI debug output if requested
-	ui.SetUIDebug(browseDebug)
-
 	// Start warming up the markdown renderer in the background
 	// This initializes glamour/chroma before the user needs it
 	ui.WarmupMarkdownRenderer()
 
 	client := github.NewClient()
-	client.SetDebug(browseDebug)
 	if repoFlag != "" {
 		client.SetRepo(repoFlag)
 	}
 
+	if err := checkKindFlags(browseSuggestions, browseDiscussion); err != nil {
+		return err
+	}
+
+	if browseJSON {
+		if len(args) > 1 {
+			return fmt.Errorf("--json accepts at most a PR_NUMBER argument")
+		}
+		return printBrowseJSON(client, args)
+	}
+
 	var prNumber int
 	var commentID int64
 	var err error
@@ -64,23 +83,39 @@ func runBrowse(cmd *cobra.Command, args []string) error {
 			return err
 		}
 
-		comments, err := client.FetchReviewComments(prNumber)
+		comments, err := fetchReviewComments(client, prNumber)
 		if err != nil {
 			return fmt.Errorf("failed to fetch review comments: %w", err)
 		}
+		comments, err = filterBrowseComments(client, comments)
+		if err != nil {
+			return err
+		}
 		if len(comments) == 0 {
 			fmt.Printf("No review comments found in %s\n",
-				ui.CreateHyperlink(fmt.Sprintf("https://github.com/%s/pull/%d", getRepoFromClient(client), prNumber),
+				ui.CreateHyperlink(prURL(client, prNumber),
 					ui.Colorize(ui.ColorCyan, fmt.Sprintf("PR #%d", prNumber))))
 			return nil
 		}
 
-		// Track collapsed state
+		// Without a terminal (or with --plain), print what list would
+		if !ui.Interactive() {
+			fmt.Printf("Found %d review comment(s):\n", len(comments))
+			displayComments(comments, false)
+			return nil
+		}
+
+		// Track collapsed state, restoring the previous session for this PR
+		repo := getRepoFromClient(client)
 		collapsedFiles := make(map[string]bool)
+		browseStore, saved := loadBrowseState(repo, prNumber)
+		for _, path := range saved.CollapsedFiles {
+			collapsedFiles[path] = true
+		}
 
 		// Use interactive selector with resolve action
 		renderer := &browseItemRenderer{
-			repo:           getRepoFromClient(client),
+			repo:           repo,
 			prNumber:       prNumber,
 			collapsedFiles: collapsedFiles,
 		}
@@ -105,39 +140,22 @@ func runBrowse(cmd *cobra.Command, args []string) error {
 			if item.Comment.HTMLURL == "" {
 				return "", fmt.Errorf("comment has no URL")
 			}
-			if err := openURLInBrowser(item.Comment.HTMLURL); err != nil {
+			if err := ui.OpenURL(item.Comment.HTMLURL); err != nil {
 				return "", err
 			}
 			return fmt.Sprintf("Opened comment %d in browser", item.Comment.ID), nil
 		}
 
 		// Filter function (hide resolved and collapsed)
-		filterFunc := func(item BrowseItem, hideResolved bool) bool {
-			// 1. Check collapse state (Always applies)
-			if (item.Type == "comment" || item.Type == "comment_preview") && collapsedFiles[item.Path] {
-				return false
-			}
+		filterFunc := browseFilter(collapsedFiles)
 
-			// 2. Check resolved state (Only if hideResolved is true)
-			if hideResolved {
-				if item.Type == "file" {
-					return true // Always show headers
-				}
-				return !item.Comment.IsResolved()
-			}
+		// Handle selection (Enter key): a file header folds or unfolds its
+		// comments, a comment opens the detail view (from the cached data)
+		onSelect := toggleCollapsed(collapsedFiles)
 
-			return true
-		}
-
-		// Handle selection (Enter key)
-		onSelect := func(item BrowseItem) (string, error) {
-			if item.Type == "file" {
-				collapsedFiles[item.Path] = !collapsedFiles[item.Path]
-				return "", nil // Just refresh
-			}
-
-			// Use cached data from initial fetch - no additional API calls
-			return "SHOW_DETAIL", nil
+		// -/+ keys: collapse or expand every file
+		collapseAll := func(items []BrowseItem, collapse bool) string {
+			return setAllCollapsed(collapsedFiles, items, collapse)
 		}
 
 		// Editor actions for R (resolve with comment)
@@ -254,6 +272,10 @@ func runBrowse(cmd *cobra.Command, args []string) error {
 			if err != nil {
 				return nil, err
 			}
+			freshComments, err = filterBrowseComments(client, freshComments)
+			if err != nil {
+				return nil, err
+			}
 			return buildCommentTree(freshComments), nil
 		}
 
@@ -268,19 +290,65 @@ func runBrowse(cmd *cobra.Command, args []string) error {
 			if item.SelectedCommentIdx > 0 && item.SelectedCommentIdx-1 < len(comment.ThreadComments) {
 				body = comment.ThreadComments[item.SelectedCommentIdx-1].Body
 			}
-			prompt := fmt.Sprintf("Review comment on %s:%d\n\n%s",
-				comment.Path,
-				comment.Line,
+			prompt := fmt.Sprintf("Review comment on %s\n\n%s",
+				comment.LocationLabel(),
 				body)
 			return "LAUNCH_AGENT:" + prompt, nil
 		}
 
 		// Edit action - open file in editor at comment line
 		editAction := func(item BrowseItem) (string, error) {
+			return fmt.Sprintf("EDIT_FILE:%s:%d", renderer.EditPath(item), renderer.EditLine(item)), nil
+		}
+
+		// Apply action - apply the comment's suggestion to the local file
+		suggestionApplier := applier.New()
+		suggestionApplier.SetGitHubClient(client)
+		if store, err := state.DefaultAppliedStore(); err == nil {
+			suggestionApplier.SetAppliedStore(store)
+		}
+		if store, err := state.DefaultOutcomeStore(); err == nil {
+			suggestionApplier.SetOutcomeStore(store)
+		}
+		applyAction := func(item BrowseItem) (string, error) {
+			if item.Type == "file" || !item.Comment.HasSuggestion {
+				return "", fmt.Errorf("no suggestion to apply")
+			}
+			comment := item.Comment
+			cfg, err := config.Load()
+			if err != nil {
+				return "", err
+			}
+			if pattern, ok := cfg.ProtectedPattern(comment.Path); ok {
+				return "", fmt.Errorf("%s is protected (matches %q), use 'apply --force --comment-id %d'", comment.Path, pattern, comment.ID)
+			}
+			if err := suggestionApplier.Apply(comment); err != nil {
+				if errors.Is(err, applier.ErrAlreadyApplied) {
+					return ui.Colorize(ui.ColorGray, fmt.Sprintf("Suggestion already applied to %s", comment.LocationLabel())), nil
+				}
+				return "", fmt.Errorf("failed to apply suggestion: %w", err)
+			}
+			status := fmt.Sprintf("Applied suggestion to %s", comment.LocationLabel())
+			if !comment.IsResolved() {
+				status += " (r to resolve the thread)"
+			}
+			return ui.Colorize(ui.ColorGreen, status), nil
+		}
+
+		// Copy actions - the link or ID of the comment (or selected reply)
+		copyLinkAction := func(item BrowseItem) (string, error) {
 			if item.Type == "file" {
-				return "", fmt.Errorf("cannot edit file header")
+				return "", fmt.Errorf("no comment to copy")
 			}
-			return fmt.Sprintf("EDIT_FILE:%s:%d", item.Comment.Path, item.Comment.Line), nil
+			_, url := browseItemRef(item)
+			return copyStatus("link", url), nil
+		}
+		copyIDAction := func(item BrowseItem) (string, error) {
+			if item.Type == "file" {
+				return "", fmt.Errorf("no comment to copy")
+			}
+			id, _ := browseItemRef(item)
+			return copyStatus("ID", strconv.FormatInt(id, 10)), nil
 		}
 
 		// Reaction action - get comment ID for reaction
@@ -308,7 +376,7 @@ func runBrowse(cmd *cobra.Command, args []string) error {
 				// Return a success message without the URL.
 				return fmt.Sprintf("%s reaction added", emoji), nil
 			}
-			url := fmt.Sprintf("https://github.com/%s/pull/%d#discussion_r%d", repo, prNumber, commentID)
+			url := fmt.Sprintf("%s/%s/pull/%d#discussion_r%d", client.BaseWebURL(), repo, prNumber, commentID)
 			return fmt.Sprintf("%s reaction added at %s", emoji, url), nil
 		}
 
@@ -323,6 +391,16 @@ func runBrowse(cmd *cobra.Command, args []string) error {
 			IsItemResolved: isItemResolved,
 			RefreshItems:   refreshItems,
 
+			// n/N key: jump between unresolved comments
+			JumpTarget: func(item BrowseItem) bool {
+				return item.Type == "comment" && !item.IsPreview && !item.Comment.IsResolved()
+			},
+			JumpKey: "n/N next/prev unresolved",
+
+			// -/+ key: collapse/expand all files
+			CollapseAllAction: collapseAll,
+			CollapseAllKey:    "-/+ collapse/expand all",
+
 			// r/u key: resolve/unresolve
 			ResolveAction: resolveAction,
 			ResolveKey:    "r resolve",
@@ -348,14 +426,32 @@ func runBrowse(cmd *cobra.Command, args []string) error {
 			AgentAction: agentAction,
 			AgentKey:    "a agent",
 
+			// A key: apply suggestion (detail view)
+			ApplyAction: applyAction,
+			ApplyKey:    "A apply suggestion",
+
 			// e key: edit file
 			EditAction: editAction,
-			EditKey:    "e edit",
+			EditKey:    "e/ctrl+e edit file at line",
 
 			// x key: add reaction
 			ReactionAction:   reactionAction,
 			ReactionComplete: reactionComplete,
 			ReactionKey:      "x react",
+
+			// y/Y keys: copy the comment link/ID
+			CopyLinkAction: copyLinkAction,
+			CopyLinkKey:    "y copy link",
+			CopyIDAction:   copyIDAction,
+			CopyIDKey:      "Y copy ID",
+
+			// Restore the cursor and remember where it was left
+			InitialSelect: func(item BrowseItem) bool {
+				return browseItemMatches(item, saved)
+			},
+			OnExit: func(item BrowseItem) {
+				saveBrowseState(browseStore, repo, prNumber, collapsedFiles, item)
+			},
 		})
 		if err != nil {
 			if errors.Is(err, ui.ErrNoSelection) {
@@ -375,15 +471,23 @@ func runBrowse(cmd *cobra.Command, args []string) error {
 
 		commentID = selected.Comment.ID
 	} else if len(args) == 1 {
-		// One argument: treat as COMMENT_ID, infer PR from current branch
-		commentID, err = strconv.ParseInt(args[0], 10, 64)
-		if err != nil {
-			return fmt.Errorf("invalid comment ID: %s", args[0])
-		}
-		prNumber, err = getPRNumberWithSelection([]string{}, client)
+		// One argument: a comment URL, which has both the PR and the
+		// COMMENT_ID, or a COMMENT_ID with the PR inferred from the branch
+		var isURL bool
+		prNumber, commentID, isURL, err = commentURLArg(client, args[0])
 		if err != nil {
 			return err
 		}
+		if !isURL {
+			commentID, err = strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid comment ID: %s", args[0])
+			}
+			prNumber, err = getPRNumberWithSelection([]string{}, client)
+			if err != nil {
+				return err
+			}
+		}
 	} else if len(args) == 2 {
 		// Two arguments: first is PR, second is COMMENT_ID
 		prNumber, err = strconv.Atoi(args[0])
@@ -403,7 +507,7 @@ func runBrowse(cmd *cobra.Command, args []string) error {
 func openCommentInBrowser(client *github.Client, prNumber int, commentID int64) error {
 	// Fetch review comments to find the comment URL
 	// Note: This function is only used from CLI path where we don't have cached data
-	comments, err := client.FetchReviewComments(prNumber)
+	comments, err := fetchReviewComments(client, prNumber)
 	if err != nil {
 		return fmt.Errorf("failed to fetch review comments: %w", err)
 	}
@@ -421,29 +525,30 @@ func openCommentInBrowser(client *github.Client, prNumber int, commentID int64)
 		return fmt.Errorf("comment ID %d not found in PR #%d", commentID, prNumber)
 	}
 
-	return openURLInBrowser(commentURL)
+	return ui.OpenURL(commentURL)
 }
 
-// openURLInBrowser opens the given URL in the system's default browser
-func openURLInBrowser(url string) error {
-	var openCmd *exec.Cmd
-
-	switch runtime.GOOS {
-	case "darwin":
-		openCmd = exec.Command("open", url)
-	case "linux":
-		openCmd = exec.Command("xdg-open", url)
-	case "windows":
-		openCmd = exec.Command("cmd", "/c", "start", url)
-	default:
-		openCmd = exec.Command("xdg-open", url)
+// browseItemRef returns the ID and URL of the comment of a browse item, or
+// of its selected reply
+func browseItemRef(item BrowseItem) (int64, string) {
+	comment := item.Comment
+	if item.SelectedCommentIdx > 0 && item.SelectedCommentIdx-1 < len(comment.ThreadComments) {
+		reply := comment.ThreadComments[item.SelectedCommentIdx-1]
+		return reply.ID, reply.HTMLURL
 	}
+	return comment.ID, comment.HTMLURL
+}
 
-	if err := openCmd.Start(); err != nil {
-		return fmt.Errorf("failed to open browser: %w", err)
+// copyStatus copies value to the clipboard and returns the status message to
+// show. Without a clipboard tool the value is shown instead, to copy by hand.
+func copyStatus(what, value string) string {
+	if err := copyToClipboard(value); err != nil {
+		if errors.Is(err, errNoClipboard) {
+			return ui.Colorize(ui.ColorYellow, fmt.Sprintf("No clipboard tool found, %s: %s", what, value))
+		}
+		return ui.Colorize(ui.ColorRed, fmt.Sprintf("Failed to copy the %s: %v", what, err))
 	}
-
-	return nil
+	return ui.Colorize(ui.ColorGreen, fmt.Sprintf("Copied %s %s", what, value))
 }
 
 // BrowseItem represents an item in the browse list (either a file header or a comment)
@@ -455,6 +560,193 @@ type BrowseItem struct {
 	SelectedCommentIdx int // 0 = main comment, 1+ = thread reply index
 }
 
+// loadBrowseState returns the browse state saved for the PR by a previous
+// session. The store is nil when the state directory is unavailable, in
+// which case the session is simply not persisted.
+func loadBrowseState(repo string, prNumber int) (*state.BrowseStore, state.BrowseState) {
+	store, err := state.DefaultBrowseStore()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Note: browse state will not be saved: %v\n", err)
+		return nil, state.BrowseState{}
+	}
+	saved, err := store.Load(repo, prNumber)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Note: ignoring saved browse state: %v\n", err)
+		return store, state.BrowseState{}
+	}
+	return store, saved
+}
+
+// saveBrowseState records the collapsed files and the highlighted item
+func saveBrowseState(store *state.BrowseStore, repo string, prNumber int, collapsedFiles map[string]bool, selected BrowseItem) {
+	if store == nil {
+		return
+	}
+	browse := state.BrowseState{SelectedPath: selected.Path}
+	if selected.Type != "file" && selected.Comment != nil {
+		browse.SelectedCommentID = selected.Comment.ID
+	}
+	for path, collapsed := range collapsedFiles {
+		if collapsed {
+			browse.CollapsedFiles = append(browse.CollapsedFiles, path)
+		}
+	}
+	if err := store.Save(repo, prNumber, browse); err != nil {
+		fmt.Fprintf(os.Stderr, "Note: failed to save browse state: %v\n", err)
+	}
+}
+
+// browseItemMatches reports whether item is the one highlighted when the
+// saved session ended
+func browseItemMatches(item BrowseItem, saved state.BrowseState) bool {
+	if saved.SelectedCommentID != 0 {
+		return item.Type != "file" && item.Comment != nil && item.Comment.ID == saved.SelectedCommentID
+	}
+	return saved.SelectedPath != "" && item.Type == "file" && item.Path == saved.SelectedPath
+}
+
+// browseTreeFile is a file of the browse --json output
+type browseTreeFile struct {
+	Path     string              `json:"path"`
+	Comments []browseTreeComment `json:"comments"`
+}
+
+// browseTreeComment is a review comment thread of the browse --json output
+type browseTreeComment struct {
+	ID        int64  `json:"id"`
+	Line      int    `json:"line"`
+	FileLevel bool   `json:"file_level,omitempty"`
+	Author    string `json:"author"`
+	Resolved  bool   `json:"resolved"`
+	Outdated  bool   `json:"outdated"`
+	Replies   int    `json:"replies"`
+	Preview   string `json:"preview"`
+	URL       string `json:"url"`
+}
+
+// browseTree turns the items of buildCommentTree into nested files and
+// comments, the structure the browse UI shows
+func browseTree(items []BrowseItem) []browseTreeFile {
+	files := make([]browseTreeFile, 0)
+	for _, item := range items {
+		switch item.Type {
+		case "file":
+			files = append(files, browseTreeFile{Path: item.Path, Comments: []browseTreeComment{}})
+		case "comment":
+			if len(files) == 0 {
+				continue
+			}
+			comment := item.Comment
+			line := comment.Line
+			if line == 0 {
+				line = comment.OriginalLine
+			}
+			preview, _, _ := strings.Cut(ui.StripSuggestionBlock(comment.Body), "\n")
+			file := &files[len(files)-1]
+			file.Comments = append(file.Comments, browseTreeComment{
+				ID:        comment.ID,
+				Line:      line,
+				FileLevel: comment.IsFileLevel,
+				Author:    comment.Author,
+				Resolved:  comment.IsResolved(),
+				Outdated:  comment.IsOutdated,
+				Replies:   len(comment.ThreadComments),
+				Preview:   ui.Truncate(strings.TrimSpace(preview), 80),
+				URL:       comment.HTMLURL,
+			})
+		}
+	}
+	return files
+}
+
+// printBrowseJSON prints the comment tree of the PR as JSON, for editor
+// plugins that build their own navigation
+func printBrowseJSON(client *github.Client, args []string) error {
+	prNumber, err := getPRNumberWithSelection(args, client)
+	if err != nil {
+		return err
+	}
+	comments, err := fetchReviewComments(client, prNumber)
+	if err != nil {
+		return fmt.Errorf("failed to fetch review comments: %w", err)
+	}
+	comments, err = filterBrowseComments(client, comments)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(browseTree(buildCommentTree(comments))); err != nil {
+		return fmt.Errorf("failed to encode comment tree: %w", err)
+	}
+	return nil
+}
+
+// browseFilter returns the selector filter of the comment tree: comments of
+// collapsed files are hidden, and resolved ones when hideResolved is set
+func browseFilter(collapsedFiles map[string]bool) func(BrowseItem, bool) bool {
+	return func(item BrowseItem, hideResolved bool) bool {
+		// 1. Check collapse state (Always applies)
+		if (item.Type == "comment" || item.Type == "comment_preview") && collapsedFiles[item.Path] {
+			return false
+		}
+
+		// 2. Check resolved state (Only if hideResolved is true)
+		if hideResolved {
+			if item.Type == "file" {
+				return true // Always show headers
+			}
+			return !item.Comment.IsResolved()
+		}
+
+		return true
+	}
+}
+
+// toggleCollapsed returns the enter action of the comment tree: a file
+// header folds or unfolds its comments, anything else is left to the
+// detail view
+func toggleCollapsed(collapsedFiles map[string]bool) ui.CustomAction[BrowseItem] {
+	return func(item BrowseItem) (string, error) {
+		if item.Type == "file" {
+			collapsedFiles[item.Path] = !collapsedFiles[item.Path]
+			if collapsedFiles[item.Path] {
+				return "Collapsed " + item.Path, nil
+			}
+			return "Expanded " + item.Path, nil
+		}
+		return "", nil
+	}
+}
+
+// setAllCollapsed marks every file of items as collapsed, or none of them,
+// and returns the status message to show
+func setAllCollapsed(collapsedFiles map[string]bool, items []BrowseItem, collapse bool) string {
+	clear(collapsedFiles)
+	if !collapse {
+		return "Expanded all files"
+	}
+	files := 0
+	for _, item := range items {
+		if item.Type == "file" {
+			collapsedFiles[item.Path] = true
+			files++
+		}
+	}
+	return fmt.Sprintf("Collapsed %d file(s)", files)
+}
+
+// filterBrowseComments applies the --exclude-me, --suggestions-only and
+// --discussion-only filters
+func filterBrowseComments(client *github.Client, comments []*github.ReviewComment) ([]*github.ReviewComment, error) {
+	comments, err := excludeOwnComments(client, comments, browseExcludeMe)
+	if err != nil {
+		return nil, err
+	}
+	return filterByKind(comments, browseSuggestions, browseDiscussion), nil
+}
+
 // buildCommentTree converts a flat list of comments into a tree-like structure
 func buildCommentTree(comments []*github.ReviewComment) []BrowseItem {
 	// Sort comments by Path then Line
@@ -563,8 +855,8 @@ func (r *browseItemRenderer) Title(item BrowseItem) string {
 		preview := "..."
 		if len(lines) > 0 {
 			preview = lines[0]
-			if len(preview) > 80 {
-				preview = preview[:77] + "..."
+			if truncated := ui.Truncate(preview, 80); truncated != preview {
+				preview = truncated
 			} else if len(lines) > 1 {
 				preview += "..."
 			}
@@ -575,7 +867,18 @@ func (r *browseItemRenderer) Title(item BrowseItem) string {
 	// Comment Metadata
 	style := ui.NewReviewListStyle(item.Comment.Author, item.Comment.IsResolved())
 	// Indent with tree structure
-	return fmt.Sprintf("  └── %s Line %d %s", style.FormatCommentTitle(item.Comment.ID), item.Comment.Line, style.Status.Format(true))
+	lines, label := item.Comment.LinesLabel(), "Line"
+	if strings.Contains(lines, "-") {
+		label = "Lines"
+	}
+	title := fmt.Sprintf("  └── %s %s %s %s", style.FormatCommentTitle(item.Comment.ID), label, lines, style.Status.Format(true))
+	if item.Comment.IsFileLevel {
+		title = fmt.Sprintf("  └── %s File-level comment %s", style.FormatCommentTitle(item.Comment.ID), style.Status.Format(true))
+	}
+	if replies := len(item.Comment.ThreadComments); replies > 0 {
+		title += " " + ui.Colorize(ui.ColorGray, ui.EmojiText(fmt.Sprintf("💬 %d", replies), fmt.Sprintf("[%d replies]", replies)))
+	}
+	return title
 }
 
 func (r *browseItemRenderer) Description(item BrowseItem) string {
@@ -603,7 +906,7 @@ func (r *browseItemRenderer) PreviewWithHighlight(item BrowseItem, highlightIdx
 		statusColor = ui.ColorGreen
 	}
 	preview.WriteString(ui.Colorize(ui.ColorCyan, fmt.Sprintf("Author: @%s\n", comment.Author)))
-	preview.WriteString(ui.Colorize(ui.ColorCyan, fmt.Sprintf("Location: %s:%d\n", comment.Path, comment.Line)))
+	preview.WriteString(ui.Colorize(ui.ColorCyan, fmt.Sprintf("Location: %s\n", comment.LocationDescription())))
 	preview.WriteString(ui.Colorize(ui.ColorCyan, fmt.Sprintf("Status: %s\n", ui.Colorize(statusColor, status))))
 	if comment.HTMLURL != "" {
 		preview.WriteString(ui.Colorize(ui.ColorCyan, fmt.Sprintf("URL: %s\n", ui.CreateHyperlink(comment.HTMLURL, comment.HTMLURL))))
@@ -637,7 +940,7 @@ func (r *browseItemRenderer) PreviewWithHighlight(item BrowseItem, highlightIdx
 			preview.WriteString(rendered)
 		} else {
 			// Fallback to wrapped text
-			preview.WriteString(ui.WrapText(body, 80))
+			preview.WriteString(ui.WrapText(body, ui.TextWidth()))
 		}
 		preview.WriteString("\n")
 		if highlightIdx == 0 {
@@ -704,7 +1007,7 @@ func (r *browseItemRenderer) PreviewWithHighlight(item BrowseItem, highlightIdx
 			if err == nil && rendered != "" {
 				preview.WriteString(rendered)
 			} else {
-				preview.WriteString(ui.WrapText(replyBody, 80))
+				preview.WriteString(ui.WrapText(replyBody, ui.TextWidth()))
 			}
 			preview.WriteString("\n")
 
@@ -718,14 +1021,26 @@ func (r *browseItemRenderer) PreviewWithHighlight(item BrowseItem, highlightIdx
 }
 
 func (r *browseItemRenderer) EditPath(item BrowseItem) string {
+	if item.Type != "file" && item.Comment != nil && item.Comment.Path != "" {
+		return item.Comment.Path
+	}
 	return item.Path
 }
 
+// EditLine returns the line the editor should open at. File headers open
+// at the top of the file, and comments without a current line (outdated
+// ones) fall back to their original line.
 func (r *browseItemRenderer) EditLine(item BrowseItem) int {
-	if item.Type == "file" {
-		return 0
+	if item.Type == "file" || item.Comment == nil {
+		return 1
+	}
+	if item.Comment.Line > 0 {
+		return item.Comment.Line
 	}
-	return item.Comment.Line
+	if item.Comment.OriginalLine > 0 {
+		return item.Comment.OriginalLine
+	}
+	return 1
 }
 
 func (r *browseItemRenderer) FilterValue(item BrowseItem) string {
@@ -772,10 +1087,8 @@ func (r *browseItemRenderer) ThreadCommentPreview(item BrowseItem, idx int) stri
 		}
 	}
 	body = strings.Join(nonQuotedLines, " ")
-	// Truncate to ~100 chars
-	if len(body) > 100 {
-		body = body[:97] + "..."
-	}
+	// Truncate to ~100 columns
+	body = ui.Truncate(body, 100)
 	return fmt.Sprintf("@%s: %s", author, body)
 }
 
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/chmouel/gh-prreview/pkg/github"
+	"github.com/chmouel/gh-prreview/pkg/ui"
+)
+
+func TestBrowseItemRendererTitleReplyCount(t *testing.T) {
+	renderer := &browseItemRenderer{collapsedFiles: map[string]bool{}}
+	single := &github.ReviewComment{ID: 1, Path: "main.go", Line: 3, Author: "reviewer"}
+	thread := &github.ReviewComment{
+		ID: 2, Path: "main.go", Line: 8, Author: "reviewer",
+		ThreadComments: []github.ThreadComment{{ID: 3}, {ID: 4}},
+	}
+
+	tests := []struct {
+		name      string
+		colors    bool
+		comment   *github.ReviewComment
+		wantBadge string
+	}{
+		{"emoji badge for a thread", true, thread, "💬 2"},
+		{"plain badge for a thread", false, thread, "[2 replies]"},
+		{"no badge without replies", true, single, ""},
+		{"no plain badge without replies", false, single, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previous := ui.ColorsEnabled()
+			ui.SetColorEnabled(tt.colors)
+			defer ui.SetColorEnabled(previous)
+
+			title := renderer.Title(BrowseItem{Type: "comment", Path: tt.comment.Path, Comment: tt.comment})
+			if tt.wantBadge == "" {
+				if strings.Contains(title, "💬") || strings.Contains(title, "replies]") {
+					t.Errorf("Title() = %q, want no reply badge", title)
+				}
+				return
+			}
+			if !strings.Contains(title, tt.wantBadge) {
+				t.Errorf("Title() = %q, want it to contain %q", title, tt.wantBadge)
+			}
+		})
+	}
+}
+
+func TestBrowseItemRendererEditTarget(t *testing.T) {
+	renderer := &browseItemRenderer{collapsedFiles: map[string]bool{}}
+
+	tests := []struct {
+		name     string
+		item     BrowseItem
+		wantPath string
+		wantLine int
+	}{
+		{"file header opens at the top", BrowseItem{Type: "file", Path: "a.go"}, "a.go", 1},
+		{
+			"comment opens at its line",
+			BrowseItem{Type: "comment", Path: "a.go", Comment: &github.ReviewComment{Path: "a.go", Line: 12, OriginalLine: 10}},
+			"a.go", 12,
+		},
+		{
+			"outdated comment falls back to the original line",
+			BrowseItem{Type: "comment", Path: "a.go", Comment: &github.ReviewComment{Path: "a.go", OriginalLine: 10}},
+			"a.go", 10,
+		},
+		{
+			"file-level comment opens at the top",
+			BrowseItem{Type: "comment", Path: "a.go", Comment: &github.ReviewComment{Path: "a.go"}},
+			"a.go", 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := renderer.EditPath(tt.item); got != tt.wantPath {
+				t.Errorf("EditPath() = %q, want %q", got, tt.wantPath)
+			}
+			if got := renderer.EditLine(tt.item); got != tt.wantLine {
+				t.Errorf("EditLine() = %d, want %d", got, tt.wantLine)
+			}
+		})
+	}
+}
+
+func TestBrowseTree(t *testing.T) {
+	comments := []*github.ReviewComment{
+		{ID: 3, Path: "pkg/b.go", Line: 9, Author: "bob", Body: "Rename this\nplease", SubjectType: "resolved", HTMLURL: "u3"},
+		{ID: 1, Path: "a.go", Line: 7, Author: "alice", Body: "Later line", HTMLURL: "u1"},
+		{
+			ID: 2, Path: "a.go", OriginalLine: 2, Author: "carol", IsOutdated: true, HTMLURL: "u2",
+			Body:           "```suggestion\nx := 1\n```",
+			ThreadComments: []github.ThreadComment{{ID: 4}},
+		},
+	}
+
+	got := browseTree(buildCommentTree(comments))
+	want := []browseTreeFile{
+		{Path: "a.go", Comments: []browseTreeComment{
+			{ID: 2, Line: 2, Author: "carol", Outdated: true, Replies: 1, URL: "u2"},
+			{ID: 1, Line: 7, Author: "alice", Preview: "Later line", URL: "u1"},
+		}},
+		{Path: "pkg/b.go", Comments: []browseTreeComment{
+			{ID: 3, Line: 9, Author: "bob", Resolved: true, Preview: "Rename this", URL: "u3"},
+		}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("browseTree() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestSetAllCollapsed(t *testing.T) {
+	items := buildCommentTree([]*github.ReviewComment{
+		{ID: 1, Path: "a.go", Line: 1},
+		{ID: 2, Path: "a.go", Line: 9},
+		{ID: 3, Path: "b.go", Line: 4},
+	})
+	collapsed := map[string]bool{"old.go": true}
+
+	if got := setAllCollapsed(collapsed, items, true); got != "Collapsed 2 file(s)" {
+		t.Errorf("setAllCollapsed(collapse) = %q", got)
+	}
+	if want := map[string]bool{"a.go": true, "b.go": true}; !reflect.DeepEqual(collapsed, want) {
+		t.Errorf("collapsed files = %v, want %v", collapsed, want)
+	}
+
+	if got := setAllCollapsed(collapsed, items, false); got != "Expanded all files" {
+		t.Errorf("setAllCollapsed(expand) = %q", got)
+	}
+	if len(collapsed) != 0 {
+		t.Errorf("collapsed files = %v, want none", collapsed)
+	}
+}
+
+func TestBrowseFilterToggleCollapsed(t *testing.T) {
+	collapsed := map[string]bool{}
+	filter := browseFilter(collapsed)
+	toggle := toggleCollapsed(collapsed)
+
+	header := BrowseItem{Type: "file", Path: "a.go"}
+	open := BrowseItem{Type: "comment", Path: "a.go", Comment: &github.ReviewComment{ID: 1, Path: "a.go"}}
+	resolved := BrowseItem{Type: "comment", Path: "a.go", Comment: &github.ReviewComment{ID: 2, Path: "a.go", SubjectType: "resolved"}}
+
+	if !filter(open, true) || filter(resolved, true) || !filter(resolved, false) {
+		t.Error("only resolved comments should be hidden when hiding resolved")
+	}
+
+	if msg, err := toggle(header); err != nil || msg != "Collapsed a.go" {
+		t.Fatalf("toggle(header) = %q, %v", msg, err)
+	}
+	if filter(open, false) || !filter(header, true) {
+		t.Error("comments of a collapsed file should be hidden, its header kept")
+	}
+	if msg, _ := toggle(header); msg != "Expanded a.go" || !filter(open, false) {
+		t.Errorf("toggle(header) again = %q, want the file expanded", msg)
+	}
+	if msg, _ := toggle(open); msg != "" {
+		t.Errorf("toggle(comment) = %q, want no status so the detail view opens", msg)
+	}
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// errNoClipboard is returned when none of the known clipboard tools is
+// installed
+var errNoClipboard = errors.New("no clipboard tool found")
+
+// clipboardCommands lists the commands that can write stdin to the clipboard
+// on goos, in order of preference
+func clipboardCommands(goos string, wayland bool) [][]string {
+	switch goos {
+	case "darwin":
+		return [][]string{{"pbcopy"}}
+	case "windows":
+		return [][]string{{"clip.exe"}}
+	}
+
+	var commands [][]string
+	if wayland {
+		commands = append(commands, []string{"wl-copy"})
+	}
+	commands = append(commands,
+		[]string{"xclip", "-selection", "clipboard"},
+		[]string{"xsel", "--clipboard", "--input"},
+		// WSL, where the Windows tools are on the PATH
+		[]string{"clip.exe"},
+	)
+	return commands
+}
+
+// copyToClipboard writes text to the system clipboard with the first
+// clipboard tool found, or returns errNoClipboard
+func copyToClipboard(text string) error {
+	for _, command := range clipboardCommands(runtime.GOOS, os.Getenv("WAYLAND_DISPLAY") != "") {
+		path, err := exec.LookPath(command[0])
+		if err != nil {
+			continue
+		}
+		cmd := exec.Command(path, command[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("%s failed: %w: %s", command[0], err, strings.TrimSpace(string(output)))
+		}
+		return nil
+	}
+	return errNoClipboard
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClipboardCommands(t *testing.T) {
+	tests := []struct {
+		name    string
+		goos    string
+		wayland bool
+		want    []string
+	}{
+		{"macOS", "darwin", false, []string{"pbcopy"}},
+		{"Windows", "windows", false, []string{"clip.exe"}},
+		{"X11", "linux", false, []string{"xclip", "xsel", "clip.exe"}},
+		{"Wayland first", "linux", true, []string{"wl-copy", "xclip", "xsel", "clip.exe"}},
+		{"other Unix", "freebsd", false, []string{"xclip", "xsel", "clip.exe"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, command := range clipboardCommands(tt.goos, tt.wayland) {
+				got = append(got, command[0])
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("clipboardCommands(%q, %v) = %v, want %v", tt.goos, tt.wayland, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCopyToClipboardWithoutTool(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	t.Setenv("WAYLAND_DISPLAY", "")
+	if err := copyToClipboard("text"); err != errNoClipboard {
+		t.Errorf("copyToClipboard() error = %v, want errNoClipboard", err)
+	}
+}
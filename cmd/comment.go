@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"os/exec"
 	"strconv"
 	"strings"
 
@@ -18,19 +17,32 @@ var (
 	commentBody     string
 	commentBodyFile string
 	commentUseStdin bool
-	commentDebug    bool
 	commentResolve  bool
+	commentQuote    bool
+	commentPending  bool
+	commentPath     string
+	commentLine     int
+	commentInReply  bool
 )
 
 var commentCmd = &cobra.Command{
-	Use:   "comment COMMENT_ID [PR_NUMBER]",
+	Use:   "comment COMMENT_ID [PR_NUMBER] or --pending --path FILE --line N [PR_NUMBER]",
 	Short: "Reply to a pull request review comment",
 	Long: `Post a reply to an existing pull request review comment thread.
 
 COMMENT_ID is required. You can find comment IDs by using 'gh prreview list'.
 When only COMMENT_ID is provided, the PR is inferred from the current branch.
-When both COMMENT_ID and PR_NUMBER are provided, they are used directly.`,
-	Args: cobra.MinimumNArgs(1),
+When both COMMENT_ID and PR_NUMBER are provided, they are used directly.
+A comment URL copied from the browser (...pull/123#discussion_r456) can be given instead of COMMENT_ID; the PR and repository come from the URL.
+
+With --in-reply-to, COMMENT_ID can also be a reply in the thread: the reply
+is posted with in_reply_to set to it instead of going through the first
+comment of the thread.
+
+With --pending, a new comment on --path and --line is added to your pending
+review instead, starting one if needed. Pending comments are only visible to
+you until 'gh prreview review --submit-pending' submits them together.`,
+	Args: cobra.MaximumNArgs(2),
 	RunE: runComment,
 }
 
@@ -38,17 +50,27 @@ func init() {
 	commentCmd.Flags().StringVar(&commentBody, "body", "", "Comment body to post")
 	commentCmd.Flags().StringVar(&commentBodyFile, "body-file", "", "Path to file containing the comment body")
 	commentCmd.Flags().BoolVar(&commentUseStdin, "stdin", false, "Read the comment body from standard input")
-	commentCmd.Flags().BoolVar(&commentDebug, "debug", false, "Enable debug output")
 	commentCmd.Flags().BoolVar(&commentResolve, "resolve", false, "Resolve the comment thread after replying")
+	commentCmd.Flags().BoolVar(&commentQuote, "quote", false, "Pre-fill the editor with the replied-to comment as a blockquote")
+	commentCmd.Flags().BoolVar(&commentInReply, "in-reply-to", false, "Post with in_reply_to set to COMMENT_ID, which may be a reply rather than the first comment of the thread")
+	commentCmd.Flags().BoolVar(&commentPending, "pending", false, "Add a new comment to your pending review instead of replying")
+	commentCmd.Flags().StringVar(&commentPath, "path", "", "With --pending, the file to comment on")
+	commentCmd.Flags().IntVar(&commentLine, "line", 0, "With --pending, the line to comment on, in the new version of the file")
 }
 
 func runComment(cmd *cobra.Command, args []string) error {
 	client := github.NewClient()
-	client.SetDebug(commentDebug)
 	if repoFlag != "" {
 		client.SetRepo(repoFlag)
 	}
 
+	if commentPending {
+		return runPendingComment(args, client)
+	}
+	if commentPath != "" || commentLine != 0 {
+		return errors.New("--path and --line can only be used with --pending")
+	}
+
 	var (
 		prNumber  int
 		commentID int64
@@ -59,16 +81,24 @@ func runComment(cmd *cobra.Command, args []string) error {
 	if len(args) == 0 {
 		return fmt.Errorf("comment ID is required. Use 'gh prreview list' to see available comments")
 	} else if len(args) == 1 {
-		// One argument: treat as COMMENT_ID, infer PR from current branch
-		commentIDVal, err := strconv.ParseInt(args[0], 10, 64)
-		if err != nil {
-			return fmt.Errorf("invalid comment ID: %s", args[0])
-		}
-		commentID = commentIDVal
-		prNumber, err = getPRNumberWithSelection([]string{}, client)
+		// One argument: a comment URL, which has both the PR and the
+		// COMMENT_ID, or a COMMENT_ID with the PR inferred from the branch
+		var isURL bool
+		prNumber, commentID, isURL, err = commentURLArg(client, args[0])
 		if err != nil {
 			return err
 		}
+		if !isURL {
+			commentIDVal, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid comment ID: %s", args[0])
+			}
+			commentID = commentIDVal
+			prNumber, err = getPRNumberWithSelection([]string{}, client)
+			if err != nil {
+				return err
+			}
+		}
 	} else if len(args) >= 2 {
 		// Two arguments: first is COMMENT_ID, second is PR_NUMBER
 		commentIDVal, err := strconv.ParseInt(args[0], 10, 64)
@@ -82,42 +112,55 @@ func runComment(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	body, err := resolveCommentBody()
+	// The PR comments are needed to quote the comment or resolve its thread
+	var comments []*github.ReviewComment
+	if commentQuote || commentResolve {
+		comments, err = fetchReviewComments(client, prNumber)
+		if err != nil {
+			return fmt.Errorf("failed to fetch review comments: %w", err)
+		}
+	}
+
+	var body string
+	if commentQuote {
+		if commentBody != "" || commentBodyFile != "" || commentUseStdin {
+			return errors.New("--quote cannot be used with --body, --body-file, or --stdin")
+		}
+		author, quotedBody, ok := findQuotedComment(comments, commentID)
+		if !ok {
+			return fmt.Errorf("comment ID %d not found in PR #%d", commentID, prNumber)
+		}
+		body, err = promptForQuoteReply(ui.FormatQuotedReply(author, quotedBody, "", "", false))
+	} else {
+		body, err = resolveCommentBody()
+	}
 	if err != nil {
 		return err
 	}
 
-	reply, err := client.ReplyToReviewComment(prNumber, commentID, body)
+	var reply *github.ThreadComment
+	if commentInReply {
+		reply, err = client.ReplyToThreadComment(prNumber, commentID, body)
+	} else {
+		reply, err = client.ReplyToReviewComment(prNumber, commentID, body)
+	}
 	if err != nil {
 		return err
 	}
 
 	link := reply.HTMLURL
 	if link == "" {
-		link = fmt.Sprintf("https://github.com/%s/pull/%d#discussion_r%d", getRepoFromClient(client), prNumber, reply.ID)
+		link = commentURL(client, prNumber, reply.ID)
 	}
 
 	fmt.Printf("%sReply posted by @%s: %s\n",
-		ui.Colorize(ui.ColorGreen, ui.EmojiText("✓ ", "")),
+		ui.Colorize(ui.ColorGreen, ui.EmojiText("✓ ", "OK: ")),
 		ui.Colorize(ui.ColorCyan, reply.Author),
 		ui.CreateHyperlink(link, fmt.Sprintf("comment %d", reply.ID)))
 
 	// Resolve the thread if --resolve flag is set
 	if commentResolve {
-		// Fetch the thread ID for this comment
-		comments, err := client.FetchReviewComments(prNumber)
-		if err != nil {
-			return fmt.Errorf("failed to fetch review comments: %w", err)
-		}
-
-		var threadID string
-		for _, c := range comments {
-			if c.ID == commentID {
-				threadID = c.ThreadID
-				break
-			}
-		}
-
+		threadID := findThreadID(comments, commentID)
 		if threadID == "" {
 			return fmt.Errorf("comment ID %d not found in PR #%d", commentID, prNumber)
 		}
@@ -127,9 +170,43 @@ func runComment(cmd *cobra.Command, args []string) error {
 		}
 
 		fmt.Printf("%sThread marked as resolved\n",
-			ui.Colorize(ui.ColorGreen, ui.EmojiText("✓ ", "")))
+			ui.Colorize(ui.ColorGreen, ui.EmojiText("✓ ", "OK: ")))
+	}
+
+	return nil
+}
+
+// runPendingComment adds a comment to the user's pending review; the only
+// optional argument is PR_NUMBER
+func runPendingComment(args []string, client *github.Client) error {
+	if commentResolve || commentQuote || commentInReply {
+		return errors.New("--pending cannot be combined with --resolve, --quote or --in-reply-to")
+	}
+	if commentPath == "" || commentLine <= 0 {
+		return errors.New("--pending requires --path and a positive --line")
+	}
+	if len(args) > 1 {
+		return errors.New("--pending accepts at most a PR_NUMBER argument")
+	}
+
+	prNumber, err := getPRNumberWithSelection(args, client)
+	if err != nil {
+		return err
+	}
+
+	body, err := resolveCommentBody()
+	if err != nil {
+		return err
+	}
+
+	reviewID, err := client.AddPendingReviewComment(prNumber, commentPath, commentLine, body)
+	if err != nil {
+		return err
 	}
 
+	fmt.Printf("%sAdded a comment on %s:%d to pending review %d\n",
+		ui.Colorize(ui.ColorGreen, ui.EmojiText("✓ ", "OK: ")), commentPath, commentLine, reviewID)
+	fmt.Printf("Submit it with: gh prreview review --submit-pending %d\n", prNumber)
 	return nil
 }
 
@@ -165,11 +242,58 @@ func resolveCommentBody() (string, error) {
 		}
 		return sanitizeComment(string(data), false)
 	default:
-		return promptForCommentBody()
+		return promptForCommentBody("")
 	}
 }
 
-func promptForCommentBody() (string, error) {
+// findQuotedComment returns the author and body of a review comment or of a
+// reply in one of the threads
+func findQuotedComment(comments []*github.ReviewComment, id int64) (string, string, bool) {
+	for _, c := range comments {
+		if c.ID == id {
+			return c.Author, c.Body, true
+		}
+		for _, reply := range c.ThreadComments {
+			if reply.ID == id {
+				return reply.Author, reply.Body, true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// findThreadID returns the ID of the review thread a comment or one of its
+// replies belongs to
+func findThreadID(comments []*github.ReviewComment, id int64) string {
+	for _, c := range comments {
+		if c.ID == id {
+			return c.ThreadID
+		}
+		for _, reply := range c.ThreadComments {
+			if reply.ID == id {
+				return c.ThreadID
+			}
+		}
+	}
+	return ""
+}
+
+// promptForQuoteReply opens the editor pre-filled with quote, a blank line and
+// a line for the reply. Leaving the quote alone is treated as an empty comment.
+func promptForQuoteReply(quote string) (string, error) {
+	body, err := promptForCommentBody(quote + "\n")
+	if err != nil {
+		return "", err
+	}
+	if body == strings.TrimSpace(quote) {
+		return "", errors.New("comment body cannot be empty")
+	}
+	return body, nil
+}
+
+// promptForCommentBody opens the user's editor, pre-filled with initial, and returns the
+// sanitized content
+func promptForCommentBody(initial string) (string, error) {
 	template := "# Write your PR review comment above. Lines starting with # are ignored.\n"
 
 	tmpFile, err := os.CreateTemp("", "gh-prreview-comment-*.md")
@@ -180,7 +304,7 @@ func promptForCommentBody() (string, error) {
 		_ = os.Remove(tmpFile.Name())
 	}()
 
-	content := template
+	content := initial + template
 	if _, err := tmpFile.WriteString(content); err != nil {
 		closeErr := tmpFile.Close()
 		return "", fmt.Errorf("failed to write template: %w (and closing file: %v)", err, closeErr)
@@ -189,23 +313,8 @@ func promptForCommentBody() (string, error) {
 		return "", fmt.Errorf("failed to close temporary file: %w", err)
 	}
 
-	editor := os.Getenv("EDITOR")
-	if editor == "" {
-		editor = "vi"
-	}
-
-	editorParts := strings.Fields(editor)
-	if len(editorParts) == 0 {
-		return "", fmt.Errorf("invalid EDITOR value: %q", editor)
-	}
-
-	editorCmd := exec.Command(editorParts[0], append(editorParts[1:], tmpFile.Name())...)
-	editorCmd.Stdin = os.Stdin
-	editorCmd.Stdout = os.Stdout
-	editorCmd.Stderr = os.Stderr
-
-	if err := editorCmd.Run(); err != nil {
-		return "", fmt.Errorf("editor exited with error: %w", err)
+	if err := ui.LaunchEditor(tmpFile.Name(), 0); err != nil {
+		return "", err
 	}
 
 	result, err := os.ReadFile(tmpFile.Name())
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chmouel/gh-prreview/pkg/github"
+)
+
+func TestFindQuotedComment(t *testing.T) {
+	comments := []*github.ReviewComment{
+		{
+			ID:     1,
+			Author: "reviewer",
+			Body:   "Please rename this",
+			ThreadComments: []github.ThreadComment{
+				{ID: 2, Author: "author", Body: "Which name?"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		id         int64
+		wantAuthor string
+		wantBody   string
+		wantOK     bool
+	}{
+		{"top-level comment", 1, "reviewer", "Please rename this", true},
+		{"reply in a thread", 2, "author", "Which name?", true},
+		{"unknown comment", 3, "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			author, body, ok := findQuotedComment(comments, tt.id)
+			if author != tt.wantAuthor || body != tt.wantBody || ok != tt.wantOK {
+				t.Errorf("findQuotedComment(%d) = (%q, %q, %v), want (%q, %q, %v)",
+					tt.id, author, body, ok, tt.wantAuthor, tt.wantBody, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestFindThreadID(t *testing.T) {
+	comments := []*github.ReviewComment{
+		{ID: 1, ThreadID: "PRRT_1", ThreadComments: []github.ThreadComment{{ID: 2}, {ID: 3}}},
+		{ID: 4, ThreadID: "PRRT_4"},
+	}
+
+	for id, want := range map[int64]string{1: "PRRT_1", 3: "PRRT_1", 4: "PRRT_4", 5: ""} {
+		if got := findThreadID(comments, id); got != want {
+			t.Errorf("findThreadID(%d) = %q, want %q", id, got, want)
+		}
+	}
+}
+
+// fakeEditor points $EDITOR at a shell script run with the file to edit as $1
+func fakeEditor(t *testing.T, script string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "editor.sh")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("EDITOR", path)
+}
+
+func TestPromptForQuoteReply(t *testing.T) {
+	quote := "> @reviewer wrote:\n>\n> Please rename this\n\n"
+
+	// Reply typed below the quote, template left in place
+	fakeEditor(t, `sed '5s/^$/Renamed, thanks/' "$1" > "$1.new" && mv "$1.new" "$1"`)
+	got, err := promptForQuoteReply(quote)
+	if err != nil {
+		t.Fatalf("promptForQuoteReply() returned error: %v", err)
+	}
+	want := "> @reviewer wrote:\n>\n> Please rename this\n\nRenamed, thanks"
+	if got != want {
+		t.Errorf("promptForQuoteReply() = %q, want %q", got, want)
+	}
+
+	fakeEditor(t, "true")
+	if _, err := promptForQuoteReply(quote); err == nil {
+		t.Error("promptForQuoteReply() with the quote left alone should return an error")
+	}
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/chmouel/gh-prreview/pkg/applier"
+	"github.com/chmouel/gh-prreview/pkg/github"
+	"github.com/spf13/cobra"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff [PR_NUMBER] COMMENT_ID",
+	Short: "Print a suggestion as a unified diff",
+	Long: `Print the suggestion of a review comment as a unified diff against the local
+file, without modifying anything. The patch is the change apply would make and
+can be piped into other tools, e.g. 'gh prreview diff 123456 | git apply --check'.
+When only COMMENT_ID is given, the PR is inferred from the current branch.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runDiff,
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	client := github.NewClient()
+	if repoFlag != "" {
+		client.SetRepo(repoFlag)
+	}
+
+	commentArg := args[len(args)-1]
+	commentID, err := strconv.ParseInt(commentArg, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid comment ID: %s", commentArg)
+	}
+
+	var prNumber int
+	if len(args) == 2 {
+		prNumber, err = strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid PR number: %s", args[0])
+		}
+	} else {
+		prNumber, err = client.GetCurrentBranchPR()
+		if err != nil {
+			return err
+		}
+	}
+
+	comments, err := fetchReviewComments(client, prNumber)
+	if err != nil {
+		return fmt.Errorf("failed to fetch review comments: %w", err)
+	}
+
+	selected, err := selectCommentsByID(comments, []int64{commentID})
+	if err != nil {
+		return err
+	}
+	comment := selected[0]
+
+	fileContent, err := os.ReadFile(comment.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read file %s: %w", comment.Path, err)
+	}
+
+	patch, err := applier.BuildPatch(comment, fileContent)
+	if err != nil {
+		return err
+	}
+	if patch == "" {
+		fmt.Fprintf(os.Stderr, "Suggestion of comment %d is already applied to %s\n", commentID, comment.Path)
+		return nil
+	}
+
+	fmt.Print(patch)
+	return nil
+}
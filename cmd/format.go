@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+const (
+	formatText = "text"
+	formatJSON = "json"
+)
+
+var outputFormat string
+
+// summaryOut is where printSummary writes, kept pointing at the real stdout
+// while redirectStdout is in effect
+var summaryOut io.Writer = os.Stdout
+
+func validateOutputFormat() error {
+	switch outputFormat {
+	case formatText, formatJSON:
+		return nil
+	default:
+		return fmt.Errorf("invalid --format %q (expected %s or %s)", outputFormat, formatText, formatJSON)
+	}
+}
+
+// jsonOutput reports whether the end-of-run summaries are requested as JSON
+func jsonOutput() bool {
+	return outputFormat == formatJSON
+}
+
+// redirectStdout sends everything printed to stdout to stderr in JSON mode,
+// so that the JSON summary is the only thing on stdout. The returned function
+// undoes the redirection.
+func redirectStdout() func() {
+	if !jsonOutput() {
+		return func() {}
+	}
+	stdout := os.Stdout
+	summaryOut = stdout
+	os.Stdout = os.Stderr
+	return func() {
+		os.Stdout = stdout
+	}
+}
+
+// printSummary writes v as JSON in JSON mode, and otherwise calls text to
+// print the human-readable summary
+func printSummary(v any, text func()) error {
+	if !jsonOutput() {
+		text()
+		return nil
+	}
+	encoder := json.NewEncoder(summaryOut)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode summary: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPrintSummary(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		wantOut  string
+		wantText bool
+	}{
+		{"text calls the text printer", formatText, "", true},
+		{"json encodes the value", formatJSON, "{\n  \"applied\": 2\n}\n", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previousFormat, previousOut := outputFormat, summaryOut
+			defer func() { outputFormat, summaryOut = previousFormat, previousOut }()
+
+			var out bytes.Buffer
+			outputFormat = tt.format
+			summaryOut = &out
+
+			textCalled := false
+			err := printSummary(struct {
+				Applied int `json:"applied"`
+			}{2}, func() { textCalled = true })
+			if err != nil {
+				t.Fatalf("printSummary() error = %v", err)
+			}
+			if textCalled != tt.wantText {
+				t.Errorf("text printer called = %v, want %v", textCalled, tt.wantText)
+			}
+			if out.String() != tt.wantOut {
+				t.Errorf("output = %q, want %q", out.String(), tt.wantOut)
+			}
+		})
+	}
+}
+
+func TestValidateOutputFormat(t *testing.T) {
+	previous := outputFormat
+	defer func() { outputFormat = previous }()
+
+	for _, format := range []string{formatText, formatJSON} {
+		outputFormat = format
+		if err := validateOutputFormat(); err != nil {
+			t.Errorf("validateOutputFormat(%q) error = %v", format, err)
+		}
+	}
+
+	outputFormat = "yaml"
+	if err := validateOutputFormat(); err == nil || !strings.Contains(err.Error(), "yaml") {
+		t.Errorf("validateOutputFormat(%q) error = %v, want invalid format error", outputFormat, err)
+	}
+}
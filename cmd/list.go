@@ -1,22 +1,63 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
 	"strings"
+	"text/template"
+	"time"
 
+	"github.com/chmouel/gh-prreview/pkg/diffhunk"
+	"github.com/chmouel/gh-prreview/pkg/diffposition"
 	"github.com/chmouel/gh-prreview/pkg/github"
+	"github.com/chmouel/gh-prreview/pkg/log"
+	"github.com/chmouel/gh-prreview/pkg/report"
 	"github.com/chmouel/gh-prreview/pkg/ui"
 	"github.com/spf13/cobra"
 )
 
 var (
 	listShowResolved bool
-	listDebug        bool
 	listLLM          bool
 	listJSON         bool
 	listCodeContext  bool
+	listHTML         bool
+	listOutput       string
+	listExcludeMe    bool
+	listWordDiff     bool
+	listLocalContext bool
+	listHunkLocal    bool
+	listWatch        bool
+	listInterval     int
+	listCount        bool
+	listFailIfAny    bool
+	listNoPager      bool
+	listLLMTemplate  string
+	listResolvedBody bool
+	listContextLines int
+	listSuggestions  bool
+	listDiscussion   bool
+	listReview       int64
+	listAuthor       string
+	listSince        string
+	listAllPRs       bool
+	listNeedsReply   bool
+	listInteractive  bool
+	listWeb          bool
 )
 
+// listSinceTime is --since, parsed
+var listSinceTime time.Time
+
+// localContextRadius is how many lines --local-context shows around the
+// commented lines
+const localContextRadius = 3
+
 var listCmd = &cobra.Command{
 	Use:   "list [PR_NUMBER] [THREAD_ID]",
 	Short: "List review comments for a pull request",
@@ -27,15 +68,36 @@ var listCmd = &cobra.Command{
 
 func init() {
 	listCmd.Flags().BoolVar(&listShowResolved, "all", false, "Show resolved/done suggestions")
-	listCmd.Flags().BoolVar(&listDebug, "debug", false, "Enable debug output")
+	listCmd.Flags().BoolVar(&listResolvedBody, "show-resolved-bodies", false, "With --all, show resolved comments in full instead of one line each")
 	listCmd.Flags().BoolVar(&listLLM, "llm", false, "Output in a format suitable for LLM consumption")
+	listCmd.Flags().StringVar(&listLLMTemplate, "llm-template", "", "Go text/template file rendering each comment of the --llm output")
 	listCmd.Flags().BoolVar(&listJSON, "json", false, "Output raw review comment JSON (includes thread replies)")
 	listCmd.Flags().BoolVar(&listCodeContext, "code-context", false, "Display surrounding diff context for each comment")
+	listCmd.Flags().IntVar(&listContextLines, "context-lines", 0, "Show N lines above and below the commented lines in --code-context instead of the whole diff hunk")
+	listCmd.Flags().BoolVar(&listWordDiff, "word-diff", false, "Highlight the changed words of modified lines in --code-context diffs")
+	listCmd.Flags().BoolVar(&listHunkLocal, "diff-context-from-local", false, "Show the --code-context lines from the local file as it is now instead of the diff hunk stored with the review")
+	listCmd.Flags().BoolVar(&listLocalContext, "local-context", false, "Display the current local file content around each comment")
+	listCmd.Flags().BoolVar(&listWatch, "watch", false, "Keep running and print new or edited comments")
+	listCmd.Flags().IntVar(&listInterval, "interval", 30, "With --watch, seconds between checks")
+	listCmd.Flags().BoolVar(&listCount, "count", false, "Only print the number of comments that would be listed")
+	listCmd.Flags().BoolVar(&listFailIfAny, "fail-if-any", false, "Exit with a non-zero status when there is any comment to list (e.g. in a pre-push hook)")
+	listCmd.Flags().BoolVar(&listNoPager, "no-pager", false, "Do not pipe the output through $PAGER")
+	listCmd.Flags().BoolVar(&listNeedsReply, "needs-reply", false, "Only list unresolved threads whose latest comment is not from the current user")
+	listCmd.Flags().BoolVar(&listExcludeMe, "exclude-me", false, "Hide comments authored by the current user")
+	listCmd.Flags().BoolVar(&listSuggestions, "suggestions-only", false, "Only list comments with a suggestion")
+	listCmd.Flags().BoolVar(&listDiscussion, "discussion-only", false, "Only list comments without a suggestion")
+	listCmd.Flags().Int64Var(&listReview, "review", 0, "Only list comments submitted with this review ID (see 'gh prreview reviews')")
+	listCmd.Flags().StringVar(&listAuthor, "author", "", "Only list threads started by this reviewer (e.g. Copilot)")
+	listCmd.Flags().StringVar(&listSince, "since", "", "Only list threads with activity since a date (2006-01-02) or for a duration (48h, 7d)")
+	listCmd.Flags().BoolVar(&listAllPRs, "all-prs", false, "List the comments of every pull request in --state (open by default), grouped by PR")
+	listCmd.Flags().BoolVar(&listInteractive, "interactive", false, "Show the comments in the browse tree to search (/) and read them, without any action on the PR")
+	listCmd.Flags().BoolVar(&listWeb, "web", false, "Open the pull request in the browser instead of listing its comments")
+	listCmd.Flags().BoolVar(&listHTML, "html", false, "Generate a self-contained HTML report of the review")
+	listCmd.Flags().StringVarP(&listOutput, "output", "o", "", "Write the --html report to a file instead of stdout")
 }
 
 func runList(cmd *cobra.Command, args []string) error {
 	client := github.NewClient()
-	client.SetDebug(listDebug)
 	if repoFlag != "" {
 		client.SetRepo(repoFlag)
 	}
@@ -43,32 +105,108 @@ func runList(cmd *cobra.Command, args []string) error {
 	if listJSON && listLLM {
 		return fmt.Errorf("--json cannot be combined with --llm")
 	}
+	if listHTML && (listJSON || listLLM) {
+		return fmt.Errorf("--html cannot be combined with --json or --llm")
+	}
+	if listOutput != "" && !listHTML {
+		return fmt.Errorf("--output can only be used with --html")
+	}
+	if cmd.Flags().Changed("context-lines") {
+		if listContextLines < 0 {
+			return fmt.Errorf("--context-lines must not be negative")
+		}
+		listCodeContext = true
+	} else {
+		listContextLines = -1
+	}
+	if listHunkLocal && !listCodeContext {
+		return fmt.Errorf("--diff-context-from-local can only be used with --code-context or --context-lines")
+	}
+	if err := checkKindFlags(listSuggestions, listDiscussion); err != nil {
+		return err
+	}
+	if listNeedsReply && listShowResolved {
+		return fmt.Errorf("--needs-reply cannot be combined with --all")
+	}
+	if listResolvedBody && !listShowResolved {
+		return fmt.Errorf("--show-resolved-bodies can only be used with --all")
+	}
+	if listLLMTemplate != "" && !listLLM {
+		return fmt.Errorf("--llm-template can only be used with --llm")
+	}
+	if listWatch && (listJSON || listLLM || listHTML) {
+		return fmt.Errorf("--watch cannot be combined with --json, --llm or --html")
+	}
+	if listCount && (listJSON || listLLM || listHTML || listWatch) {
+		return fmt.Errorf("--count cannot be combined with --json, --llm, --html or --watch")
+	}
+	if listInteractive && (listJSON || listLLM || listHTML || listWatch || listCount || listAllPRs) {
+		return fmt.Errorf("--interactive cannot be combined with --json, --llm, --html, --watch, --count or --all-prs")
+	}
+	if listWeb && (listJSON || listLLM || listHTML || listWatch || listCount || listInteractive || listAllPRs) {
+		return fmt.Errorf("--web cannot be combined with --json, --llm, --html, --watch, --count, --interactive or --all-prs")
+	}
+	if listFailIfAny && listWatch {
+		return fmt.Errorf("--fail-if-any cannot be combined with --watch")
+	}
+	if cmd.Flags().Changed("interval") && !listWatch {
+		return fmt.Errorf("--interval can only be used with --watch")
+	}
+	if listInterval <= 0 {
+		return fmt.Errorf("--interval must be a positive number of seconds")
+	}
+
+	if listSince != "" {
+		since, err := parseSince(listSince, time.Now())
+		if err != nil {
+			return err
+		}
+		listSinceTime = since
+	}
+	if listAllPRs {
+		if len(args) > 0 {
+			return fmt.Errorf("--all-prs cannot be used with PR_NUMBER or THREAD_ID")
+		}
+		if listJSON || listLLM || listHTML || listWatch || listReview != 0 {
+			return fmt.Errorf("--all-prs cannot be combined with --json, --llm, --html, --watch or --review")
+		}
+		return runListAllPRs(cmd, client)
+	}
+
+	var llmTemplate *template.Template
+	if listLLM {
+		var err error
+		if llmTemplate, err = loadLLMTemplate(listLLMTemplate); err != nil {
+			return err
+		}
+	}
 
 	prNumber, err := getPRNumberWithSelection(args, client)
 	if err != nil {
 		return err
 	}
+	if listWeb {
+		return openPRInBrowser(client, prNumber)
+	}
 
 	var threadID string
 	if len(args) > 1 {
 		threadID = args[1]
 	}
 
-	comments, err := client.FetchReviewComments(prNumber)
+	comments, err := fetchReviewComments(client, prNumber)
 	if err != nil {
 		return fmt.Errorf("failed to fetch review comments: %w", err)
 	}
 
-	// Filter out resolved comments unless --all is specified
-	filteredComments := make([]*github.ReviewComment, 0)
-	for _, comment := range comments {
-		if listShowResolved || !comment.IsResolved() {
-			filteredComments = append(filteredComments, comment)
-		}
+	filteredComments, err := filterListComments(client, comments, threadID)
+	if err != nil {
+		return err
 	}
 
-	if threadID != "" {
-		filteredComments = filterByThreadID(filteredComments, threadID)
+	if listCount {
+		fmt.Println(len(filteredComments))
+		return failIfAny(cmd, filteredComments)
 	}
 
 	if listJSON {
@@ -85,34 +223,390 @@ func runList(cmd *cobra.Command, args []string) error {
 			return err
 		}
 		fmt.Println(jsonOutput)
-		return nil
+		return failIfAny(cmd, filteredComments)
+	}
+
+	if listHTML {
+		if err := writeHTMLReport(client, prNumber, filteredComments); err != nil {
+			return err
+		}
+		return failIfAny(cmd, filteredComments)
+	}
+
+	// Without a terminal (or with --plain), --interactive prints the usual list
+	if listInteractive && len(filteredComments) > 0 && ui.Interactive() {
+		if err := listInteractively(client, prNumber, filteredComments); err != nil {
+			return err
+		}
+		return failIfAny(cmd, filteredComments)
+	}
+
+	// Long reviews go through the pager, but not the machine-readable output
+	// and not --watch, which never ends
+	if !listNoPager && !listLLM && !listWatch {
+		defer startPager()()
+	}
+
+	if !listLLM {
+		printClosedPRHeader(client, prNumber)
 	}
 
 	if len(filteredComments) == 0 {
-		if threadID != "" {
+		switch {
+		case threadID != "":
 			fmt.Printf("No review comments found for thread ID %s.\n", threadID)
-			return nil
+		case listReview != 0 && !listShowResolved:
+			fmt.Printf("No unresolved review comments found in review %d. Use --all to show resolved comments.\n", listReview)
+		case listReview != 0:
+			fmt.Printf("No review comments found in review %d.\n", listReview)
+		case listShowResolved:
+			fmt.Println("No review comments found.")
+		default:
+			fmt.Println("No unresolved review comments found. Use --all to show resolved comments.")
+		}
+	} else if listLLM {
+		// Use readable format if requested
+		if err := displayLLMFormat(os.Stdout, llmTemplate, filteredComments); err != nil {
+			return err
 		}
+	} else {
+		fmt.Printf("Found %d review comment(s):\n", len(filteredComments))
+		displayComments(filteredComments, threadID != "" || listResolvedBody)
+	}
+
+	if err := failIfAny(cmd, filteredComments); err != nil {
+		return err
+	}
+	if listWatch {
+		return watchComments(client, prNumber, threadID, filteredComments, time.Duration(listInterval)*time.Second)
+	}
+	return nil
+}
+
+// printClosedPRHeader notes that the PR is merged or closed above its
+// comments; nothing is printed for an open PR
+func printClosedPRHeader(client *github.Client, prNumber int) {
+	pr, err := client.GetPR(prNumber)
+	if err != nil {
+		log.Debugf("Could not fetch the state of PR #%d: %v", prNumber, err)
+		return
+	}
+	label := pr.StateLabel()
+	if label == "" {
+		return
+	}
+	header := ui.CreateHyperlink(prURL(client, prNumber), fmt.Sprintf("PR #%d (%s)", prNumber, label))
+	fmt.Printf("%s\n\n", ui.Colorize(ui.ColorMagenta, header))
+}
+
+// listInteractively shows comments in the tree of browse, read-only: / searches
+// them and enter shows one in full, but there is no key to open the browser,
+// reply, resolve or react
+func listInteractively(client *github.Client, prNumber int, comments []*github.ReviewComment) error {
+	collapsedFiles := make(map[string]bool)
+	renderer := &browseItemRenderer{
+		repo:           getRepoFromClient(client),
+		prNumber:       prNumber,
+		collapsedFiles: collapsedFiles,
+	}
+
+	_, err := ui.Select(ui.SelectorOptions[BrowseItem]{
+		Items:      buildCommentTree(comments),
+		Renderer:   renderer,
+		OnSelect:   toggleCollapsed(collapsedFiles),
+		FilterFunc: browseFilter(collapsedFiles),
+		JumpTarget: func(item BrowseItem) bool {
+			return item.Type == "comment" && !item.IsPreview && !item.Comment.IsResolved()
+		},
+		JumpKey: "n/N next/prev unresolved",
+		CollapseAllAction: func(items []BrowseItem, collapse bool) string {
+			return setAllCollapsed(collapsedFiles, items, collapse)
+		},
+		CollapseAllKey: "-/+ collapse/expand all",
+	})
+	if err != nil && !errors.Is(err, ui.ErrNoSelection) {
+		return fmt.Errorf("selection cancelled: %w", err)
+	}
+	return nil
+}
+
+// runListAllPRs prints the comments of every PR in --state, each PR under a
+// header with its number and title. PRs without comments to list are left out.
+func runListAllPRs(cmd *cobra.Command, client *github.Client) error {
+	var prs []*github.PullRequest
+	err := withSpinner("Fetching "+prStateQualifier()+"pull requests", func() error {
+		var err error
+		prs, err = client.ListPRs(prLimit, prState)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	type prComments struct {
+		pr       *github.PullRequest
+		comments []*github.ReviewComment
+	}
+	var groups []prComments
+	var all []*github.ReviewComment
+	for _, pr := range prs {
+		comments, err := fetchReviewComments(client, pr.Number)
+		if err != nil {
+			return fmt.Errorf("failed to fetch review comments of PR #%d: %w", pr.Number, err)
+		}
+		comments, err = filterListComments(client, comments, "")
+		if err != nil {
+			return err
+		}
+		if len(comments) > 0 {
+			groups = append(groups, prComments{pr, comments})
+			all = append(all, comments...)
+		}
+	}
+
+	if listCount {
+		fmt.Println(len(all))
+		return failIfAny(cmd, all)
+	}
+
+	if !listNoPager {
+		defer startPager()()
+	}
+
+	if len(all) == 0 {
 		if listShowResolved {
-			fmt.Println("No review comments found.")
+			fmt.Printf("No review comments found in %d %spull request(s).\n", len(prs), prStateQualifier())
 		} else {
-			fmt.Println("No unresolved review comments found. Use --all to show resolved comments.")
+			fmt.Printf("No unresolved review comments found in %d %spull request(s). Use --all to show resolved comments.\n", len(prs), prStateQualifier())
 		}
-		return nil
+		return failIfAny(cmd, all)
 	}
 
-	// Use readable format if requested
-	if listLLM {
-		displayLLMFormat(filteredComments)
+	fmt.Printf("Found %d review comment(s) in %d pull request(s):\n", len(all), len(groups))
+	for _, group := range groups {
+		title := fmt.Sprintf("PR #%d: %s", group.pr.Number, group.pr.Title)
+		if label := group.pr.StateLabel(); label != "" {
+			title += fmt.Sprintf(" (%s)", label)
+		}
+		header := ui.CreateHyperlink(prURL(client, group.pr.Number), title)
+		fmt.Printf("\n%s\n", ui.Colorize(ui.ColorMagenta, header))
+		displayComments(group.comments, listResolvedBody)
+	}
+	return failIfAny(cmd, all)
+}
+
+// parseSince parses the --since value, either a date (2006-01-02, local
+// time) or a duration back from now, in Go syntax (48h) or in days (7d)
+func parseSince(value string, now time.Time) (time.Time, error) {
+	if date, err := time.ParseInLocation("2006-01-02", value, now.Location()); err == nil {
+		return date, nil
+	}
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+	if duration, err := time.ParseDuration(value); err == nil && duration >= 0 {
+		return now.Add(-duration), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since %q, use a date (2006-01-02) or a duration (48h, 7d)", value)
+}
+
+// failIfAny returns an error when --fail-if-any is set and there are
+// comments, so that the exit status tells scripts about them. The error is
+// reported by main only, without the usage text.
+func failIfAny(cmd *cobra.Command, comments []*github.ReviewComment) error {
+	if !listFailIfAny || len(comments) == 0 {
 		return nil
 	}
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	if listShowResolved {
+		return fmt.Errorf("%d review comment(s) found", len(comments))
+	}
+	return fmt.Errorf("%d unresolved review comment(s) found", len(comments))
+}
+
+// filterListComments applies the --exclude-me, --suggestions-only,
+// --discussion-only, --review, --author, --since, --all and THREAD_ID filters
+func filterListComments(client *github.Client, comments []*github.ReviewComment, threadID string) ([]*github.ReviewComment, error) {
+	comments, err := excludeOwnComments(client, comments, listExcludeMe)
+	if err != nil {
+		return nil, err
+	}
+	comments = filterByKind(comments, listSuggestions, listDiscussion)
+	if listReview != 0 {
+		comments = github.FilterByReview(comments, listReview)
+	}
+	if listAuthor != "" {
+		comments = github.FilterByAuthor(comments, listAuthor)
+	}
+	if !listSinceTime.IsZero() {
+		comments = github.FilterActiveSince(comments, listSinceTime)
+	}
+	if listNeedsReply {
+		login, err := client.CurrentUser()
+		if err != nil {
+			return nil, err
+		}
+		comments = github.FilterNeedsReply(comments, login)
+	}
 
-	fmt.Printf("Found %d review comment(s):\n", len(filteredComments))
+	// Filter out resolved comments unless --all is specified
+	filteredComments := make([]*github.ReviewComment, 0)
+	for _, comment := range comments {
+		if listShowResolved || !comment.IsResolved() {
+			filteredComments = append(filteredComments, comment)
+		}
+	}
 
-	for i, comment := range filteredComments {
-		displayComment(i+1, len(filteredComments), comment)
+	if threadID != "" {
+		filteredComments = filterByThreadID(filteredComments, threadID)
 	}
+	return filteredComments, nil
+}
+
+// commentChange is a comment or reply that appeared or was edited between two
+// fetches in --watch mode
+type commentChange struct {
+	Updated bool // edited rather than new
+	Thread  *github.ReviewComment
+	ID      int64
+	Author  string
+	Body    string
+	HTMLURL string
+}
+
+// commentVersions maps the ID of every comment and reply to its last update
+func commentVersions(comments []*github.ReviewComment) map[int64]time.Time {
+	versions := make(map[int64]time.Time)
+	for _, comment := range comments {
+		versions[comment.ID] = lastUpdate(comment.CreatedAt, comment.UpdatedAt)
+		for _, reply := range comment.ThreadComments {
+			versions[reply.ID] = lastUpdate(reply.CreatedAt, reply.UpdatedAt)
+		}
+	}
+	return versions
+}
+
+func lastUpdate(created, updated time.Time) time.Time {
+	if updated.After(created) {
+		return updated
+	}
+	return created
+}
+
+// diffComments returns the comments and replies that are not in previous, or
+// were updated since, in thread order
+func diffComments(previous map[int64]time.Time, comments []*github.ReviewComment) []commentChange {
+	var changes []commentChange
+	check := func(thread *github.ReviewComment, id int64, author, body, url string, created, updated time.Time) {
+		seen, ok := previous[id]
+		if ok && !lastUpdate(created, updated).After(seen) {
+			return
+		}
+		changes = append(changes, commentChange{
+			Updated: ok,
+			Thread:  thread,
+			ID:      id,
+			Author:  author,
+			Body:    body,
+			HTMLURL: url,
+		})
+	}
+
+	for _, comment := range comments {
+		check(comment, comment.ID, comment.Author, comment.Body, comment.HTMLURL, comment.CreatedAt, comment.UpdatedAt)
+		for _, reply := range comment.ThreadComments {
+			check(comment, reply.ID, reply.Author, reply.Body, reply.HTMLURL, reply.CreatedAt, reply.UpdatedAt)
+		}
+	}
+	return changes
+}
 
+// watchComments polls the PR every interval and prints the comments added or
+// edited since the previous fetch, until interrupted
+func watchComments(client *github.Client, prNumber int, threadID string, comments []*github.ReviewComment, interval time.Duration) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	versions := commentVersions(comments)
+	fmt.Printf("\n%s\n", ui.Colorize(ui.ColorGray,
+		fmt.Sprintf("Watching for new comments every %s (Ctrl-C to stop)...", interval)))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			fmt.Printf("\n%s\n", ui.Colorize(ui.ColorGray, "Stopped watching"))
+			return nil
+		case <-ticker.C:
+		}
+
+		fetched, err := client.FetchReviewComments(prNumber)
+		if err == nil {
+			fetched, err = filterListComments(client, fetched, threadID)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s %sFailed to refresh comments: %v\n",
+				time.Now().Format("15:04:05"), ui.EmojiText("⚠️  ", "Warning: "), err)
+			continue
+		}
+
+		for _, change := range diffComments(versions, fetched) {
+			displayCommentChange(change)
+		}
+		versions = commentVersions(fetched)
+	}
+}
+
+// displayCommentChange prints a new or edited comment with a timestamp
+func displayCommentChange(change commentChange) {
+	kind := "New comment"
+	if change.Updated {
+		kind = "Edited comment"
+	}
+	if change.ID != change.Thread.ID {
+		kind = strings.Replace(kind, "comment", "reply", 1)
+	}
+	location := ui.CreateHyperlink(change.HTMLURL, change.Thread.LocationLabel())
+	fmt.Printf("\n%s %s\n",
+		ui.Colorize(ui.ColorGray, time.Now().Format("15:04:05")),
+		ui.Colorize(ui.ColorCyan, fmt.Sprintf("%s by @%s on %s (ID %d)", kind, change.Author, location, change.ID)))
+	for _, line := range strings.Split(ui.WrapText(change.Body, ui.IndentedTextWidth(2)), "\n") {
+		fmt.Printf("  %s\n", line)
+	}
+}
+
+// writeHTMLReport renders the comments as an HTML report to stdout or to --output
+func writeHTMLReport(client *github.Client, prNumber int, comments []*github.ReviewComment) error {
+	opts := report.Options{
+		Repo:        getRepoFromClient(client),
+		PRNumber:    prNumber,
+		PRURL:       prURL(client, prNumber),
+		GeneratedAt: time.Now(),
+	}
+
+	var out io.Writer = os.Stdout
+	if listOutput != "" {
+		f, err := os.Create(listOutput)
+		if err != nil {
+			return fmt.Errorf("failed to create report file: %w", err)
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+		out = f
+	}
+
+	if err := report.WriteHTML(out, comments, opts); err != nil {
+		return err
+	}
+
+	if listOutput != "" {
+		fmt.Fprintf(os.Stderr, "HTML report written to %s\n", listOutput)
+	}
 	return nil
 }
 
@@ -128,7 +622,23 @@ func filterByThreadID(comments []*github.ReviewComment, threadID string) []*gith
 
 func dumpCommentsJSON(client *github.Client, prNumber int, comments []*github.ReviewComment) (string, error) {
 	commentIDs := collectCommentIDs(comments)
-	return client.DumpCommentsJSON(prNumber, commentIDs)
+	return client.DumpCommentsJSON(prNumber, commentIDs, collectThreadIDs(comments))
+}
+
+// collectThreadIDs maps the ID of every comment and reply to the ID of its
+// thread, the one resolve takes
+func collectThreadIDs(comments []*github.ReviewComment) map[int64]string {
+	threadIDs := make(map[int64]string)
+	for _, comment := range comments {
+		if comment.ThreadID == "" {
+			continue
+		}
+		threadIDs[comment.ID] = comment.ThreadID
+		for _, reply := range comment.ThreadComments {
+			threadIDs[reply.ID] = comment.ThreadID
+		}
+	}
+	return threadIDs
 }
 
 func collectCommentIDs(comments []*github.ReviewComment) []int64 {
@@ -151,10 +661,50 @@ func collectCommentIDs(comments []*github.ReviewComment) []int64 {
 	return ids
 }
 
+// displayComments shows the unresolved comments first, then the resolved
+// ones under a "Resolved (N)" header, collapsed to one line each unless
+// expandResolved is set
+func displayComments(comments []*github.ReviewComment, expandResolved bool) {
+	unresolved, resolved := partitionResolved(comments)
+	for i, comment := range unresolved {
+		displayComment(i+1, len(comments), comment)
+	}
+	if len(resolved) == 0 {
+		return
+	}
+
+	fmt.Printf("\n%s\n", ui.Colorize(ui.ColorGreen, fmt.Sprintf("Resolved (%d)", len(resolved))))
+	for i, comment := range resolved {
+		if expandResolved {
+			displayComment(len(unresolved)+i+1, len(comments), comment)
+			continue
+		}
+		location := ui.CreateHyperlink(comment.HTMLURL, comment.LocationDescription())
+		fmt.Printf("  %s %s by @%s (ID %d)\n",
+			ui.EmojiText("✅", "-"), location, comment.Author, comment.ID)
+	}
+	if !expandResolved {
+		fmt.Printf("\n%s\n", ui.Colorize(ui.ColorGray, "Use --show-resolved-bodies to show resolved comments in full."))
+	}
+}
+
+// partitionResolved splits comments into unresolved and resolved ones,
+// keeping their order
+func partitionResolved(comments []*github.ReviewComment) (unresolved, resolved []*github.ReviewComment) {
+	for _, comment := range comments {
+		if comment.IsResolved() {
+			resolved = append(resolved, comment)
+		} else {
+			unresolved = append(unresolved, comment)
+		}
+	}
+	return unresolved, resolved
+}
+
 // displayComment displays a single review comment with formatting
 func displayComment(index, total int, comment *github.ReviewComment) {
 	// Create clickable link to the review comment
-	fileLocation := fmt.Sprintf("%s:%d", comment.Path, comment.Line)
+	fileLocation := comment.LocationDescription()
 	clickableLocation := ui.CreateHyperlink(comment.HTMLURL, fileLocation)
 
 	// Header
@@ -177,7 +727,7 @@ func displayComment(index, total int, comment *github.ReviewComment) {
 			fmt.Println(rendered)
 		} else {
 			// Fallback to wrapped text
-			wrappedComment := ui.WrapText(commentText, 80)
+			wrappedComment := ui.WrapText(commentText, ui.TextWidth())
 			fmt.Printf("%s\n", wrappedComment)
 		}
 	}
@@ -185,13 +735,27 @@ func displayComment(index, total int, comment *github.ReviewComment) {
 	// Show the suggestion if present
 	if comment.HasSuggestion {
 		fmt.Printf("\n%s\n", ui.Colorize(ui.ColorYellow, "Suggested change:"))
-		fmt.Println(ui.ColorizeCode(comment.SuggestedCode))
+		if comment.IsDeletion {
+			fmt.Println(ui.Colorize(ui.ColorRed, "(delete the commented lines)"))
+		} else {
+			language := ui.CodeFenceLanguageFromPath(comment.Path)
+			fmt.Println(ui.WrapCode(ui.HighlightCode(comment.SuggestedCode, language), ui.TerminalWidth()))
+		}
 	}
 
 	// Show context (diff hunk) if available and requested
-	if listCodeContext && comment.DiffHunk != "" {
+	if listCodeContext && comment.DiffHunk != "" && !(listHunkLocal && displayLocalCodeContext(comment, listContextLines)) {
 		fmt.Printf("\n%s\n", ui.Colorize(ui.ColorYellow, "Context:"))
-		fmt.Println(ui.ColorizeDiff(comment.DiffHunk))
+		hunk := codeContext(comment, listContextLines)
+		if listWordDiff {
+			fmt.Println(ui.ColorizeDiffWords(hunk))
+		} else {
+			fmt.Println(ui.ColorizeDiff(hunk))
+		}
+	}
+
+	if listLocalContext {
+		displayLocalContext(comment)
 	}
 
 	// Show thread comments (replies)
@@ -199,7 +763,7 @@ func displayComment(index, total int, comment *github.ReviewComment) {
 		fmt.Printf("\n%s\n", ui.Colorize(ui.ColorCyan, "Thread replies:"))
 		for i, threadComment := range comment.ThreadComments {
 			fmt.Printf("\n  %s\n", ui.Colorize(ui.ColorGray, fmt.Sprintf("└─ Reply %d by @%s:", i+1, threadComment.Author)))
-			rendered, err := ui.RenderMarkdown(threadComment.Body)
+			rendered, err := ui.RenderMarkdownWidth(threadComment.Body, ui.IndentedTextWidth(5))
 			if err == nil && rendered != "" {
 				// Indent the rendered markdown
 				lines := strings.Split(rendered, "\n")
@@ -208,7 +772,7 @@ func displayComment(index, total int, comment *github.ReviewComment) {
 				}
 			} else {
 				// Fallback to wrapped text
-				wrappedReply := ui.WrapText(threadComment.Body, 75)
+				wrappedReply := ui.WrapText(threadComment.Body, ui.IndentedTextWidth(5))
 				lines := strings.Split(wrappedReply, "\n")
 				for _, line := range lines {
 					fmt.Printf("     %s\n", line)
@@ -220,41 +784,145 @@ func displayComment(index, total int, comment *github.ReviewComment) {
 	fmt.Println()
 }
 
-// displayLLMFormat displays review comments in a readable format for LLM consumption
-func displayLLMFormat(comments []*github.ReviewComment) {
-	for i, comment := range comments {
-		if i > 0 {
-			fmt.Println("---")
-		}
+// displayLocalContext shows the lines of the local file around the comment,
+// as they are now rather than as the reviewer saw them
+func displayLocalContext(comment *github.ReviewComment) {
+	if comment.Line == 0 {
+		return
+	}
+	first := comment.Line
+	if comment.StartLine > 0 && comment.StartLine < comment.Line {
+		first = comment.StartLine
+	}
 
-		fmt.Printf("FILE: %s:%d\n", comment.Path, comment.Line)
-		fmt.Printf("COMMENT_ID: %d\n", comment.ID)
-		fmt.Printf("AUTHOR: %s\n", comment.Author)
-		fmt.Printf("URL: %s\n", comment.HTMLURL)
+	fmt.Printf("\n%s\n", ui.Colorize(ui.ColorYellow, "Local file:"))
+	start, lines, err := localContextWindow(comment.Path, first, comment.Line, localContextRadius)
+	if err != nil {
+		fmt.Printf("%s\n", ui.Colorize(ui.ColorGray, fmt.Sprintf("(unavailable: %v)", err)))
+		return
+	}
+	printLocalLines(comment, first, start, lines)
+}
 
-		if comment.IsResolved() {
-			fmt.Println("STATUS: resolved")
-		} else {
-			fmt.Println("STATUS: unresolved")
+// displayLocalCodeContext shows the --code-context lines of comment from the
+// local file, and reports false when they cannot be read so the stored hunk is
+// shown instead
+func displayLocalCodeContext(comment *github.ReviewComment, n int) bool {
+	first, last, err := localCodeContextRange(comment, n)
+	if err == nil {
+		var start int
+		var lines []string
+		start, lines, err = localContextWindow(comment.Path, first, last, 0)
+		if err == nil {
+			end := start + len(lines) - 1
+			fmt.Printf("\n%s\n", ui.Colorize(ui.ColorYellow, fmt.Sprintf("Context (local file, lines %d-%d):", start, end)))
+			commented := comment.Line
+			if comment.StartLine > 0 && comment.StartLine < comment.Line {
+				commented = comment.StartLine
+			}
+			printLocalLines(comment, commented, start, lines)
+			return true
 		}
+	}
+	fmt.Printf("\n%s\n", ui.Colorize(ui.ColorGray, fmt.Sprintf("(local file unavailable: %v, showing the diff hunk of the review)", err)))
+	return false
+}
 
-		// Show the review comment (without suggestion block)
-		commentText := ui.StripSuggestionBlock(comment.Body)
-		if commentText != "" {
-			fmt.Printf("COMMENT:\n%s\n", commentText)
+// localCodeContextRange returns the lines of the local file that match what
+// the diff hunk of comment covers: as many lines above the commented line as
+// the hunk (trimmed to n lines like codeContext when n is not negative) has
+// on its new side, and n lines below
+func localCodeContextRange(comment *github.ReviewComment, n int) (int, int, error) {
+	if comment.Line == 0 || comment.IsOutdated || comment.DiffSide == diffposition.DiffSideLeft {
+		return 0, 0, fmt.Errorf("the comment is not on a line of the current file")
+	}
+	dh, err := diffhunk.ParseDiffHunk(comment.DiffHunk)
+	if err != nil {
+		return 0, 0, err
+	}
+	if n >= 0 {
+		dh = dh.TrimBefore(firstCommentedLine(dh, comment), n)
+	}
+	return max(comment.Line-max(dh.NewLines-1, 0), 1), comment.Line + max(n, 0), nil
+}
+
+// printLocalLines prints lines of the local file, the first being number
+// start, with a gutter marking the lines from first to the comment's line
+func printLocalLines(comment *github.ReviewComment, first, start int, lines []string) {
+	highlighted := strings.Split(ui.HighlightCode(strings.Join(lines, "\n"), ui.CodeFenceLanguageFromPath(comment.Path)), "\n")
+	if len(highlighted) != len(lines) {
+		highlighted = lines
+	}
+	width := len(fmt.Sprintf("%d", start+len(lines)-1))
+	for i, line := range highlighted {
+		number := start + i
+		marker := " "
+		if number >= first && number <= comment.Line {
+			marker = ">"
 		}
+		gutter := fmt.Sprintf("%s %*d │", marker, width, number)
+		fmt.Printf("%s %s\n", ui.Colorize(ui.ColorGray, gutter), line)
+	}
+}
+
+// codeContext returns the diff hunk shown for comment: the whole hunk when n
+// is negative, otherwise n lines above the commented lines and, read from the
+// local file, n lines below them. GitHub hunks end at the commented line, so
+// only the local file has what follows.
+func codeContext(comment *github.ReviewComment, n int) string {
+	if n < 0 {
+		return comment.DiffHunk
+	}
+	dh, err := diffhunk.ParseDiffHunk(comment.DiffHunk)
+	if err != nil {
+		return comment.DiffHunk
+	}
 
-		// Show the suggestion if present
-		if comment.HasSuggestion {
-			fmt.Printf("SUGGESTION:\n%s\n", comment.SuggestedCode)
+	dh = dh.TrimBefore(firstCommentedLine(dh, comment), n)
+	if n > 0 && !comment.IsOutdated && comment.DiffSide != diffposition.DiffSideLeft && comment.Line > 0 {
+		if _, after, err := localContextWindow(comment.Path, comment.Line+1, comment.Line+n, 0); err == nil {
+			dh.AppendContext(after)
 		}
+	}
+	return dh.String()
+}
 
-		// Show thread replies
-		if len(comment.ThreadComments) > 0 {
-			fmt.Println("REPLIES:")
-			for j, reply := range comment.ThreadComments {
-				fmt.Printf("  [%d] %s: %s\n", j+1, reply.Author, reply.Body)
-			}
+// firstCommentedLine returns the index in the hunk of the first line the
+// comment is on, or of the last line when it cannot be found
+func firstCommentedLine(dh *diffhunk.DiffHunk, comment *github.ReviewComment) int {
+	for i, line := range dh.Lines {
+		number := line.NewLineNumber
+		if comment.DiffSide == diffposition.DiffSideLeft {
+			number = line.OldLineNumber
+		}
+		if comment.OriginalStartLine > 0 && number == comment.OriginalStartLine {
+			return i
 		}
 	}
+	return len(dh.Lines) - 1
+}
+
+// localContextWindow returns the lines first-radius to last+radius (1-based,
+// inclusive) of the file at path, clamped to the file, along with the number
+// of the first line returned
+func localContextWindow(path string, first, last, radius int) (int, []string, error) {
+	if first < 1 || first > last {
+		return 0, nil, fmt.Errorf("invalid line range %d-%d", first, last)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if len(data) == 0 {
+		lines = nil
+	}
+	if first > len(lines) {
+		return 0, nil, fmt.Errorf("line %d is past the end of %s (%d lines)", first, path, len(lines))
+	}
+
+	start := max(first-radius, 1)
+	end := min(last+radius, len(lines))
+	return start, lines[start-1 : end], nil
 }
@@ -0,0 +1,281 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chmouel/gh-prreview/pkg/diffposition"
+	"github.com/chmouel/gh-prreview/pkg/github"
+	"github.com/spf13/cobra"
+)
+
+func TestLocalContextWindow(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main.go")
+	content := "l1\nl2\nl3\nl4\nl5\nl6\nl7\nl8\nl9\nl10\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		first, last int
+		wantStart   int
+		wantLines   []string
+		wantErr     string
+	}{
+		{"middle of the file", 5, 5, 3, []string{"l3", "l4", "l5", "l6", "l7"}, ""},
+		{"multi-line range", 4, 6, 2, []string{"l2", "l3", "l4", "l5", "l6", "l7", "l8"}, ""},
+		{"clamped at the start", 1, 1, 1, []string{"l1", "l2", "l3"}, ""},
+		{"clamped at the end", 10, 10, 8, []string{"l8", "l9", "l10"}, ""},
+		{"range running past the end", 9, 12, 7, []string{"l7", "l8", "l9", "l10"}, ""},
+		{"line past the end", 11, 11, 0, nil, "past the end"},
+		{"invalid range", 0, 0, 0, nil, "invalid line range"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, lines, err := localContextWindow(path, tt.first, tt.last, 2)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("localContextWindow() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("localContextWindow() error = %v", err)
+			}
+			if start != tt.wantStart {
+				t.Errorf("start = %d, want %d", start, tt.wantStart)
+			}
+			if !reflect.DeepEqual(lines, tt.wantLines) {
+				t.Errorf("lines = %q, want %q", lines, tt.wantLines)
+			}
+		})
+	}
+}
+
+func TestLocalContextWindowMissingFile(t *testing.T) {
+	_, _, err := localContextWindow(filepath.Join(t.TempDir(), "gone.go"), 1, 1, 2)
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("localContextWindow() error = %v, want os.ErrNotExist", err)
+	}
+}
+
+func TestDiffComments(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	before := []*github.ReviewComment{
+		{
+			ID: 1, Author: "alice", CreatedAt: t0,
+			ThreadComments: []github.ThreadComment{{ID: 2, Author: "bob", CreatedAt: t0}},
+		},
+		{ID: 3, Author: "alice", CreatedAt: t0},
+	}
+	after := []*github.ReviewComment{
+		{
+			ID: 1, Author: "alice", CreatedAt: t0,
+			ThreadComments: []github.ThreadComment{
+				{ID: 2, Author: "bob", CreatedAt: t0, UpdatedAt: t0.Add(time.Minute)},
+				{ID: 4, Author: "alice", CreatedAt: t0.Add(2 * time.Minute)},
+			},
+		},
+		{ID: 3, Author: "alice", CreatedAt: t0, UpdatedAt: t0},
+		{ID: 5, Author: "carol", CreatedAt: t0.Add(3 * time.Minute)},
+	}
+
+	changes := diffComments(commentVersions(before), after)
+
+	type summary struct {
+		id      int64
+		thread  int64
+		updated bool
+	}
+	var got []summary
+	for _, change := range changes {
+		got = append(got, summary{change.ID, change.Thread.ID, change.Updated})
+	}
+	want := []summary{
+		{2, 1, true},
+		{4, 1, false},
+		{5, 5, false},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("diffComments() = %+v, want %+v", got, want)
+	}
+
+	if changes := diffComments(commentVersions(after), after); len(changes) != 0 {
+		t.Errorf("diffComments() without changes = %d changes, want none", len(changes))
+	}
+}
+
+func TestFailIfAny(t *testing.T) {
+	comments := []*github.ReviewComment{{ID: 1}, {ID: 2}}
+
+	tests := []struct {
+		name     string
+		enabled  bool
+		all      bool
+		comments []*github.ReviewComment
+		wantErr  string
+	}{
+		{"disabled", false, false, comments, ""},
+		{"no comments", true, false, nil, ""},
+		{"unresolved comments", true, false, comments, "2 unresolved review comment(s) found"},
+		{"with --all", true, true, comments, "2 review comment(s) found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previousFail, previousAll := listFailIfAny, listShowResolved
+			defer func() { listFailIfAny, listShowResolved = previousFail, previousAll }()
+			listFailIfAny, listShowResolved = tt.enabled, tt.all
+
+			cmd := &cobra.Command{}
+			err := failIfAny(cmd, tt.comments)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("failIfAny() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("failIfAny() error = %v, want %q", err, tt.wantErr)
+			}
+			if !cmd.SilenceUsage || !cmd.SilenceErrors {
+				t.Error("failIfAny() should silence the usage and cobra's error output")
+			}
+		})
+	}
+}
+
+func TestPartitionResolved(t *testing.T) {
+	comments := []*github.ReviewComment{
+		{ID: 1, SubjectType: "resolved"},
+		{ID: 2, SubjectType: "line"},
+		{ID: 3, SubjectType: "resolved"},
+		{ID: 4, SubjectType: "file"},
+	}
+
+	unresolved, resolved := partitionResolved(comments)
+	if got := collectCommentIDs(unresolved); !reflect.DeepEqual(got, []int64{2, 4}) {
+		t.Errorf("unresolved = %v, want [2 4]", got)
+	}
+	if got := collectCommentIDs(resolved); !reflect.DeepEqual(got, []int64{1, 3}) {
+		t.Errorf("resolved = %v, want [1 3]", got)
+	}
+}
+
+func TestCodeContext(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.WriteFile("main.go", []byte("l1\nl2\nl3\nl4\nL5\nl6\nl7\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	hunk := "@@ -1,4 +1,5 @@\n l1\n l2\n l3\n l4\n+L5"
+	comment := &github.ReviewComment{Path: "main.go", Line: 5, OriginalStartLine: 5, DiffHunk: hunk}
+
+	tests := []struct {
+		name    string
+		n       int
+		comment *github.ReviewComment
+		want    string
+	}{
+		{name: "full hunk by default", n: -1, comment: comment, want: hunk},
+		{name: "trimmed and expanded", n: 1, comment: comment, want: "@@ -4,2 +4,3 @@\n l4\n+L5\n l6"},
+		{name: "past the end of the file", n: 5, comment: comment, want: "@@ -1,6 +1,7 @@\n l1\n l2\n l3\n l4\n+L5\n l6\n l7"},
+		{
+			name:    "outdated comments are not expanded",
+			n:       1,
+			comment: &github.ReviewComment{Path: "main.go", Line: 5, OriginalStartLine: 5, DiffHunk: hunk, IsOutdated: true},
+			want:    "@@ -4,1 +4,2 @@\n l4\n+L5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := codeContext(tt.comment, tt.n); got != tt.want {
+				t.Errorf("codeContext() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalCodeContextRange(t *testing.T) {
+	hunk := "@@ -1,4 +1,5 @@\n l1\n l2\n l3\n l4\n+L5"
+	comment := &github.ReviewComment{Path: "main.go", Line: 5, OriginalStartLine: 5, DiffHunk: hunk}
+
+	tests := []struct {
+		name      string
+		n         int
+		comment   *github.ReviewComment
+		wantFirst int
+		wantLast  int
+		wantErr   bool
+	}{
+		{name: "whole hunk", n: -1, comment: comment, wantFirst: 1, wantLast: 5},
+		{name: "trimmed hunk and lines below", n: 1, comment: comment, wantFirst: 4, wantLast: 6},
+		{
+			name:      "line moved since the review",
+			n:         -1,
+			comment:   &github.ReviewComment{Path: "main.go", Line: 9, OriginalStartLine: 5, DiffHunk: hunk},
+			wantFirst: 5, wantLast: 9,
+		},
+		{
+			name:    "outdated comment",
+			n:       -1,
+			comment: &github.ReviewComment{Path: "main.go", Line: 5, DiffHunk: hunk, IsOutdated: true},
+			wantErr: true,
+		},
+		{
+			name:    "comment on a removed line",
+			n:       -1,
+			comment: &github.ReviewComment{Path: "main.go", Line: 5, DiffHunk: hunk, DiffSide: diffposition.DiffSideLeft},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, last, err := localCodeContextRange(tt.comment, tt.n)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("localCodeContextRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (first != tt.wantFirst || last != tt.wantLast) {
+				t.Errorf("localCodeContextRange() = %d-%d, want %d-%d", first, last, tt.wantFirst, tt.wantLast)
+			}
+		})
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "2026-03-01", want: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{value: "7d", want: time.Date(2026, 3, 3, 15, 0, 0, 0, time.UTC)},
+		{value: "48h", want: time.Date(2026, 3, 8, 15, 0, 0, 0, time.UTC)},
+		{value: "90m", want: time.Date(2026, 3, 10, 13, 30, 0, 0, time.UTC)},
+		{value: "-2d", wantErr: true},
+		{value: "last week", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseSince(tt.value, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSince(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("parseSince(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/template"
+
+	"github.com/chmouel/gh-prreview/pkg/github"
+	"github.com/chmouel/gh-prreview/pkg/ui"
+)
+
+// defaultLLMTemplate renders one comment of the list --llm output. It is
+// executed with the *github.ReviewComment; --llm-template replaces it.
+const defaultLLMTemplate = `FILE: {{.LocationLabel}}
+COMMENT_ID: {{.ID}}
+{{- if .ThreadID}}
+THREAD_ID: {{.ThreadID}}
+{{- end}}
+AUTHOR: {{.Author}}
+URL: {{.HTMLURL}}
+{{- if .OriginalCommitID}}
+COMMIT: {{.OriginalCommitID}}
+{{- end}}
+STATUS: {{if .IsResolved}}resolved{{else}}unresolved{{end}}
+{{- with stripSuggestion .Body}}
+COMMENT:
+{{.}}
+{{- end}}
+{{- if .IsDeletion}}
+SUGGESTION: delete the commented lines
+{{- else if .HasSuggestion}}
+SUGGESTION:
+{{.SuggestedCode}}
+{{- end}}
+{{- if .ThreadComments}}
+REPLIES:
+{{- range $i, $reply := .ThreadComments}}
+  [{{add $i 1}}] {{$reply.Author}}: {{$reply.Body}}
+{{- end}}
+{{- end}}
+`
+
+// llmTemplateFuncs are the functions available to LLM templates
+var llmTemplateFuncs = template.FuncMap{
+	"add":             func(a, b int) int { return a + b },
+	"stripSuggestion": ui.StripSuggestionBlock,
+}
+
+// loadLLMTemplate parses the template file at path, or the default template
+// when path is empty
+func loadLLMTemplate(path string) (*template.Template, error) {
+	text := defaultLLMTemplate
+	if path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read LLM template: %w", err)
+		}
+		text = string(content)
+	}
+
+	tmpl, err := template.New("llm").Funcs(llmTemplateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse LLM template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// displayLLMFormat writes each comment through tmpl, separated by "---" lines
+func displayLLMFormat(w io.Writer, tmpl *template.Template, comments []*github.ReviewComment) error {
+	for i, comment := range comments {
+		if i > 0 {
+			if _, err := fmt.Fprintln(w, "---"); err != nil {
+				return err
+			}
+		}
+		if err := tmpl.Execute(w, comment); err != nil {
+			return fmt.Errorf("failed to execute LLM template for comment %d: %w", comment.ID, err)
+		}
+	}
+	return nil
+}
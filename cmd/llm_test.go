@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chmouel/gh-prreview/pkg/github"
+)
+
+func TestDisplayLLMFormatDefaultTemplate(t *testing.T) {
+	comments := []*github.ReviewComment{
+		{
+			ID:               1,
+			ThreadID:         "PRRT_1",
+			Path:             "main.go",
+			Line:             5,
+			StartLine:        5,
+			EndLine:          5,
+			Author:           "alice",
+			HTMLURL:          "https://example.com/1",
+			OriginalCommitID: "abc123",
+			Body:             "Use fmt\n```suggestion\nfmt.Println()\n```",
+			HasSuggestion:    true,
+			SuggestedCode:    "fmt.Println()",
+			ThreadComments:   []github.ThreadComment{{Author: "bob", Body: "Done"}},
+		},
+		{
+			ID:            2,
+			Path:          "old.go",
+			Line:          3,
+			StartLine:     3,
+			EndLine:       3,
+			Author:        "carol",
+			HTMLURL:       "https://example.com/2",
+			Body:          "```suggestion\n```",
+			HasSuggestion: true,
+			IsDeletion:    true,
+		},
+	}
+
+	tmpl, err := loadLLMTemplate("")
+	if err != nil {
+		t.Fatalf("loadLLMTemplate() returned error: %v", err)
+	}
+	var out strings.Builder
+	if err := displayLLMFormat(&out, tmpl, comments); err != nil {
+		t.Fatalf("displayLLMFormat() returned error: %v", err)
+	}
+
+	want := "FILE: " + comments[0].LocationLabel() + "\n" +
+		"COMMENT_ID: 1\n" +
+		"THREAD_ID: PRRT_1\n" +
+		"AUTHOR: alice\n" +
+		"URL: https://example.com/1\n" +
+		"COMMIT: abc123\n" +
+		"STATUS: unresolved\n" +
+		"COMMENT:\nUse fmt\n" +
+		"SUGGESTION:\nfmt.Println()\n" +
+		"REPLIES:\n  [1] bob: Done\n" +
+		"---\n" +
+		"FILE: " + comments[1].LocationLabel() + "\n" +
+		"COMMENT_ID: 2\n" +
+		"AUTHOR: carol\n" +
+		"URL: https://example.com/2\n" +
+		"STATUS: unresolved\n" +
+		"SUGGESTION: delete the commented lines\n"
+	if out.String() != want {
+		t.Errorf("displayLLMFormat() =\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func TestLoadLLMTemplateFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prompt.tmpl")
+	if err := os.WriteFile(path, []byte("{{.Path}}:{{.Line}} {{.Author}}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl, err := loadLLMTemplate(path)
+	if err != nil {
+		t.Fatalf("loadLLMTemplate() returned error: %v", err)
+	}
+	var out strings.Builder
+	comments := []*github.ReviewComment{{Path: "a.go", Line: 1, Author: "alice"}, {Path: "b.go", Line: 2, Author: "bob"}}
+	if err := displayLLMFormat(&out, tmpl, comments); err != nil {
+		t.Fatalf("displayLLMFormat() returned error: %v", err)
+	}
+	if want := "a.go:1 alice\n---\nb.go:2 bob\n"; out.String() != want {
+		t.Errorf("displayLLMFormat() = %q, want %q", out.String(), want)
+	}
+}
+
+func TestLoadLLMTemplateErrors(t *testing.T) {
+	if _, err := loadLLMTemplate(filepath.Join(t.TempDir(), "missing.tmpl")); err == nil || !strings.Contains(err.Error(), "failed to read LLM template") {
+		t.Errorf("missing template error = %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "broken.tmpl")
+	if err := os.WriteFile(path, []byte("{{.Path"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadLLMTemplate(path); err == nil || !strings.Contains(err.Error(), "failed to parse LLM template") {
+		t.Errorf("broken template error = %v", err)
+	}
+}
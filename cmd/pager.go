@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/chmouel/gh-prreview/pkg/ui"
+	"golang.org/x/term"
+)
+
+// defaultPager keeps colors and hyperlinks (-R), exits right away when the
+// output fits on one screen (-F) and leaves it on the screen afterwards (-X)
+const defaultPager = "less -FRX"
+
+// pagerCommand returns the pager command line from $PAGER, or the default
+// one. An empty result means paging is disabled ($PAGER set to "" or "cat").
+func pagerCommand() []string {
+	pager, ok := os.LookupEnv("PAGER")
+	if !ok {
+		pager = defaultPager
+	}
+	fields := strings.Fields(pager)
+	if len(fields) == 0 || fields[0] == "cat" {
+		return nil
+	}
+	return fields
+}
+
+// startPager sends stdout through the pager while stdout is a terminal. The
+// returned function restores stdout and waits for the user to quit the pager.
+// When the pager cannot be started, output goes to the terminal as usual.
+func startPager() func() {
+	if !term.IsTerminal(int(os.Stdout.Fd())) {
+		return func() {}
+	}
+	args := pagerCommand()
+	if args == nil {
+		return func() {}
+	}
+
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return func() {}
+	}
+	pager := exec.Command(args[0], args[1:]...)
+	pager.Stdin = reader
+	pager.Stdout = os.Stdout
+	pager.Stderr = os.Stderr
+	if _, ok := os.LookupEnv("LESS"); !ok {
+		pager.Env = append(os.Environ(), "LESS=FRX")
+	}
+	if err := pager.Start(); err != nil {
+		reader.Close()
+		writer.Close()
+		return func() {}
+	}
+	reader.Close()
+
+	// Wrapping is sized for the terminal, not for the pipe
+	ui.SetTerminalWidth(ui.TerminalWidth())
+	stdout := os.Stdout
+	os.Stdout = writer
+	return func() {
+		os.Stdout = stdout
+		writer.Close()
+		_ = pager.Wait()
+		ui.SetTerminalWidth(0)
+	}
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestPagerCommand(t *testing.T) {
+	tests := []struct {
+		name  string
+		pager *string
+		want  []string
+	}{
+		{"default", nil, []string{"less", "-FRX"}},
+		{"from PAGER", stringPtr("most -s"), []string{"most", "-s"}},
+		{"disabled with cat", stringPtr("cat"), nil},
+		{"disabled when empty", stringPtr(""), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("PAGER", "")
+			if tt.pager == nil {
+				os.Unsetenv("PAGER")
+			} else {
+				t.Setenv("PAGER", *tt.pager)
+			}
+			if got := pagerCommand(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("pagerCommand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
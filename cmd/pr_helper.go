@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/chmouel/gh-prreview/pkg/github"
 	"github.com/chmouel/gh-prreview/pkg/ui"
@@ -29,15 +30,26 @@ func getPRNumberWithSelection(args []string, client *github.Client) (int, error)
 		fmt.Fprintf(os.Stderr, "Auto-detected PR #%d for current branch\n", prNumber)
 		return prNumber, nil
 	}
+	if errors.Is(err, github.ErrRateLimited) {
+		return 0, err
+	}
 
-	// Fallback: Interactive PR selection
-	prs, err := client.ListOpenPRs()
+	// Fallback: Interactive PR selection, which needs a terminal
+	if !ui.Interactive() {
+		return 0, fmt.Errorf("no PR found for current branch, pass a PR number: %w", ui.ErrNotInteractive)
+	}
+	var prs []*github.PullRequest
+	err = withSpinner("Fetching "+prStateQualifier()+"pull requests", func() error {
+		var err error
+		prs, err = client.ListPRs(prLimit, prState)
+		return err
+	})
 	if err != nil {
 		return 0, fmt.Errorf("no PR found for current branch and failed to list PRs: %w", err)
 	}
 
 	if len(prs) == 0 {
-		return 0, fmt.Errorf("no open pull requests found")
+		return 0, fmt.Errorf("no %spull requests found", prStateQualifier())
 	}
 
 	selected, err := ui.SelectPR(prs)
@@ -45,12 +57,34 @@ func getPRNumberWithSelection(args []string, client *github.Client) (int, error)
 		if errors.Is(err, ui.ErrNoSelection) {
 			os.Exit(0) // Silent exit on cancel
 		}
+		if errors.Is(err, ui.ErrNotInteractive) {
+			return 0, fmt.Errorf("no PR found for current branch, pass a PR number: %w", err)
+		}
 		return 0, err
 	}
 
 	return selected.Number, nil
 }
 
+// validatePRState checks the --state value
+func validatePRState() error {
+	switch prState {
+	case github.PRStateOpen, github.PRStateClosed, github.PRStateAll:
+		return nil
+	default:
+		return fmt.Errorf("invalid --state %q (expected %s, %s or %s)", prState, github.PRStateOpen, github.PRStateClosed, github.PRStateAll)
+	}
+}
+
+// prStateQualifier returns the word to put before "pull requests" in the
+// messages about the PRs --state selects, with a trailing space
+func prStateQualifier() string {
+	if prState == github.PRStateAll {
+		return ""
+	}
+	return prState + " "
+}
+
 // getRepoFromClient extracts the repository name from the client
 func getRepoFromClient(client *github.Client) string {
 	// Use the global repoFlag if set
@@ -67,3 +101,109 @@ func getRepoFromClient(client *github.Client) string {
 	// Fallback - we'll construct the URL without the repo part
 	return "owner/repo"
 }
+
+// prURL returns the web URL of a pull request on the client's GitHub host
+func prURL(client *github.Client, prNumber int) string {
+	return fmt.Sprintf("%s/%s/pull/%d", client.BaseWebURL(), getRepoFromClient(client), prNumber)
+}
+
+// openPRInBrowser opens the page of the pull request, for --web
+func openPRInBrowser(client *github.Client, prNumber int) error {
+	url := prURL(client, prNumber)
+	if err := ui.OpenURL(url); err != nil {
+		return err
+	}
+	fmt.Printf("Opening %s in your browser.\n", url)
+	return nil
+}
+
+// commentURL returns the web URL of a review comment on the client's GitHub host
+func commentURL(client *github.Client, prNumber int, commentID int64) string {
+	return fmt.Sprintf("%s#discussion_r%d", prURL(client, prNumber), commentID)
+}
+
+// commentURLArg handles a comment URL given where a COMMENT_ID is expected:
+// it returns the PR number and comment ID from the URL and points client at
+// the URL's host and repository. ok is false when arg is not a URL.
+func commentURLArg(client *github.Client, arg string) (prNumber int, commentID int64, ok bool, err error) {
+	if !strings.Contains(arg, "://") {
+		return 0, 0, false, nil
+	}
+	host, repo, prNumber, commentID, err := github.ParseCommentURL(arg)
+	if err != nil {
+		return 0, 0, true, err
+	}
+	if repoFlag != "" && !strings.EqualFold(repoFlag, repo) {
+		return 0, 0, true, fmt.Errorf("comment URL is for %s, but --repo is %s", repo, repoFlag)
+	}
+	client.SetHost(host)
+	client.SetRepo(repo)
+	return prNumber, commentID, true, nil
+}
+
+// excludeOwnComments drops the comments authored by the current user when
+// enabled (--exclude-me)
+func excludeOwnComments(client *github.Client, comments []*github.ReviewComment, enabled bool) ([]*github.ReviewComment, error) {
+	if !enabled {
+		return comments, nil
+	}
+	login, err := client.CurrentUser()
+	if err != nil {
+		return nil, err
+	}
+	return github.ExcludeAuthor(comments, login), nil
+}
+
+// checkKindFlags rejects --suggestions-only combined with --discussion-only
+func checkKindFlags(suggestionsOnly, discussionOnly bool) error {
+	if suggestionsOnly && discussionOnly {
+		return fmt.Errorf("--suggestions-only cannot be combined with --discussion-only")
+	}
+	return nil
+}
+
+// filterByKind keeps only the comments carrying a suggestion
+// (--suggestions-only) or only the discussion ones (--discussion-only)
+func filterByKind(comments []*github.ReviewComment, suggestionsOnly, discussionOnly bool) []*github.ReviewComment {
+	if !suggestionsOnly && !discussionOnly {
+		return comments
+	}
+	filtered := make([]*github.ReviewComment, 0, len(comments))
+	for _, comment := range comments {
+		if comment.HasSuggestion == suggestionsOnly {
+			filtered = append(filtered, comment)
+		}
+	}
+	return filtered
+}
+
+// fetchReviewComments fetches the review comments of a PR, with a spinner
+// showing the current stage
+func fetchReviewComments(client *github.Client, prNumber int) ([]*github.ReviewComment, error) {
+	var comments []*github.ReviewComment
+	client.SetProgressFunc(setSpinnerMessage)
+	defer client.SetProgressFunc(nil)
+	err := withSpinner("Fetching review comments", func() error {
+		var err error
+		comments, err = client.FetchReviewComments(prNumber)
+		return err
+	})
+	return comments, err
+}
+
+// fetchFileReviewComments is fetchReviewComments limited to the comments on
+// path, or all comments when path is empty
+func fetchFileReviewComments(client *github.Client, prNumber int, path string) ([]*github.ReviewComment, error) {
+	if path == "" {
+		return fetchReviewComments(client, prNumber)
+	}
+	var comments []*github.ReviewComment
+	client.SetProgressFunc(setSpinnerMessage)
+	defer client.SetProgressFunc(nil)
+	err := withSpinner("Fetching review comments on "+path, func() error {
+		var err error
+		comments, err = client.FetchReviewCommentsForPath(prNumber, path)
+		return err
+	})
+	return comments, err
+}
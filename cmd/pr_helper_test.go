@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/chmouel/gh-prreview/pkg/github"
+)
+
+func TestCommentURLUsesClientHost(t *testing.T) {
+	oldRepo := repoFlag
+	repoFlag = "owner/repo"
+	t.Cleanup(func() { repoFlag = oldRepo })
+
+	client := github.NewClient()
+	client.SetHost("github.example.com")
+
+	if got, want := prURL(client, 42), "https://github.example.com/owner/repo/pull/42"; got != want {
+		t.Errorf("prURL() = %q, want %q", got, want)
+	}
+	if got, want := commentURL(client, 42, 7), "https://github.example.com/owner/repo/pull/42#discussion_r7"; got != want {
+		t.Errorf("commentURL() = %q, want %q", got, want)
+	}
+}
+
+func TestCommentURLArg(t *testing.T) {
+	oldRepo := repoFlag
+	t.Cleanup(func() { repoFlag = oldRepo })
+
+	repoFlag = ""
+	client := github.NewClient()
+
+	if _, _, isURL, err := commentURLArg(client, "456"); isURL || err != nil {
+		t.Errorf("commentURLArg(ID) = %v, %v, want not a URL", isURL, err)
+	}
+
+	pr, id, isURL, err := commentURLArg(client, "https://github.example.com/owner/repo/pull/42#discussion_r7")
+	if err != nil || !isURL || pr != 42 || id != 7 {
+		t.Fatalf("commentURLArg(URL) = %d, %d, %v, %v", pr, id, isURL, err)
+	}
+	if got, want := commentURL(client, pr, id), "https://github.example.com/owner/repo/pull/42#discussion_r7"; got != want {
+		t.Errorf("commentURL() after commentURLArg = %q, want %q", got, want)
+	}
+
+	if _, _, isURL, err := commentURLArg(client, "https://github.com/owner/repo/pull/42"); !isURL || err == nil {
+		t.Errorf("commentURLArg(PR URL) = %v, %v, want an error", isURL, err)
+	}
+
+	repoFlag = "other/repo"
+	if _, _, _, err := commentURLArg(client, "https://github.com/owner/repo/pull/42#discussion_r7"); err == nil {
+		t.Error("commentURLArg() with a different --repo should return an error")
+	}
+}
+
+func TestFilterByKind(t *testing.T) {
+	comments := []*github.ReviewComment{
+		{ID: 1, HasSuggestion: true},
+		{ID: 2},
+		{ID: 3, HasSuggestion: true, IsDeletion: true},
+	}
+
+	tests := []struct {
+		name            string
+		suggestionsOnly bool
+		discussionOnly  bool
+		want            []int64
+	}{
+		{"no filter", false, false, []int64{1, 2, 3}},
+		{"suggestions only", true, false, []int64{1, 3}},
+		{"discussion only", false, true, []int64{2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []int64
+			for _, comment := range filterByKind(comments, tt.suggestionsOnly, tt.discussionOnly) {
+				got = append(got, comment.ID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("filterByKind() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if err := checkKindFlags(true, true); err == nil {
+		t.Error("checkKindFlags() should reject both flags together")
+	}
+}
+
+func TestValidatePRState(t *testing.T) {
+	previous := prState
+	defer func() { prState = previous }()
+
+	qualifiers := map[string]string{
+		github.PRStateOpen:   "open ",
+		github.PRStateClosed: "closed ",
+		github.PRStateAll:    "",
+	}
+	for state, qualifier := range qualifiers {
+		prState = state
+		if err := validatePRState(); err != nil {
+			t.Errorf("validatePRState(%q) error = %v", state, err)
+		}
+		if got := prStateQualifier(); got != qualifier {
+			t.Errorf("prStateQualifier(%q) = %q, want %q", state, got, qualifier)
+		}
+	}
+
+	prState = "merged"
+	if err := validatePRState(); err == nil || !strings.Contains(err.Error(), "merged") {
+		t.Errorf("validatePRState(%q) error = %v, want invalid state error", prState, err)
+	}
+}
@@ -2,48 +2,138 @@ package cmd
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 
 	"github.com/chmouel/gh-prreview/pkg/github"
+	"github.com/chmouel/gh-prreview/pkg/state"
 	"github.com/chmouel/gh-prreview/pkg/ui"
 	"github.com/spf13/cobra"
 )
 
 var (
 	resolveUnresolve bool
-	resolveDebug     bool
 	resolveAll       bool
 	resolveComment   string
+	resolveReaction  string
+	resolveFile      string
+	resolveAuthor    string
+	resolveYes       bool
+	resolveUnsub     bool
+	resolveDryRun    bool
+	resolveReact     string
+	resolveWeb       bool
+	resolveInteract  bool
+	resolveForce     bool
 )
 
 var resolveCmd = &cobra.Command{
 	Use:   "resolve [COMMENT_ID] or [PR_NUMBER] [COMMENT_ID]",
 	Short: "Resolve or unresolve review comment threads",
-	Long: `Mark review comment threads as resolved or unresolved. Use --all to apply the action to all unresolved comments on a PR.
+	Long: `Mark review comment threads as resolved or unresolved. Use --all to apply the action to all unresolved comments on a PR, or add --file PATH to limit it to one file.
 When no arguments are provided, PR is inferred from the current branch and you will be prompted for a comment ID.
 When one argument is provided, it's treated as COMMENT_ID and PR is inferred from the current branch.
-When two arguments are provided, the first is PR_NUMBER and the second is COMMENT_ID.`,
+When two arguments are provided, the first is PR_NUMBER and the second is COMMENT_ID.
+A comment URL copied from the browser (...pull/123#discussion_r456) can be given instead of COMMENT_ID; the PR and repository come from the URL.
+Use --from-reactions EMOJI to resolve every unresolved thread where the PR author reacted with EMOJI
+(e.g. 🚀); the only optional argument is then PR_NUMBER.
+Use --react EMOJI to also react on the first comment of each thread (e.g. 👍) before it is resolved.
+Use --web to open the PR in the browser instead; the only optional argument is then PR_NUMBER.
+Use --interactive to pick the threads to resolve from a list: space marks a comment, or every comment
+of a file on its header, and enter resolves the marked ones; the only optional argument is then PR_NUMBER.`,
 	Args: cobra.MinimumNArgs(0),
 	RunE: runResolve,
 }
 
 func init() {
 	resolveCmd.Flags().BoolVar(&resolveUnresolve, "unresolve", false, "Mark the thread as unresolved instead of resolved")
-	resolveCmd.Flags().BoolVar(&resolveDebug, "debug", false, "Enable debug output")
 	resolveCmd.Flags().BoolVar(&resolveAll, "all", false, "Apply action to all unresolved comments on the PR")
 	resolveCmd.Flags().StringVarP(&resolveComment, "comment", "c", "", "Add a comment when resolving")
+	resolveCmd.Flags().BoolVarP(&resolveYes, "yes", "y", false, "Post the --comment reply without showing a preview and asking for confirmation")
+	resolveCmd.Flags().StringVar(&resolveFile, "file", "", "With --all, only act on the threads of this file")
+	resolveCmd.Flags().StringVar(&resolveAuthor, "author", "", "With --all, only act on the threads started by this reviewer (e.g. Copilot)")
+	resolveCmd.Flags().BoolVar(&resolveUnsub, "unsubscribe", false, "Also stop notifications for the PR once threads are resolved")
+	resolveCmd.Flags().BoolVar(&resolveDryRun, "dry-run", false, "With --all or --from-reactions, only list the threads that would be changed")
+	resolveCmd.Flags().StringVar(&resolveReact, "react", "", "Also react with this emoji (e.g. 👍) on the first comment of each thread resolved")
+	resolveCmd.Flags().BoolVar(&resolveInteract, "interactive", false, "Pick the unresolved threads to resolve in a list, marking them with space")
+	resolveCmd.Flags().BoolVar(&resolveWeb, "web", false, "Open the pull request in the browser instead of changing any thread")
+	resolveCmd.Flags().BoolVar(&resolveForce, "force", false, "Resolve threads even when the change applied locally is no longer found")
+	resolveCmd.Flags().StringVar(&resolveReaction, "from-reactions", "", "Resolve threads where the PR author reacted with this emoji (e.g. 🚀)")
 }
 
 func runResolve(cmd *cobra.Command, args []string) error {
 	client := github.NewClient()
-	client.SetDebug(resolveDebug)
 	if repoFlag != "" {
 		client.SetRepo(repoFlag)
 	}
 
+	if resolveFile != "" && !resolveAll {
+		return fmt.Errorf("--file requires --all")
+	}
+	if resolveAuthor != "" && !resolveAll {
+		return fmt.Errorf("--author requires --all")
+	}
+	if resolveUnsub && resolveUnresolve {
+		return fmt.Errorf("--unsubscribe cannot be combined with --unresolve")
+	}
+	if resolveDryRun && !resolveAll && resolveReaction == "" {
+		return fmt.Errorf("--dry-run requires --all or --from-reactions")
+	}
+	if resolveReact != "" {
+		if resolveUnresolve || resolveReaction != "" {
+			return fmt.Errorf("--react cannot be combined with --unresolve or --from-reactions")
+		}
+		if _, err := github.NormalizeReaction(resolveReact); err != nil {
+			return err
+		}
+	}
+
+	if resolveWeb {
+		if len(args) > 1 {
+			return fmt.Errorf("--web accepts at most a PR_NUMBER argument")
+		}
+		prNumber, err := getPRNumberWithSelection(args, client)
+		if err != nil {
+			return err
+		}
+		return openPRInBrowser(client, prNumber)
+	}
+
+	if resolveInteract {
+		if resolveAll || resolveUnresolve || resolveReaction != "" || resolveDryRun {
+			return fmt.Errorf("--interactive cannot be combined with --all, --unresolve, --from-reactions or --dry-run")
+		}
+		if len(args) > 1 {
+			return fmt.Errorf("--interactive accepts at most a PR_NUMBER argument")
+		}
+		if !ui.Interactive() {
+			return fmt.Errorf("--interactive needs a terminal, use --all or a COMMENT_ID: %w", ui.ErrNotInteractive)
+		}
+		prNumber, err := getPRNumberWithSelection(args, client)
+		if err != nil {
+			return err
+		}
+		return resolveInteractively(client, prNumber)
+	}
+
+	if resolveReaction != "" {
+		if resolveUnresolve || resolveAll {
+			return fmt.Errorf("--from-reactions cannot be combined with --unresolve or --all")
+		}
+		if len(args) > 1 {
+			return fmt.Errorf("--from-reactions accepts at most a PR_NUMBER argument")
+		}
+		prNumber, err := getPRNumberWithSelection(args, client)
+		if err != nil {
+			return err
+		}
+		return resolveFromReactions(client, prNumber, resolveReaction)
+	}
+
 	var prNumber int
 	var commentID int64
 	var err error
@@ -69,15 +159,23 @@ func runResolve(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("invalid comment ID: %s", strings.TrimSpace(input))
 		}
 	} else if len(args) == 1 {
-		// One argument: treat as COMMENT_ID, infer PR from current branch
-		commentID, err = strconv.ParseInt(args[0], 10, 64)
-		if err != nil {
-			return fmt.Errorf("invalid comment ID: %s", args[0])
-		}
-		prNumber, err = client.GetCurrentBranchPR()
+		// One argument: a comment URL, which has both the PR and the
+		// COMMENT_ID, or a COMMENT_ID with the PR inferred from the branch
+		var isURL bool
+		prNumber, commentID, isURL, err = commentURLArg(client, args[0])
 		if err != nil {
 			return err
 		}
+		if !isURL {
+			commentID, err = strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid comment ID: %s", args[0])
+			}
+			prNumber, err = client.GetCurrentBranchPR()
+			if err != nil {
+				return err
+			}
+		}
 	} else if len(args) == 2 {
 		// Two arguments: first is PR, second is COMMENT_ID
 		prNumber, err = strconv.Atoi(args[0])
@@ -125,63 +223,169 @@ func resolveCommentText(text string) (string, error) {
 	return text, nil
 }
 
+// stdinReader is shared by the confirmation prompts, so answers piped on stdin
+// are not lost to a previous prompt's buffering
+var stdinReader = bufio.NewReader(os.Stdin)
+
+// quoteReplyBody indents a reply body behind a gutter so it stands out from
+// the surrounding output
+func quoteReplyBody(body string) string {
+	lines := strings.Split(body, "\n")
+	for i, line := range lines {
+		lines[i] = ui.Colorize(ui.ColorGray, "  │ ") + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatReplyPreview describes the reply about to be posted: the threads it
+// goes to, then the body
+func formatReplyPreview(body string, threads []*github.ReviewComment) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n", ui.Colorize(ui.ColorCyan, fmt.Sprintf("Reply to post on %d thread(s):", len(threads))))
+	for _, thread := range threads {
+		location := thread.LocationLabel()
+		fmt.Fprintf(&b, "  • %s (%s)\n",
+			ui.CreateHyperlink(thread.HTMLURL, location),
+			ui.Colorize(ui.ColorGray, fmt.Sprintf("Comment %d", thread.ID)))
+	}
+	fmt.Fprintf(&b, "\n%s\n", quoteReplyBody(body))
+	return b.String()
+}
+
+// confirmReply previews a --comment reply and asks before posting it, unless
+// --yes was given
+func confirmReply(in *bufio.Reader, body string, threads []*github.ReviewComment, action string) bool {
+	if resolveYes {
+		return true
+	}
+	fmt.Printf("\n%s", formatReplyPreview(body, threads))
+	return confirmPrompt(in, fmt.Sprintf("\n%s ",
+		ui.Colorize(ui.ColorYellow, fmt.Sprintf("Post this reply and %s? [y/N]:", action))))
+}
+
+// confirmPrompt prints prompt and reads a line from in, returning true only
+// for an explicit yes
+func confirmPrompt(in *bufio.Reader, prompt string) bool {
+	fmt.Print(prompt)
+	response, _ := in.ReadString('\n')
+	response = strings.ToLower(strings.TrimSpace(response))
+	return response == "y" || response == "yes"
+}
+
 func addCommentToReview(client *github.Client, prNumber int, commentID int64, commentBody string, commentLink string) error {
 	if _, err := client.ReplyToReviewComment(prNumber, commentID, commentBody); err != nil {
 		fmt.Printf("%sFailed to add comment to %s: %v\\n",
-			ui.Colorize(ui.ColorRed, ui.EmojiText("❌ ", "")),
+			ui.Colorize(ui.ColorRed, ui.EmojiText("❌ ", "FAIL: ")),
 			ui.Colorize(ui.ColorCyan, commentLink),
 			ui.Colorize(ui.ColorRed, err.Error()))
 		return err
 	}
 	fmt.Printf("%sComment added to %s\\n",
-		ui.Colorize(ui.ColorGreen, ui.EmojiText("✓ ", "")),
+		ui.Colorize(ui.ColorGreen, ui.EmojiText("✓ ", "OK: ")),
 		ui.Colorize(ui.ColorCyan, commentLink))
 	return nil
 }
 
+// reactToThread adds the --react reaction to the first comment of a thread.
+// A failure is reported and returned, so the thread is left unresolved.
+func reactToThread(client *github.Client, prNumber int, commentID int64, emoji string, commentLink string) error {
+	content, err := github.NormalizeReaction(emoji)
+	if err == nil {
+		err = client.AddReactionToComment(prNumber, commentID, content)
+	}
+	if err != nil {
+		fmt.Printf("%sFailed to react to %s: %v\n",
+			ui.Colorize(ui.ColorRed, ui.EmojiText("❌ ", "FAIL: ")),
+			ui.Colorize(ui.ColorCyan, commentLink),
+			ui.Colorize(ui.ColorRed, err.Error()))
+		return err
+	}
+	fmt.Printf("%s%s reaction added to %s\n",
+		ui.Colorize(ui.ColorGreen, ui.EmojiText("✓ ", "OK: ")),
+		emoji, ui.Colorize(ui.ColorCyan, commentLink))
+	return nil
+}
+
+// filterCommentsByPath returns the comments on path, or all comments when path
+// is empty. Paths are compared after cleaning, so "./pkg/a.go" matches "pkg/a.go".
+func filterCommentsByPath(comments []*github.ReviewComment, path string) []*github.ReviewComment {
+	if path == "" {
+		return comments
+	}
+
+	path = filepath.ToSlash(filepath.Clean(path))
+	var filtered []*github.ReviewComment
+	for _, comment := range comments {
+		if comment.Path == path {
+			filtered = append(filtered, comment)
+		}
+	}
+	return filtered
+}
+
+// groupCommentsByPath groups comments by file, in the order each file first
+// appears
+func groupCommentsByPath(comments []*github.ReviewComment) [][]*github.ReviewComment {
+	var groups [][]*github.ReviewComment
+	index := make(map[string]int)
+	for _, comment := range comments {
+		i, ok := index[comment.Path]
+		if !ok {
+			i = len(groups)
+			index[comment.Path] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], comment)
+	}
+	return groups
+}
+
 func resolveAllComments(client *github.Client, prNumber int) error {
 	// Fetch all review comments
-	comments, err := client.FetchReviewComments(prNumber)
+	comments, err := fetchReviewComments(client, prNumber)
 	if err != nil {
 		return fmt.Errorf("failed to fetch review comments: %w", err)
 	}
 
-	// Filter unresolved comments
-	var unresolvedComments []*github.ReviewComment
-	for _, comment := range comments {
-		if !comment.IsResolved() {
-			unresolvedComments = append(unresolvedComments, comment)
-		}
+	unresolvedComments := unresolvedCommentsFor(comments, resolveFile, resolveAuthor)
+
+	prLink := ui.CreateHyperlink(prURL(client, prNumber),
+		ui.Colorize(ui.ColorCyan, fmt.Sprintf("PR #%d", prNumber)))
+	target := prLink
+	if resolveFile != "" {
+		target = fmt.Sprintf("%s of %s", ui.Colorize(ui.ColorCyan, resolveFile), prLink)
+	}
+	if resolveAuthor != "" {
+		target = fmt.Sprintf("%s from %s", target, ui.Colorize(ui.ColorCyan, "@"+resolveAuthor))
 	}
 
 	if len(unresolvedComments) == 0 {
-		fmt.Printf("No unresolved comments found in %s\n",
-			ui.CreateHyperlink(fmt.Sprintf("https://github.com/%s/pull/%d", getRepoFromClient(client), prNumber),
-				ui.Colorize(ui.ColorCyan, fmt.Sprintf("PR #%d", prNumber))))
+		fmt.Printf("No unresolved comments found in %s\n", target)
 		return nil
 	}
 
 	// Show summary and ask for confirmation
-	prLink := ui.CreateHyperlink(fmt.Sprintf("https://github.com/%s/pull/%d", getRepoFromClient(client), prNumber),
-		ui.Colorize(ui.ColorCyan, fmt.Sprintf("PR #%d", prNumber)))
 	fmt.Printf("Found %s unresolved comment(s) in %s:\n",
-		ui.Colorize(ui.ColorYellow, fmt.Sprintf("%d", len(unresolvedComments))), prLink)
-
-	for _, comment := range unresolvedComments {
-		// Create clickable link to the review comment
-		fileLocation := fmt.Sprintf("%s:%d", comment.Path, comment.Line)
-		clickableLocation := ui.CreateHyperlink(comment.HTMLURL, fileLocation)
+		ui.Colorize(ui.ColorYellow, fmt.Sprintf("%d", len(unresolvedComments))), target)
+
+	for _, group := range groupCommentsByPath(unresolvedComments) {
+		fmt.Printf("  %s (%d)\n", ui.Colorize(ui.ColorCyan, group[0].Path), len(group))
+		for _, comment := range group {
+			// Create clickable link to the review comment
+			fileLocation := comment.LocationLabel()
+			clickableLocation := ui.CreateHyperlink(comment.HTMLURL, fileLocation)
+
+			// Truncate comment body and colorize it
+			commentPreview := ui.Truncate(ui.StripSuggestionBlock(comment.Body), 50)
+			if commentPreview == "" {
+				commentPreview = "(no text content)"
+			}
 
-		// Truncate comment body and colorize it
-		commentPreview := truncateString(ui.StripSuggestionBlock(comment.Body), 50)
-		if commentPreview == "" {
-			commentPreview = "(no text content)"
+			fmt.Printf("    • %s: %s (%s)\n",
+				ui.Colorize(ui.ColorCyan, fmt.Sprintf("Comment %d", comment.ID)),
+				ui.Colorize(ui.ColorGray, commentPreview),
+				ui.Colorize(ui.ColorGreen, clickableLocation))
 		}
-
-		fmt.Printf("  • %s: %s (%s)\n",
-			ui.Colorize(ui.ColorCyan, fmt.Sprintf("Comment %d", comment.ID)),
-			ui.Colorize(ui.ColorGray, commentPreview),
-			ui.Colorize(ui.ColorGreen, clickableLocation))
 	}
 
 	action := "resolve"
@@ -191,68 +395,229 @@ func resolveAllComments(client *github.Client, prNumber int) error {
 		actionColor = ui.ColorYellow
 	}
 
-	fmt.Printf("\n%s all %s comment(s)? [y/N]: ",
-		ui.Colorize(actionColor, fmt.Sprintf("Are you sure you want to %s", action)),
-		ui.Colorize(ui.ColorYellow, fmt.Sprintf("%d", len(unresolvedComments))))
-	reader := bufio.NewReader(os.Stdin)
-	response, err := reader.ReadString('\n')
-	if err != nil {
-		return fmt.Errorf("failed to read input: %w", err)
+	// Resolve comment text once (with @file support)
+	var commentText string
+	if resolveComment != "" {
+		commentText, err = resolveCommentText(resolveComment)
+		if err != nil {
+			return err
+		}
 	}
 
-	response = strings.ToLower(strings.TrimSpace(response))
-	if response != "y" && response != "yes" {
+	scope := resolveAllScope(resolveFile, resolveAuthor)
+	replyNote := ""
+	if commentText != "" {
+		// Every thread gets the same reply, show it before anything is posted
+		fmt.Printf("\n%s\n%s\n", ui.Colorize(ui.ColorCyan, "Reply to post on each thread:"), quoteReplyBody(commentText))
+		replyNote = " and post this reply on each"
+	}
+	if resolveReact != "" {
+		replyNote += fmt.Sprintf(" and react with %s on each", resolveReact)
+	}
+	if resolveDryRun {
+		fmt.Printf("\n%s\n", ui.Colorize(ui.ColorGray,
+			fmt.Sprintf("Dry run: would %s %d comment(s)%s%s, nothing was changed", action, len(unresolvedComments), scope, replyNote)))
+		return nil
+	}
+	prompt := fmt.Sprintf("\n%s all %s comment(s)%s%s? [y/N]: ",
+		ui.Colorize(actionColor, fmt.Sprintf("Are you sure you want to %s", action)),
+		ui.Colorize(ui.ColorYellow, fmt.Sprintf("%d", len(unresolvedComments))), scope, replyNote)
+	if !confirmPrompt(stdinReader, prompt) {
 		fmt.Println(ui.Colorize(ui.ColorGray, "Operation cancelled"))
 		return nil
 	}
 
-	// Resolve/unresolve all comments
-	successCount := 0
-	errorCount := 0
+	return resolveThreads(client, prNumber, unresolvedComments, commentText)
+}
 
-	// Resolve comment text once (with @file support)
+// unresolvedCommentsFor returns the unresolved comments resolve --all acts
+// on, limited to path and author when given
+func unresolvedCommentsFor(comments []*github.ReviewComment, path, author string) []*github.ReviewComment {
+	var unresolved []*github.ReviewComment
+	for _, comment := range comments {
+		if !comment.IsResolved() {
+			unresolved = append(unresolved, comment)
+		}
+	}
+	unresolved = filterCommentsByPath(unresolved, path)
+	if author != "" {
+		unresolved = github.FilterByAuthor(unresolved, author)
+	}
+	return unresolved
+}
+
+// resolveAllScope describes the --file and --author limits of resolve --all
+// for its confirmation and dry-run messages, e.g. " in main.go from @alice"
+func resolveAllScope(path, author string) string {
+	scope := ""
+	if path != "" {
+		scope = " in " + ui.Colorize(ui.ColorCyan, path)
+	}
+	if author != "" {
+		scope += " from " + ui.Colorize(ui.ColorCyan, "@"+author)
+	}
+	return scope
+}
+
+// resolveInteractively lists the unresolved comments in the browse tree and
+// resolves the threads marked with space, or the highlighted one when
+// nothing is marked
+func resolveInteractively(client *github.Client, prNumber int) error {
+	comments, err := fetchReviewComments(client, prNumber)
+	if err != nil {
+		return fmt.Errorf("failed to fetch review comments: %w", err)
+	}
+	var unresolved []*github.ReviewComment
+	for _, comment := range comments {
+		if !comment.IsResolved() {
+			unresolved = append(unresolved, comment)
+		}
+	}
+	if len(unresolved) == 0 {
+		fmt.Printf("No unresolved comments found in PR #%d\n", prNumber)
+		return nil
+	}
+
+	// Resolve the reply text before the selector, so a bad @file fails first
 	var commentText string
 	if resolveComment != "" {
-		var err error
 		commentText, err = resolveCommentText(resolveComment)
 		if err != nil {
 			return err
 		}
 	}
 
-	for _, comment := range unresolvedComments {
+	collapsedFiles := make(map[string]bool)
+	selected, err := ui.SelectMany(ui.SelectorOptions[BrowseItem]{
+		Items: buildCommentTree(unresolved),
+		Renderer: &browseItemRenderer{
+			repo:           getRepoFromClient(client),
+			prNumber:       prNumber,
+			collapsedFiles: collapsedFiles,
+		},
+		OnSelect:   toggleCollapsed(collapsedFiles),
+		FilterFunc: browseFilter(collapsedFiles),
+	})
+	if errors.Is(err, ui.ErrNoSelection) {
+		fmt.Println(ui.Colorize(ui.ColorGray, "Operation cancelled"))
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	chosen := commentsFromBrowseItems(selected, unresolved)
+	if len(chosen) == 0 {
+		fmt.Println(ui.Colorize(ui.ColorGray, "No comment selected"))
+		return nil
+	}
+	summary, files := formatResolveSelection(chosen)
+	fmt.Printf("\n%s", summary)
+	replyNote := ""
+	if commentText != "" {
+		fmt.Printf("\n%s\n%s\n", ui.Colorize(ui.ColorCyan, "Reply to post on each thread:"), quoteReplyBody(commentText))
+		replyNote = " and post this reply on each"
+	}
+	if resolveReact != "" {
+		replyNote += fmt.Sprintf(" and react with %s on each", resolveReact)
+	}
+	prompt := fmt.Sprintf("\n%s%s? [y/N]: ", ui.Colorize(ui.ColorGreen,
+		fmt.Sprintf("Resolve %d thread(s) in %d file(s)", len(chosen), files)), replyNote)
+	if !confirmPrompt(stdinReader, prompt) {
+		fmt.Println(ui.Colorize(ui.ColorGray, "Operation cancelled"))
+		return nil
+	}
+	return resolveThreads(client, prNumber, chosen, commentText)
+}
+
+// formatResolveSelection lists the threads picked with resolve --interactive,
+// and returns how many files they are on
+func formatResolveSelection(comments []*github.ReviewComment) (string, int) {
+	var b strings.Builder
+	b.WriteString(ui.Colorize(ui.ColorCyan, "Threads to resolve:") + "\n")
+	files := make(map[string]bool)
+	for _, comment := range comments {
+		files[comment.Path] = true
+		fmt.Fprintf(&b, "  • %s (%s)\n",
+			ui.CreateHyperlink(comment.HTMLURL, comment.LocationLabel()),
+			ui.Colorize(ui.ColorGray, fmt.Sprintf("Comment %d", comment.ID)))
+	}
+	return b.String(), len(files)
+}
+
+// commentsFromBrowseItems returns the comments of the items picked in the
+// comment tree, in the order of comments: a comment or its preview line
+// stands for the comment, a marked file header for every comment on the file
+func commentsFromBrowseItems(items []BrowseItem, comments []*github.ReviewComment) []*github.ReviewComment {
+	ids := make(map[int64]bool)
+	paths := make(map[string]bool)
+	for _, item := range items {
+		if item.Type == "file" {
+			paths[item.Path] = true
+		} else if item.Comment != nil {
+			ids[item.Comment.ID] = true
+		}
+	}
+	var chosen []*github.ReviewComment
+	for _, comment := range comments {
+		if ids[comment.ID] || paths[comment.Path] {
+			chosen = append(chosen, comment)
+		}
+	}
+	return chosen
+}
+
+// resolveThreads resolves the threads of comments, or unresolves them with
+// --unresolve, after posting commentText and the --react reaction on each
+// when given, then prints a summary
+func resolveThreads(client *github.Client, prNumber int, comments []*github.ReviewComment, commentText string) error {
+	successCount := 0
+	errorCount := 0
+
+	for _, comment := range comments {
 		commentLink := ui.CreateHyperlink(comment.HTMLURL, fmt.Sprintf("Comment %d", comment.ID))
 
+		if !resolveUnresolve && skipMissingChange(client, comment.ID, commentLink) {
+			errorCount++
+			continue
+		}
 		if commentText != "" {
 			if err := addCommentToReview(client, prNumber, comment.ID, commentText, commentLink); err != nil {
 				errorCount++
 				continue // Continue to next comment if adding a comment fails
 			}
 		}
+		if resolveReact != "" {
+			if err := reactToThread(client, prNumber, comment.ID, resolveReact, commentLink); err != nil {
+				errorCount++
+				continue
+			}
+		}
 		if resolveUnresolve {
 			if err := client.UnresolveThread(comment.ThreadID); err != nil {
 				fmt.Printf("%sFailed to unresolve %s: %v\n",
-					ui.Colorize(ui.ColorRed, ui.EmojiText("❌ ", "")),
+					ui.Colorize(ui.ColorRed, ui.EmojiText("❌ ", "FAIL: ")),
 					ui.Colorize(ui.ColorCyan, commentLink),
 					ui.Colorize(ui.ColorRed, err.Error()))
 				errorCount++
 			} else {
 				fmt.Printf("%s%s marked as unresolved\n",
-					ui.Colorize(ui.ColorYellow, ui.EmojiText("✓ ", "")),
+					ui.Colorize(ui.ColorYellow, ui.EmojiText("✓ ", "OK: ")),
 					ui.Colorize(ui.ColorCyan, commentLink))
 				successCount++
 			}
 		} else {
 			if err := client.ResolveThread(comment.ThreadID); err != nil {
 				fmt.Printf("%sFailed to resolve %s: %v\n",
-					ui.Colorize(ui.ColorRed, ui.EmojiText("❌ ", "")),
+					ui.Colorize(ui.ColorRed, ui.EmojiText("❌ ", "FAIL: ")),
 					ui.Colorize(ui.ColorCyan, commentLink),
 					ui.Colorize(ui.ColorRed, err.Error()))
 				errorCount++
 			} else {
 				fmt.Printf("%s%s marked as resolved\n",
-					ui.Colorize(ui.ColorGreen, ui.EmojiText("✓ ", "")),
+					ui.Colorize(ui.ColorGreen, ui.EmojiText("✓ ", "OK: ")),
 					ui.Colorize(ui.ColorCyan, commentLink))
+				forgetAppliedChange(client, comment.ID)
 				successCount++
 			}
 		}
@@ -262,70 +627,254 @@ func resolveAllComments(client *github.Client, prNumber int) error {
 		ui.Colorize(ui.ColorCyan, "Summary"),
 		ui.Colorize(ui.ColorGreen, fmt.Sprintf("%d successful", successCount)),
 		ui.Colorize(ui.ColorRed, fmt.Sprintf("%d failed", errorCount)))
+	if resolveUnsub && successCount > 0 {
+		return unsubscribeFromPR(client, prNumber)
+	}
+	return nil
+}
+
+// resolveFromReactions resolves the unresolved threads where the PR author
+// reacted with the given emoji, for teams that use a reaction to mean "done"
+func resolveFromReactions(client *github.Client, prNumber int, emoji string) error {
+	content, err := github.NormalizeReaction(emoji)
+	if err != nil {
+		return err
+	}
+
+	prAuthor, err := client.GetPRAuthor(prNumber)
+	if err != nil {
+		return err
+	}
+
+	comments, err := fetchReviewComments(client, prNumber)
+	if err != nil {
+		return fmt.Errorf("failed to fetch review comments: %w", err)
+	}
+
+	matches := github.FilterResolvableByReaction(comments, prAuthor, content)
+	prLink := ui.CreateHyperlink(prURL(client, prNumber),
+		ui.Colorize(ui.ColorCyan, fmt.Sprintf("PR #%d", prNumber)))
+	if len(matches) == 0 {
+		fmt.Printf("No unresolved threads with a %s reaction from @%s in %s\n", emoji, prAuthor, prLink)
+		return nil
+	}
+
+	if resolveDryRun {
+		fmt.Printf("Would resolve %d thread(s) with a %s reaction from @%s in %s:\n", len(matches), emoji, prAuthor, prLink)
+		for _, comment := range matches {
+			fmt.Printf("  • %s (%s)\n",
+				ui.CreateHyperlink(comment.HTMLURL, comment.LocationLabel()),
+				ui.Colorize(ui.ColorGray, fmt.Sprintf("Comment %d", comment.ID)))
+		}
+		fmt.Printf("\n%s\n", ui.Colorize(ui.ColorGray, "Dry run: nothing was changed"))
+		return nil
+	}
+
+	var commentText string
+	if resolveComment != "" {
+		commentText, err = resolveCommentText(resolveComment)
+		if err != nil {
+			return err
+		}
+		if !confirmReply(stdinReader, commentText, matches, "resolve") {
+			fmt.Println(ui.Colorize(ui.ColorGray, "Operation cancelled"))
+			return nil
+		}
+	}
+
+	successCount := 0
+	errorCount := 0
+	for _, comment := range matches {
+		commentLink := ui.CreateHyperlink(comment.HTMLURL, fmt.Sprintf("Comment %d", comment.ID))
+
+		if skipMissingChange(client, comment.ID, commentLink) {
+			errorCount++
+			continue
+		}
+		if commentText != "" {
+			if err := addCommentToReview(client, prNumber, comment.ID, commentText, commentLink); err != nil {
+				errorCount++
+				continue
+			}
+		}
+		if err := client.ResolveThread(comment.ThreadID); err != nil {
+			fmt.Printf("%sFailed to resolve %s: %v\n",
+				ui.Colorize(ui.ColorRed, ui.EmojiText("❌ ", "FAIL: ")),
+				ui.Colorize(ui.ColorCyan, commentLink),
+				ui.Colorize(ui.ColorRed, err.Error()))
+			errorCount++
+			continue
+		}
+		fmt.Printf("%s%s (%s) marked as resolved\n",
+			ui.Colorize(ui.ColorGreen, ui.EmojiText("✓ ", "OK: ")),
+			ui.Colorize(ui.ColorCyan, commentLink),
+			comment.LocationLabel())
+		forgetAppliedChange(client, comment.ID)
+		successCount++
+	}
+
+	fmt.Printf("\n%s: %s, %s\n",
+		ui.Colorize(ui.ColorCyan, "Summary"),
+		ui.Colorize(ui.ColorGreen, fmt.Sprintf("%d successful", successCount)),
+		ui.Colorize(ui.ColorRed, fmt.Sprintf("%d failed", errorCount)))
+	if resolveUnsub && successCount > 0 {
+		return unsubscribeFromPR(client, prNumber)
+	}
 	return nil
 }
 
 func resolveIndividualComment(client *github.Client, prNumber int, commentID int64) error {
 	// Fetch review comments to find the thread ID
-	comments, err := client.FetchReviewComments(prNumber)
+	comments, err := fetchReviewComments(client, prNumber)
 	if err != nil {
 		return fmt.Errorf("failed to fetch review comments: %w", err)
 	}
 
 	// Find the comment with the given ID
-	var threadID string
+	var target *github.ReviewComment
 	for _, comment := range comments {
 		if comment.ID == commentID {
-			threadID = comment.ThreadID
+			target = comment
 			break
 		}
 	}
 
-	if threadID == "" {
+	if target == nil || target.ThreadID == "" {
 		return fmt.Errorf("comment ID %d not found in PR #%d", commentID, prNumber)
 	}
+	threadID := target.ThreadID
 
 	// Resolve or unresolve the thread
-	commentLink := ui.CreateHyperlink(fmt.Sprintf("https://github.com/%s/pull/%d#discussion_r%d", getRepoFromClient(client), prNumber, commentID),
+	commentLink := ui.CreateHyperlink(commentURL(client, prNumber, commentID),
 		fmt.Sprintf("Comment %d", commentID))
 
+	if !resolveUnresolve && !verifyAppliedChange(client, commentID, commentLink) && !resolveForce {
+		if !confirmPrompt(stdinReader, ui.Colorize(ui.ColorYellow, "Resolve anyway? [y/N]:")+" ") {
+			fmt.Println(ui.Colorize(ui.ColorGray, "Operation cancelled"))
+			return nil
+		}
+	}
+
 	if resolveComment != "" {
 		commentText, err := resolveCommentText(resolveComment)
 		if err != nil {
 			return err
 		}
+		action := "resolve"
+		if resolveUnresolve {
+			action = "unresolve"
+		}
+		if !confirmReply(stdinReader, commentText, []*github.ReviewComment{target}, action) {
+			fmt.Println(ui.Colorize(ui.ColorGray, "Operation cancelled"))
+			return nil
+		}
 		if err := addCommentToReview(client, prNumber, commentID, commentText, commentLink); err != nil {
 			// Log the error but continue to resolve/unresolve the thread
 			fmt.Printf("%sFailed to add comment to %s: %v\n",
-				ui.Colorize(ui.ColorRed, ui.EmojiText("❌ ", "")),
+				ui.Colorize(ui.ColorRed, ui.EmojiText("❌ ", "FAIL: ")),
 				ui.Colorize(ui.ColorCyan, commentLink),
 				ui.Colorize(ui.ColorRed, err.Error()))
 		}
 	}
 
+	if resolveReact != "" {
+		if err := reactToThread(client, prNumber, commentID, resolveReact, commentLink); err != nil {
+			return fmt.Errorf("failed to add reaction: %w", err)
+		}
+	}
+
 	if resolveUnresolve {
 		if err := client.UnresolveThread(threadID); err != nil {
 			return fmt.Errorf("failed to unresolve thread: %w", err)
 		}
 		fmt.Printf("%sThread for %s marked as unresolved\n",
-			ui.Colorize(ui.ColorYellow, ui.EmojiText("✓ ", "")),
+			ui.Colorize(ui.ColorYellow, ui.EmojiText("✓ ", "OK: ")),
 			ui.Colorize(ui.ColorCyan, commentLink))
 	} else {
 		if err := client.ResolveThread(threadID); err != nil {
 			return fmt.Errorf("failed to resolve thread: %w", err)
 		}
 		fmt.Printf("%sThread for %s marked as resolved\n",
-			ui.Colorize(ui.ColorGreen, ui.EmojiText("✓ ", "")),
+			ui.Colorize(ui.ColorGreen, ui.EmojiText("✓ ", "OK: ")),
 			ui.Colorize(ui.ColorCyan, commentLink))
+		forgetAppliedChange(client, commentID)
+		if resolveUnsub {
+			// The thread is resolved whatever happens here
+			if err := unsubscribeFromPR(client, prNumber); err != nil {
+				return fmt.Errorf("thread resolved, but failed to unsubscribe: %w", err)
+			}
+		}
 	}
 
 	return nil
 }
 
-// truncateString truncates a string to the specified length and adds "..." if needed
-func truncateString(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
+// unsubscribeFromPR mutes the notifications of the PR after its threads were
+// resolved. GitHub has no per-thread subscription, so this covers the whole PR.
+func unsubscribeFromPR(client *github.Client, prNumber int) error {
+	if err := client.UnsubscribeFromPR(prNumber); err != nil {
+		return err
+	}
+	printUnsubscribed(prNumber)
+	return nil
+}
+
+func printUnsubscribed(prNumber int) {
+	fmt.Printf("%sUnsubscribed from notifications for PR #%d\n",
+		ui.Colorize(ui.ColorGreen, ui.EmojiText("🔕 ", "")), prNumber)
+}
+
+// verifyAppliedChange checks a suggestion recorded by apply is still present in
+// the local file before its thread gets resolved. Comments that were never
+// applied locally always pass.
+func verifyAppliedChange(client *github.Client, commentID int64, commentLink string) bool {
+	store, err := state.DefaultAppliedStore()
+	if err != nil {
+		return true
+	}
+	record, err := store.Get(getRepoFromClient(client), commentID)
+	if err != nil || record == nil {
+		return true
+	}
+
+	location := fmt.Sprintf("%s:%d-%d", record.Path, record.StartLine, record.EndLine)
+	present, err := record.StillPresent()
+	if err != nil {
+		fmt.Printf("%sCould not check applied change for %s: %v\n",
+			ui.Colorize(ui.ColorYellow, ui.EmojiText("⚠️  ", "Warning: ")),
+			ui.Colorize(ui.ColorCyan, commentLink), err)
+		return false
+	}
+	if !present {
+		fmt.Printf("%sApplied change for %s is no longer present at %s\n",
+			ui.Colorize(ui.ColorYellow, ui.EmojiText("⚠️  ", "Warning: ")),
+			ui.Colorize(ui.ColorCyan, commentLink), location)
+		return false
+	}
+
+	fmt.Printf("%sApplied change for %s still present at %s\n",
+		ui.Colorize(ui.ColorGreen, ui.EmojiText("✓ ", "OK: ")),
+		ui.Colorize(ui.ColorCyan, commentLink), location)
+	return true
+}
+
+// skipMissingChange reports whether a batch resolve leaves out a comment
+// whose applied change is no longer found locally, which --force overrides
+func skipMissingChange(client *github.Client, commentID int64, commentLink string) bool {
+	if verifyAppliedChange(client, commentID, commentLink) || resolveForce {
+		return false
+	}
+	fmt.Printf("%sNot resolving %s (use --force to resolve it anyway)\n",
+		ui.Colorize(ui.ColorGray, ui.EmojiText("⏭️  ", "SKIP: ")),
+		ui.Colorize(ui.ColorCyan, commentLink))
+	return true
+}
+
+// forgetAppliedChange drops the apply record of a comment once its thread is resolved
+func forgetAppliedChange(client *github.Client, commentID int64) {
+	store, err := state.DefaultAppliedStore()
+	if err != nil {
+		return
 	}
-	return s[:maxLen-3] + "..."
+	_ = store.Remove(getRepoFromClient(client), commentID)
 }
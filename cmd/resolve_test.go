@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"bufio"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/chmouel/gh-prreview/pkg/github"
+	"github.com/chmouel/gh-prreview/pkg/state"
+	"github.com/chmouel/gh-prreview/pkg/ui"
+)
+
+func TestFilterCommentsByPath(t *testing.T) {
+	comments := []*github.ReviewComment{
+		{ID: 1, Path: "pkg/main.go"},
+		{ID: 2, Path: "README.md"},
+		{ID: 3, Path: "pkg/main.go"},
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want []int64
+	}{
+		{"no path keeps everything", "", []int64{1, 2, 3}},
+		{"exact path", "pkg/main.go", []int64{1, 3}},
+		{"path is cleaned", "./pkg/../pkg/main.go", []int64{1, 3}},
+		{"no match", "pkg/other.go", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := filterCommentsByPath(comments, tt.path)
+			if len(got) != len(tt.want) {
+				t.Fatalf("filterCommentsByPath(%q) returned %d comments, want %d", tt.path, len(got), len(tt.want))
+			}
+			for i, id := range tt.want {
+				if got[i].ID != id {
+					t.Errorf("filterCommentsByPath(%q)[%d].ID = %d, want %d", tt.path, i, got[i].ID, id)
+				}
+			}
+		})
+	}
+}
+
+func TestUnresolvedCommentsFor(t *testing.T) {
+	comments := []*github.ReviewComment{
+		{ID: 1, Path: "pkg/main.go", Author: "alice"},
+		{ID: 2, Path: "README.md", Author: "bob"},
+		{ID: 3, Path: "pkg/main.go", Author: "bob"},
+		{ID: 4, Path: "pkg/main.go", Author: "Bob", SubjectType: "resolved"},
+	}
+
+	tests := []struct {
+		name   string
+		path   string
+		author string
+		want   []int64
+	}{
+		{"no filter", "", "", []int64{1, 2, 3}},
+		{"author", "", "bob", []int64{2, 3}},
+		{"author is case-insensitive", "", "ALICE", []int64{1}},
+		{"path and author", "pkg/main.go", "bob", []int64{3}},
+		{"unknown author", "", "carol", []int64{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := collectCommentIDs(unresolvedCommentsFor(comments, tt.path, tt.author))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("unresolvedCommentsFor(%q, %q) = %v, want %v", tt.path, tt.author, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveAllScope(t *testing.T) {
+	if got := resolveAllScope("", ""); got != "" {
+		t.Errorf("resolveAllScope() without filters = %q, want empty", got)
+	}
+	got := resolveAllScope("pkg/main.go", "alice")
+	for _, want := range []string{" in ", "pkg/main.go", " from ", "@alice"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("resolveAllScope() = %q, want it to contain %q", got, want)
+		}
+	}
+	if got := resolveAllScope("", "alice"); strings.Contains(got, " in ") || !strings.Contains(got, "@alice") {
+		t.Errorf("resolveAllScope() with only an author = %q, want just the author", got)
+	}
+}
+
+func TestGroupCommentsByPath(t *testing.T) {
+	comments := []*github.ReviewComment{
+		{ID: 1, Path: "pkg/main.go"},
+		{ID: 2, Path: "README.md"},
+		{ID: 3, Path: "pkg/main.go"},
+	}
+
+	groups := groupCommentsByPath(comments)
+	var got [][]int64
+	for _, group := range groups {
+		got = append(got, collectCommentIDs(group))
+	}
+	want := [][]int64{{1, 3}, {2}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("groupCommentsByPath() = %v, want %v", got, want)
+	}
+}
+
+func TestFormatReplyPreview(t *testing.T) {
+	originalEnabled := ui.ColorsEnabled()
+	ui.SetColorEnabled(false)
+	t.Cleanup(func() { ui.SetColorEnabled(originalEnabled) })
+
+	threads := []*github.ReviewComment{
+		{ID: 11, Path: "pkg/main.go", Line: 4},
+		{ID: 12, Path: "README.md", Line: 9},
+	}
+	got := formatReplyPreview("Fixed, thanks!\nSee the new test.", threads)
+	want := "Reply to post on 2 thread(s):\n" +
+		"  • pkg/main.go:4 (Comment 11)\n" +
+		"  • README.md:9 (Comment 12)\n" +
+		"\n" +
+		"  │ Fixed, thanks!\n" +
+		"  │ See the new test.\n"
+	if got != want {
+		t.Errorf("formatReplyPreview() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestConfirmReply(t *testing.T) {
+	threads := []*github.ReviewComment{{ID: 11, Path: "pkg/main.go", Line: 4}}
+
+	tests := []struct {
+		name  string
+		yes   bool
+		input string
+		want  bool
+	}{
+		{"confirmed", false, "y\n", true},
+		{"confirmed with yes", false, "YES\n", true},
+		{"declined", false, "n\n", false},
+		{"empty answer declines", false, "\n", false},
+		{"no input declines", false, "", false},
+		{"--yes skips the prompt", true, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldYes := resolveYes
+			resolveYes = tt.yes
+			t.Cleanup(func() { resolveYes = oldYes })
+
+			in := bufio.NewReader(strings.NewReader(tt.input))
+			if got := confirmReply(in, "Done", threads, "resolve"); got != tt.want {
+				t.Errorf("confirmReply() with input %q = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReactToThreadUnsupportedEmoji(t *testing.T) {
+	// The reaction is checked before anything is sent, so no client is needed
+	err := reactToThread(nil, 1, 42, "🦄", "Comment 42")
+	if err == nil || !strings.Contains(err.Error(), "unsupported reaction") {
+		t.Errorf("reactToThread() error = %v, want an unsupported reaction error", err)
+	}
+}
+
+func TestCommentsFromBrowseItems(t *testing.T) {
+	a1 := &github.ReviewComment{ID: 1, Path: "a.go"}
+	b1 := &github.ReviewComment{ID: 2, Path: "b.go"}
+	a2 := &github.ReviewComment{ID: 3, Path: "a.go"}
+	b2 := &github.ReviewComment{ID: 4, Path: "b.go"}
+	comments := []*github.ReviewComment{a1, b1, a2, b2}
+
+	items := []BrowseItem{
+		{Type: "comment_preview", Path: "b.go", Comment: b2, IsPreview: true},
+		{Type: "file", Path: "a.go"},
+		{Type: "comment", Path: "a.go", Comment: a2},
+	}
+	got := collectCommentIDs(commentsFromBrowseItems(items, comments))
+	want := []int64{1, 3, 4}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("commentsFromBrowseItems() = %v, want %v", got, want)
+	}
+}
+
+func TestFormatResolveSelection(t *testing.T) {
+	comments := []*github.ReviewComment{
+		{ID: 1, Path: "a.go", Line: 3},
+		{ID: 3, Path: "a.go", Line: 9},
+		{ID: 4, Path: "b.go", Line: 1},
+	}
+	got, files := formatResolveSelection(comments)
+	if files != 2 {
+		t.Errorf("formatResolveSelection() files = %d, want 2", files)
+	}
+	for _, want := range []string{"a.go:3", "a.go:9", "b.go:1", "Comment 4"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("formatResolveSelection() = %q, want it to contain %q", got, want)
+		}
+	}
+}
+
+func TestSkipMissingChange(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	previousRepo, previousForce := repoFlag, resolveForce
+	defer func() { repoFlag, resolveForce = previousRepo, previousForce }()
+	repoFlag = "owner/repo"
+
+	store, err := state.DefaultAppliedStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	missing := state.AppliedSuggestion{
+		Repo: "owner/repo", CommentID: 1, Path: filepath.Join(t.TempDir(), "gone.go"),
+		StartLine: 1, EndLine: 1, Lines: []string{"x := 1"},
+	}
+	if err := store.Record(missing); err != nil {
+		t.Fatal(err)
+	}
+
+	client := github.NewClient()
+	resolveForce = false
+	if !skipMissingChange(client, 1, "Comment 1") {
+		t.Error("skipMissingChange() = false for a missing change, want true")
+	}
+	if skipMissingChange(client, 2, "Comment 2") {
+		t.Error("skipMissingChange() = true for a comment never applied, want false")
+	}
+	resolveForce = true
+	if skipMissingChange(client, 1, "Comment 1") {
+		t.Error("skipMissingChange() with --force = true, want false")
+	}
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/chmouel/gh-prreview/pkg/github"
+	"github.com/chmouel/gh-prreview/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reviewApproveIfClean bool
+	reviewSubmitPending  bool
+	reviewEvent          string
+	reviewBody           string
+)
+
+// reviewEvents maps the --event values to the review events of the API
+var reviewEvents = map[string]string{
+	"comment":         "COMMENT",
+	"approve":         "APPROVE",
+	"request-changes": "REQUEST_CHANGES",
+}
+
+var reviewCmd = &cobra.Command{
+	Use:   "review [PR_NUMBER]",
+	Short: "Submit a review on a pull request",
+	Long: `Submit a review on a pull request.
+With --approve-if-clean, the PR is approved only when every review thread is
+resolved; otherwise the outstanding threads are listed and the command exits
+with a non-zero status, so it can gate a merge in scripts.
+With --submit-pending, the pending review built with 'comment --pending' is
+submitted, as a comment unless --event says otherwise.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runReview,
+}
+
+func init() {
+	reviewCmd.Flags().BoolVar(&reviewApproveIfClean, "approve-if-clean", false, "Approve the PR if no review thread is unresolved")
+	reviewCmd.Flags().BoolVar(&reviewSubmitPending, "submit-pending", false, "Submit your pending review")
+	reviewCmd.Flags().StringVar(&reviewEvent, "event", "comment", "With --submit-pending, submit as: comment, approve or request-changes")
+	reviewCmd.Flags().StringVar(&reviewBody, "body", "", "Body of the review")
+}
+
+func runReview(cmd *cobra.Command, args []string) error {
+	if reviewApproveIfClean && reviewSubmitPending {
+		return fmt.Errorf("--approve-if-clean cannot be combined with --submit-pending")
+	}
+	if !reviewApproveIfClean && !reviewSubmitPending {
+		return fmt.Errorf("--approve-if-clean or --submit-pending is required")
+	}
+	if cmd.Flags().Changed("event") && !reviewSubmitPending {
+		return fmt.Errorf("--event can only be used with --submit-pending")
+	}
+	event, ok := reviewEvents[reviewEvent]
+	if !ok {
+		return fmt.Errorf("invalid --event %q, use comment, approve or request-changes", reviewEvent)
+	}
+
+	client := github.NewClient()
+	if repoFlag != "" {
+		client.SetRepo(repoFlag)
+	}
+
+	prNumber, err := getPRNumberWithSelection(args, client)
+	if err != nil {
+		return err
+	}
+
+	if reviewSubmitPending {
+		return submitPendingReview(client, prNumber, event)
+	}
+
+	comments, err := fetchReviewComments(client, prNumber)
+	if err != nil {
+		return err
+	}
+
+	unresolved, _ := partitionResolved(comments)
+	if len(unresolved) > 0 {
+		fmt.Printf("%s\n", ui.Colorize(ui.ColorYellow,
+			fmt.Sprintf("Not approving PR #%d, %d thread(s) still unresolved:", prNumber, len(unresolved))))
+		for _, comment := range unresolved {
+			location := ui.CreateHyperlink(comment.HTMLURL, comment.LocationLabel())
+			fmt.Printf("  %s %s by @%s (ID %d)\n",
+				ui.EmojiText("💬", "-"), location, comment.Author, comment.ID)
+		}
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		return fmt.Errorf("%d unresolved review thread(s)", len(unresolved))
+	}
+
+	review, err := client.SubmitReview(prNumber, "APPROVE", reviewBody)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s Approved PR #%d (review %d)\n", ui.EmojiText("✅", "OK:"), prNumber, review.ID)
+	return nil
+}
+
+// submitPendingReview submits the user's pending review on a PR as event
+func submitPendingReview(client *github.Client, prNumber int, event string) error {
+	pending, err := client.PendingReview(prNumber)
+	if err != nil {
+		return err
+	}
+	if pending == nil {
+		return fmt.Errorf("no pending review on PR #%d, add comments with 'comment --pending'", prNumber)
+	}
+
+	review, err := client.SubmitPendingReview(prNumber, pending.ID, event, reviewBody)
+	if err != nil {
+		return err
+	}
+	state := review.State
+	if color, ok := reviewStateColors[state]; ok {
+		state = ui.Colorize(color, state)
+	}
+	fmt.Printf("%s Submitted review %d on PR #%d: %s\n", ui.EmojiText("✅", "OK:"), review.ID, prNumber, state)
+	return nil
+}
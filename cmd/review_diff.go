@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/chmouel/gh-prreview/pkg/github"
+	"github.com/chmouel/gh-prreview/pkg/reviewdiff"
+	"github.com/chmouel/gh-prreview/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reviewDiffBase         string
+	reviewDiffShowResolved bool
+)
+
+var reviewDiffCmd = &cobra.Command{
+	Use:   "review-diff [PR_NUMBER]",
+	Short: "Show review comments inline in the local diff",
+	Long: `Run 'git diff <base>...HEAD' and show each review comment right below the
+line it was made on, so the feedback appears in the context of the local changes.
+The base defaults to the branch the PR targets (origin/<branch> when it exists).
+Comments whose line is not part of the local diff are listed at the end.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runReviewDiff,
+}
+
+func init() {
+	reviewDiffCmd.Flags().StringVar(&reviewDiffBase, "base", "", "Git revision to diff against (default: the PR base branch)")
+	reviewDiffCmd.Flags().BoolVar(&reviewDiffShowResolved, "all", false, "Include resolved comments")
+}
+
+func runReviewDiff(cmd *cobra.Command, args []string) error {
+	client := github.NewClient()
+	if repoFlag != "" {
+		client.SetRepo(repoFlag)
+	}
+
+	prNumber, err := getPRNumberWithSelection(args, client)
+	if err != nil {
+		return err
+	}
+
+	base := reviewDiffBase
+	if base == "" {
+		ref, err := client.GetPRBaseRef(prNumber)
+		if err != nil {
+			return err
+		}
+		base = ref
+		if err := exec.Command("git", "rev-parse", "--verify", "--quiet", "origin/"+ref).Run(); err == nil {
+			base = "origin/" + ref
+		}
+	}
+
+	output, err := exec.Command("git", "diff", "--no-color", base+"...HEAD").Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return fmt.Errorf("git diff %s...HEAD failed: %s", base, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return fmt.Errorf("failed to run git diff: %w", err)
+	}
+	diff := strings.TrimSuffix(string(output), "\n")
+
+	comments, err := fetchReviewComments(client, prNumber)
+	if err != nil {
+		return fmt.Errorf("failed to fetch review comments: %w", err)
+	}
+	if !reviewDiffShowResolved {
+		unresolved := make([]*github.ReviewComment, 0, len(comments))
+		for _, comment := range comments {
+			if !comment.IsResolved() {
+				unresolved = append(unresolved, comment)
+			}
+		}
+		comments = unresolved
+	}
+
+	if diff == "" {
+		fmt.Printf("No changes between %s and HEAD\n", base)
+	}
+
+	placed, unplaced := reviewdiff.PlaceComments(diff, comments)
+	if diff != "" {
+		for i, line := range strings.Split(diff, "\n") {
+			fmt.Println(ui.ColorizeDiff(line))
+			for _, comment := range placed[i] {
+				printInlineComment(comment)
+			}
+		}
+	}
+
+	if len(unplaced) > 0 {
+		fmt.Printf("\n%s\n", ui.Colorize(ui.ColorYellow,
+			fmt.Sprintf("%d comment(s) not on a line of the local diff:", len(unplaced))))
+		for _, comment := range unplaced {
+			location := comment.LocationLabel()
+			if comment.IsOutdated {
+				location += " (outdated)"
+			}
+			fmt.Printf("\n%s\n", ui.CreateHyperlink(comment.HTMLURL, ui.Colorize(ui.ColorCyan, location)))
+			printInlineComment(comment)
+		}
+	}
+	return nil
+}
+
+// printInlineComment prints a review comment as a boxed note below a diff line
+func printInlineComment(comment *github.ReviewComment) {
+	gutter := ui.Colorize(ui.ColorMagenta, "    ┃ ")
+	header := fmt.Sprintf("%s %s", ui.NewAuthorStyle(comment.Author).Format(false),
+		ui.Colorize(ui.ColorGray, ui.FormatRelativeTime(comment.CreatedAt)))
+	if len(comment.ThreadComments) > 0 {
+		header += ui.Colorize(ui.ColorGray, fmt.Sprintf(" · %d repl(ies)", len(comment.ThreadComments)))
+	}
+	fmt.Println(gutter + ui.CreateHyperlink(comment.HTMLURL, header))
+
+	body := ui.StripSuggestionBlock(comment.Body)
+	if body != "" {
+		for _, line := range strings.Split(ui.WrapText(body, ui.IndentedTextWidth(6)), "\n") {
+			fmt.Println(gutter + line)
+		}
+	}
+	if comment.IsDeletion {
+		fmt.Println(gutter + ui.Colorize(ui.ColorCyan, "Suggested change:") + " " + ui.Colorize(ui.ColorRed, "delete the commented lines"))
+	} else if comment.HasSuggestion {
+		fmt.Println(gutter + ui.Colorize(ui.ColorCyan, "Suggested change:"))
+		for _, line := range strings.Split(comment.SuggestedCode, "\n") {
+			fmt.Println(gutter + ui.Colorize(ui.ColorGreen, line))
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/chmouel/gh-prreview/pkg/github"
+	"github.com/chmouel/gh-prreview/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+var reviewsCmd = &cobra.Command{
+	Use:   "reviews [PR_NUMBER]",
+	Short: "List the reviews submitted on a pull request",
+	Long: `List the reviews submitted on a pull request with their ID, author, state and
+submission time. The IDs can be passed to 'list --review' and 'apply --review'
+to work on the comments of a single review.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runReviews,
+}
+
+// reviewReport is the JSON form of a review
+type reviewReport struct {
+	ID          int64      `json:"id"`
+	Author      string     `json:"author"`
+	State       string     `json:"state"`
+	SubmittedAt *time.Time `json:"submitted_at,omitempty"`
+}
+
+func newReviewReports(reviews []github.Review) []reviewReport {
+	reports := make([]reviewReport, 0, len(reviews))
+	for _, review := range reviews {
+		report := reviewReport{ID: review.ID, Author: review.Author, State: review.State}
+		if !review.SubmittedAt.IsZero() {
+			report.SubmittedAt = &review.SubmittedAt
+		}
+		reports = append(reports, report)
+	}
+	return reports
+}
+
+// reviewStateColors highlights the outcome of a review
+var reviewStateColors = map[string]string{
+	"APPROVED":          ui.ColorGreen,
+	"CHANGES_REQUESTED": ui.ColorRed,
+	"COMMENTED":         ui.ColorYellow,
+	"DISMISSED":         ui.ColorGray,
+	"PENDING":           ui.ColorGray,
+}
+
+func runReviews(cmd *cobra.Command, args []string) error {
+	defer redirectStdout()()
+
+	client := github.NewClient()
+	if repoFlag != "" {
+		client.SetRepo(repoFlag)
+	}
+
+	prNumber, err := getPRNumberWithSelection(args, client)
+	if err != nil {
+		return err
+	}
+
+	var reviews []github.Review
+	err = withSpinner("Fetching reviews", func() error {
+		var err error
+		reviews, err = client.ListReviews(prNumber)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return printSummary(newReviewReports(reviews), func() {
+		prLink := ui.CreateHyperlink(prURL(client, prNumber),
+			ui.Colorize(ui.ColorCyan, fmt.Sprintf("PR #%d", prNumber)))
+		if len(reviews) == 0 {
+			fmt.Printf("No reviews found in %s\n", prLink)
+			return
+		}
+
+		fmt.Printf("Reviews of %s:\n\n", prLink)
+		for _, review := range reviews {
+			submitted := "-"
+			if !review.SubmittedAt.IsZero() {
+				submitted = fmt.Sprintf("%s (%s)", review.SubmittedAt.Local().Format("2006-01-02 15:04"),
+					ui.FormatRelativeTime(review.SubmittedAt))
+			}
+			state := fmt.Sprintf("%-17s", review.State)
+			if color, ok := reviewStateColors[review.State]; ok {
+				state = ui.Colorize(color, state)
+			}
+			fmt.Printf("  %-12d %s %s  %s\n", review.ID, state, submitted,
+				ui.NewAuthorStyle(review.Author).Format(false))
+		}
+	})
+}
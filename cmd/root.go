@@ -1,15 +1,25 @@
 package cmd
 
 import (
+	"errors"
+	"fmt"
 	"os"
 
+	"github.com/chmouel/gh-prreview/pkg/github"
+	"github.com/chmouel/gh-prreview/pkg/log"
 	"github.com/chmouel/gh-prreview/pkg/ui"
 	"github.com/spf13/cobra"
 )
 
 var (
-	repoFlag string
-	noColor  bool
+	repoFlag  string
+	noColor   bool
+	prLimit   int
+	prState   string
+	verbosity int
+	debugFlag bool
+	textWidth int
+	plainFlag bool
 )
 
 var rootCmd = &cobra.Command{
@@ -17,8 +27,20 @@ var rootCmd = &cobra.Command{
 	Short: "Apply GitHub review comments directly to your code",
 	Long: `gh-prreview is a GitHub CLI extension that allows you to fetch and apply
 review comments and suggestions from pull requests directly to your local code.`,
-	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 		ui.SetColorEnabled(!noColor)
+		level := log.LevelFromVerbosity(verbosity, debugFlag)
+		log.SetLevel(level)
+		ui.SetUIDebug(level >= log.LevelDebug)
+		if textWidth < 0 {
+			return fmt.Errorf("--width must not be negative")
+		}
+		ui.SetTextWidth(textWidth)
+		ui.SetPlain(plainFlag)
+		if err := validatePRState(); err != nil {
+			return err
+		}
+		return validateOutputFormat()
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) > 0 {
@@ -32,6 +54,28 @@ func Execute() error {
 	return rootCmd.Execute()
 }
 
+// Exit statuses for the failures scripts may want to tell apart from the
+// generic status 1
+const (
+	exitNotInRepo   = 2
+	exitNotFound    = 3
+	exitRateLimited = 4
+)
+
+// ExitCode returns the exit status for err, an error returned by Execute
+func ExitCode(err error) int {
+	switch {
+	case errors.Is(err, github.ErrNotInRepo):
+		return exitNotInRepo
+	case errors.Is(err, github.ErrPRNotFound), errors.Is(err, github.ErrCommentNotFound):
+		return exitNotFound
+	case errors.Is(err, github.ErrRateLimited):
+		return exitRateLimited
+	default:
+		return 1
+	}
+}
+
 func init() {
 	if _, ok := os.LookupEnv("NO_COLOR"); ok {
 		noColor = true
@@ -39,9 +83,21 @@ func init() {
 
 	rootCmd.PersistentFlags().StringVarP(&repoFlag, "repo", "R", "", "Select a repository using the OWNER/REPO format")
 	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output")
+	rootCmd.PersistentFlags().CountVarP(&verbosity, "verbose", "v", "Print notes on skipped or degraded steps; repeat (-vv) for debug output")
+	rootCmd.PersistentFlags().BoolVar(&debugFlag, "debug", false, "Enable debug output (same as -vv)")
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "format", formatText, "Format of the apply, stats and reviews summaries: text or json")
+	rootCmd.PersistentFlags().IntVar(&textWidth, "width", 0, "Wrap comment text to this many columns (default: the terminal width, or 80)")
+	rootCmd.PersistentFlags().BoolVar(&plainFlag, "plain", false, "Never start the full-screen interface: browse prints the comment list and a PR number must be given")
+	rootCmd.PersistentFlags().IntVar(&prLimit, "limit", 100, "Maximum number of pull requests to offer in the PR selector")
+	rootCmd.PersistentFlags().StringVar(&prState, "state", github.PRStateOpen, "State of the pull requests offered in the PR selector and listed by list --all-prs: open, closed (including merged) or all")
 	rootCmd.AddCommand(listCmd)
 	rootCmd.AddCommand(applyCmd)
 	rootCmd.AddCommand(resolveCmd)
 	rootCmd.AddCommand(commentCmd)
 	rootCmd.AddCommand(browseCmd)
+	rootCmd.AddCommand(statsCmd)
+	rootCmd.AddCommand(reviewsCmd)
+	rootCmd.AddCommand(reviewCmd)
+	rootCmd.AddCommand(diffCmd)
+	rootCmd.AddCommand(reviewDiffCmd)
 }
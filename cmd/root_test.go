@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/chmouel/gh-prreview/pkg/github"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{errors.New("boom"), 1},
+		{github.ErrNotInRepo, 2},
+		{fmt.Errorf("%w: #7 in owner/repo", github.ErrPRNotFound), 3},
+		{fmt.Errorf("failed to reply: %w", github.ErrCommentNotFound), 3},
+		{&github.RateLimitError{Err: errors.New("exit status 1")}, 4},
+	}
+
+	for _, tt := range tests {
+		if got := ExitCode(tt.err); got != tt.want {
+			t.Errorf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
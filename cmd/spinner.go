@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"github.com/briandowns/spinner"
+	"github.com/chmouel/gh-prreview/pkg/log"
+	"golang.org/x/term"
+)
+
+var (
+	spinnerMu     sync.Mutex
+	activeSpinner *spinner.Spinner
+)
+
+// withSpinner runs fn while showing a spinner with msg on stderr, so that
+// slow GitHub calls don't look like a hang. The spinner is left out when
+// stderr is not a terminal, and with debug output, which already reports
+// what is happening.
+func withSpinner(msg string, fn func() error) error {
+	if log.Enabled(log.LevelDebug) || !term.IsTerminal(int(os.Stderr.Fd())) {
+		return fn()
+	}
+
+	s := spinner.New(spinner.CharSets[11], 100*time.Millisecond, spinner.WithWriter(os.Stderr))
+	s.Suffix = " " + msg + "..."
+	spinnerMu.Lock()
+	activeSpinner = s
+	spinnerMu.Unlock()
+	s.Start()
+
+	err := fn()
+
+	s.Stop()
+	spinnerMu.Lock()
+	activeSpinner = nil
+	spinnerMu.Unlock()
+	return err
+}
+
+// setSpinnerMessage replaces the message of the spinner of the running
+// withSpinner call, if any, e.g. to report the stage of a multi-step fetch
+func setSpinnerMessage(msg string) {
+	spinnerMu.Lock()
+	defer spinnerMu.Unlock()
+	if activeSpinner == nil {
+		return
+	}
+	activeSpinner.Lock()
+	activeSpinner.Suffix = " " + msg + "..."
+	activeSpinner.Unlock()
+}
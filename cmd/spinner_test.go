@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWithSpinnerWithoutTerminal(t *testing.T) {
+	want := errors.New("fetch failed")
+	ran := false
+	err := withSpinner("Fetching", func() error {
+		ran = true
+		setSpinnerMessage("Still fetching") // no spinner to update
+		return want
+	})
+	if !ran {
+		t.Fatal("withSpinner() did not run fn")
+	}
+	if !errors.Is(err, want) {
+		t.Errorf("withSpinner() error = %v, want %v", err, want)
+	}
+}
@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/chmouel/gh-prreview/pkg/github"
+	"github.com/chmouel/gh-prreview/pkg/log"
+	"github.com/chmouel/gh-prreview/pkg/state"
+	"github.com/chmouel/gh-prreview/pkg/stats"
+	"github.com/chmouel/gh-prreview/pkg/ui"
+	"github.com/spf13/cobra"
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats [PR_NUMBER]",
+	Short: "Show review statistics for a pull request",
+	Long: `Show a summary of the review activity on a pull request: comment counts,
+the overall review turnaround (first review comment to latest reply) and the
+time to first response for each reviewer.
+
+The acceptance rate of each author's suggestions is computed from the history
+of apply runs recorded locally for the repository.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runStats,
+}
+
+// statsReport is the JSON form of the stats output
+type statsReport struct {
+	PR                int                      `json:"pr"`
+	Threads           int                      `json:"threads"`
+	Unresolved        int                      `json:"unresolved"`
+	Replies           int                      `json:"replies"`
+	FirstComment      *time.Time               `json:"first_comment,omitempty"`
+	LastActivity      *time.Time               `json:"last_activity,omitempty"`
+	TurnaroundSeconds float64                  `json:"turnaround_seconds"`
+	Reviewers         []reviewerResponseReport `json:"reviewers"`
+	Acceptance        []acceptanceReport       `json:"acceptance"`
+}
+
+type reviewerResponseReport struct {
+	Reviewer       string  `json:"reviewer"`
+	Threads        int     `json:"threads"`
+	Responded      int     `json:"responded"`
+	AverageSeconds float64 `json:"average_seconds"`
+	LongestSeconds float64 `json:"longest_seconds"`
+}
+
+type acceptanceReport struct {
+	Author  string  `json:"author"`
+	Applied int     `json:"applied"`
+	Total   int     `json:"total"`
+	Rate    float64 `json:"rate"`
+}
+
+func newStatsReport(prNumber int, comments []*github.ReviewComment, turnaround stats.Turnaround, rates []stats.AuthorAcceptance) statsReport {
+	report := statsReport{
+		PR:                prNumber,
+		Threads:           len(comments),
+		TurnaroundSeconds: turnaround.Total.Seconds(),
+		Reviewers:         []reviewerResponseReport{},
+		Acceptance:        []acceptanceReport{},
+	}
+	for _, comment := range comments {
+		if !comment.IsResolved() {
+			report.Unresolved++
+		}
+		report.Replies += len(comment.ThreadComments)
+	}
+	if !turnaround.FirstComment.IsZero() {
+		report.FirstComment = &turnaround.FirstComment
+		report.LastActivity = &turnaround.LastActivity
+	}
+	for _, reviewer := range turnaround.Reviewers {
+		report.Reviewers = append(report.Reviewers, reviewerResponseReport{
+			Reviewer:       reviewer.Reviewer,
+			Threads:        reviewer.Threads,
+			Responded:      reviewer.Responded,
+			AverageSeconds: reviewer.Average.Seconds(),
+			LongestSeconds: reviewer.Longest.Seconds(),
+		})
+	}
+	for _, rate := range rates {
+		report.Acceptance = append(report.Acceptance, acceptanceReport{
+			Author:  rate.Author,
+			Applied: rate.Applied,
+			Total:   rate.Total,
+			Rate:    rate.Rate(),
+		})
+	}
+	return report
+}
+
+func runStats(cmd *cobra.Command, args []string) error {
+	defer redirectStdout()()
+
+	client := github.NewClient()
+	if repoFlag != "" {
+		client.SetRepo(repoFlag)
+	}
+
+	prNumber, err := getPRNumberWithSelection(args, client)
+	if err != nil {
+		return err
+	}
+
+	comments, err := fetchReviewComments(client, prNumber)
+	if err != nil {
+		return fmt.Errorf("failed to fetch review comments: %w", err)
+	}
+
+	turnaround := stats.ComputeTurnaround(comments)
+	rates := acceptanceRates(client)
+	report := newStatsReport(prNumber, comments, turnaround, rates)
+	if jsonOutput() {
+		return printSummary(report, func() {})
+	}
+
+	prLink := ui.CreateHyperlink(prURL(client, prNumber),
+		ui.Colorize(ui.ColorCyan, fmt.Sprintf("PR #%d", prNumber)))
+	if len(comments) == 0 {
+		fmt.Printf("No review comments found in %s\n", prLink)
+		return nil
+	}
+
+	fmt.Printf("Review statistics for %s\n\n", prLink)
+	fmt.Printf("  %-16s %d (%d unresolved)\n", "Threads:", report.Threads, report.Unresolved)
+	fmt.Printf("  %-16s %d\n", "Replies:", report.Replies)
+
+	fmt.Printf("\n%s\n", ui.Colorize(ui.ColorCyan, "Turnaround"))
+	fmt.Printf("  %-16s %s (%s)\n", "First comment:",
+		turnaround.FirstComment.Local().Format("2006-01-02 15:04"), ui.FormatRelativeTime(turnaround.FirstComment))
+	fmt.Printf("  %-16s %s (%s)\n", "Last activity:",
+		turnaround.LastActivity.Local().Format("2006-01-02 15:04"), ui.FormatRelativeTime(turnaround.LastActivity))
+	fmt.Printf("  %-16s %s\n", "Total:", ui.Colorize(ui.ColorYellow, stats.FormatDuration(turnaround.Total)))
+
+	fmt.Printf("\n%s\n", ui.Colorize(ui.ColorCyan, "Time to first response by reviewer"))
+	for _, reviewer := range turnaround.Reviewers {
+		line := fmt.Sprintf("  %s: %d thread(s), %d answered",
+			ui.NewAuthorStyle(reviewer.Reviewer).Format(false), reviewer.Threads, reviewer.Responded)
+		if reviewer.Responded > 0 {
+			line += fmt.Sprintf(", avg %s, longest %s",
+				stats.FormatDuration(reviewer.Average), stats.FormatDuration(reviewer.Longest))
+		}
+		fmt.Println(line)
+	}
+
+	printAcceptanceRates(rates)
+
+	return nil
+}
+
+// acceptanceRates computes how often each author's suggestions were applied
+// in this repository, based on the locally recorded apply history
+func acceptanceRates(client *github.Client) []stats.AuthorAcceptance {
+	store, err := state.DefaultOutcomeStore()
+	if err != nil {
+		return nil
+	}
+	outcomes, err := store.Load()
+	if err != nil {
+		log.Infof("Note: failed to load apply history: %v", err)
+		return nil
+	}
+	repo, err := client.GetRepo()
+	if err != nil {
+		return nil
+	}
+	return stats.AcceptanceRates(outcomes, repo)
+}
+
+func printAcceptanceRates(rates []stats.AuthorAcceptance) {
+	if len(rates) == 0 {
+		return
+	}
+
+	fmt.Printf("\n%s\n", ui.Colorize(ui.ColorCyan, "Suggestion acceptance by author"))
+	for _, rate := range rates {
+		fmt.Printf("  %s: %s applied (%d/%d)\n",
+			ui.NewAuthorStyle(rate.Author).Format(false),
+			ui.Colorize(ui.ColorYellow, fmt.Sprintf("%.0f%%", rate.Rate()*100)),
+			rate.Applied, rate.Total)
+	}
+}
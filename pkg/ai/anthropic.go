@@ -0,0 +1,103 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	anthropicBaseURL   = "https://api.anthropic.com/v1"
+	anthropicVersion   = "2023-06-01"
+	anthropicMaxTokens = 8192
+)
+
+// AnthropicProvider implements AIProvider using the Anthropic messages API
+type AnthropicProvider struct {
+	apiKey         string
+	model          string
+	baseURL        string
+	httpClient     *http.Client
+	templateConfig *TemplateConfig
+	generation     GenerationConfig
+}
+
+// NewAnthropicProvider creates a new Anthropic provider
+func NewAnthropicProvider(apiKey string, model string, templateConfig *TemplateConfig) (*AnthropicProvider, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("API key is required")
+	}
+
+	if model == "" {
+		model = providerInfo["anthropic"].DefaultModel
+	}
+
+	return &AnthropicProvider{
+		apiKey:         apiKey,
+		model:          model,
+		baseURL:        anthropicBaseURL,
+		httpClient:     &http.Client{Timeout: defaultHTTPTimeout},
+		templateConfig: templateConfig,
+	}, nil
+}
+
+// Name returns the provider name
+func (a *AnthropicProvider) Name() string {
+	return "anthropic"
+}
+
+// Model returns the model name being used
+func (a *AnthropicProvider) Model() string {
+	return a.model
+}
+
+// ApplySuggestion uses Anthropic to generate an adapted patch for the suggestion
+func (a *AnthropicProvider) ApplySuggestion(ctx context.Context, req *SuggestionRequest) (*SuggestionResponse, error) {
+	prompt, err := BuildPrompt(req, a.templateConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build prompt: %w", err)
+	}
+
+	maxTokens := anthropicMaxTokens
+	if a.generation.MaxTokens > 0 {
+		maxTokens = a.generation.MaxTokens
+	}
+	payload := map[string]any{
+		"model":      a.model,
+		"max_tokens": maxTokens,
+		"messages": []map[string]string{
+			{"role": "user", "content": prompt},
+		},
+	}
+	if a.generation.Temperature != nil {
+		payload["temperature"] = *a.generation.Temperature
+	}
+
+	var resp struct {
+		Content []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"content"`
+	}
+
+	headers := map[string]string{
+		"x-api-key":         a.apiKey,
+		"anthropic-version": anthropicVersion,
+	}
+	if err := postJSON(ctx, a.httpClient, a.baseURL+"/messages", headers, payload, &resp); err != nil {
+		return nil, fmt.Errorf("anthropic API call failed: %w", err)
+	}
+
+	var responseText strings.Builder
+	for _, block := range resp.Content {
+		if block.Type == "text" {
+			responseText.WriteString(block.Text)
+		}
+	}
+	if responseText.Len() == 0 {
+		return nil, fmt.Errorf("no text in Anthropic response")
+	}
+
+	return parseSuggestionJSON(responseText.String(), "Anthropic")
+}
@@ -0,0 +1,61 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ChainProvider tries an ordered list of providers, falling through to the
+// next one when a provider fails (quota exhausted, outage, bad response...)
+type ChainProvider struct {
+	providers []AIProvider
+	active    int // index of the provider that answered last
+}
+
+// NewChainProvider returns a provider trying providers in order. A single
+// provider is returned as is.
+func NewChainProvider(providers ...AIProvider) (AIProvider, error) {
+	switch len(providers) {
+	case 0:
+		return nil, fmt.Errorf("at least one AI provider is required")
+	case 1:
+		return providers[0], nil
+	}
+	return &ChainProvider{providers: providers}, nil
+}
+
+// ApplySuggestion asks each provider in turn until one succeeds. When all of
+// them fail, the returned error joins every provider's error.
+func (c *ChainProvider) ApplySuggestion(ctx context.Context, req *SuggestionRequest) (*SuggestionResponse, error) {
+	var errs []error
+	for i, provider := range c.providers {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		resp, err := provider.ApplySuggestion(ctx, req)
+		if err == nil {
+			c.active = i
+			return resp, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", provider.Name(), err))
+	}
+	return nil, fmt.Errorf("all AI providers failed: %w", errors.Join(errs...))
+}
+
+// Name returns the name of the provider that answered the last request, or
+// of the first provider before any request
+func (c *ChainProvider) Name() string {
+	return c.providers[c.active].Name()
+}
+
+// Model returns the model of the provider that answered the last request, or
+// of the first provider before any request
+func (c *ChainProvider) Model() string {
+	return c.providers[c.active].Model()
+}
+
+// Providers returns the providers of the chain, in the order they are tried
+func (c *ChainProvider) Providers() []AIProvider {
+	return c.providers
+}
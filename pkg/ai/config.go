@@ -3,27 +3,102 @@ package ai
 import (
 	"fmt"
 	"os"
+	"slices"
+	"sort"
+	"strings"
 )
 
 // ProviderMetadata holds information about an AI provider.
 type ProviderMetadata struct {
-	Label   string
-	EnvVars []string
+	Label        string
+	EnvVars      []string
+	DefaultModel string
+	// SupportedModels lists known model names. It is informational only:
+	// providers release models faster than this list is updated.
+	SupportedModels []string
+	// MaxTemperature is the highest sampling temperature the API accepts
+	MaxTemperature float64
 }
 
 // providerInfo maps provider names to their metadata.
 var providerInfo = map[string]ProviderMetadata{
-	"gemini": {"Gemini", []string{"GEMINI_API_KEY", "GOOGLE_API_KEY"}},
-	"openai": {"OpenAI", []string{"OPENAI_API_KEY"}},    // Planned for future support
-	"claude": {"Claude", []string{"ANTHROPIC_API_KEY"}}, // Planned for future support
+	"gemini": {
+		Label:        "Gemini",
+		EnvVars:      []string{"GEMINI_API_KEY", "GOOGLE_API_KEY"},
+		DefaultModel: "gemini-2.5-flash-lite-preview-09-2025",
+		SupportedModels: []string{
+			"gemini-2.5-flash-lite-preview-09-2025",
+			"gemini-2.5-flash-lite",
+			"gemini-2.5-flash",
+			"gemini-2.5-pro",
+		},
+		MaxTemperature: 2,
+	},
+	"openai": {
+		Label:        "OpenAI",
+		EnvVars:      []string{"OPENAI_API_KEY"},
+		DefaultModel: "gpt-4o-mini",
+		SupportedModels: []string{
+			"gpt-4o-mini",
+			"gpt-4o",
+			"gpt-4.1-mini",
+			"gpt-4.1",
+			"gpt-5-mini",
+			"gpt-5",
+		},
+		MaxTemperature: 2,
+	},
+	"anthropic": {
+		Label:        "Anthropic",
+		EnvVars:      []string{"ANTHROPIC_API_KEY"},
+		DefaultModel: "claude-sonnet-4-5",
+		SupportedModels: []string{
+			"claude-sonnet-4-5",
+			"claude-haiku-4-5",
+			"claude-opus-4-1",
+		},
+		MaxTemperature: 1,
+	},
+}
+
+// providerAliases maps alternative provider names to their canonical name.
+var providerAliases = map[string]string{
+	"claude": "anthropic",
+}
+
+// canonicalProvider resolves provider aliases (e.g. "claude" -> "anthropic").
+func canonicalProvider(provider string) string {
+	if canonical, ok := providerAliases[provider]; ok {
+		return canonical
+	}
+	return provider
 }
 
 // GetProviderMetadata returns metadata for a given provider.
 func GetProviderMetadata(provider string) (ProviderMetadata, bool) {
-	info, ok := providerInfo[provider]
+	info, ok := providerInfo[canonicalProvider(provider)]
 	return info, ok
 }
 
+// ProviderNames returns the supported provider names, sorted.
+func ProviderNames() []string {
+	names := make([]string, 0, len(providerInfo))
+	for name := range providerInfo {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsKnownModel reports whether model is in the provider's SupportedModels list.
+func IsKnownModel(provider, model string) bool {
+	info, ok := GetProviderMetadata(provider)
+	if !ok {
+		return false
+	}
+	return slices.Contains(info.SupportedModels, model)
+}
+
 // Config holds AI provider configuration
 type Config struct {
 	Provider           string
@@ -31,9 +106,45 @@ type Config struct {
 	APIKey             string
 	CustomTemplatePath string
 	CustomVariables    map[string]interface{}
+	Generation         GenerationConfig
+}
+
+// GenerationConfig holds the sampling settings sent with each request. The
+// zero value keeps the defaults of every provider.
+type GenerationConfig struct {
+	Temperature *float64 // nil keeps the provider default
+	MaxTokens   int      // 0 keeps the provider default
+}
+
+// ProviderChain splits a provider setting into the providers to try in
+// order, e.g. "gemini,openai" tries Gemini first and falls back to OpenAI.
+func ProviderChain(provider string) []string {
+	var names []string
+	for _, name := range strings.Split(provider, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
 }
 
-// NewProviderFromConfig creates an AI provider based on configuration
+// APIKeyFromEnv returns the API key of a provider from the first of its
+// environment variables that is set
+func APIKeyFromEnv(provider string) string {
+	if meta, ok := GetProviderMetadata(provider); ok {
+		for _, envVar := range meta.EnvVars {
+			if key := os.Getenv(envVar); key != "" {
+				return key
+			}
+		}
+	}
+	return ""
+}
+
+// NewProviderFromConfig creates an AI provider based on configuration.
+// When config.Provider lists several providers, the model and API key of the
+// config apply to the first one; fallbacks use their default model and the
+// API key from their environment variables.
 func NewProviderFromConfig(config *Config) (AIProvider, error) {
 	if config == nil {
 		return nil, fmt.Errorf("config is required")
@@ -44,31 +155,70 @@ func NewProviderFromConfig(config *Config) (AIProvider, error) {
 		CustomVariables:    config.CustomVariables,
 	}
 
-	switch config.Provider {
+	names := ProviderChain(config.Provider)
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no AI provider configured (supported: gemini, openai, anthropic)")
+	}
+
+	providers := make([]AIProvider, 0, len(names))
+	for i, name := range names {
+		apiKey, model := config.APIKey, config.Model
+		if i > 0 {
+			apiKey, model = APIKeyFromEnv(name), ""
+			if apiKey == "" {
+				meta, _ := GetProviderMetadata(name)
+				return nil, fmt.Errorf("no API key for fallback AI provider %s (set %s)", name, strings.Join(meta.EnvVars, " or "))
+			}
+		}
+		provider, err := newProvider(name, apiKey, model, templateConfig, config.Generation)
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, provider)
+	}
+	return NewChainProvider(providers...)
+}
+
+func newProvider(name, apiKey, model string, templateConfig *TemplateConfig, generation GenerationConfig) (AIProvider, error) {
+	switch canonicalProvider(name) {
 	case "gemini":
-		return NewGeminiProvider(config.APIKey, config.Model, templateConfig)
+		provider, err := NewGeminiProvider(apiKey, model, templateConfig)
+		if err != nil {
+			return nil, err
+		}
+		provider.generation = generation
+		return provider, nil
+	case "openai":
+		provider, err := NewOpenAIProvider(apiKey, model, templateConfig)
+		if err != nil {
+			return nil, err
+		}
+		provider.generation = generation
+		return provider, nil
+	case "anthropic":
+		provider, err := NewAnthropicProvider(apiKey, model, templateConfig)
+		if err != nil {
+			return nil, err
+		}
+		provider.generation = generation
+		return provider, nil
 	default:
-		return nil, fmt.Errorf("unsupported AI provider: %s (supported: gemini)", config.Provider)
+		return nil, fmt.Errorf("unsupported AI provider: %s (supported: gemini, openai, anthropic)", name)
 	}
 }
 
 // LoadConfigFromEnv loads AI configuration from environment variables
 func LoadConfigFromEnv() *Config {
-	provider := getEnvWithDefault("GH_PRREVIEW_AI_PROVIDER", "")
+	provider := getEnvWithDefault("GH_PRREVIEW_AI_PROVIDER", "gemini")
 
 	config := &Config{
 		Provider: provider,
 		Model:    os.Getenv("GH_PRREVIEW_AI_MODEL"),
 	}
 
-	// Load API key based on provider
-	if meta, ok := GetProviderMetadata(config.Provider); ok {
-		for _, envVar := range meta.EnvVars {
-			if key := os.Getenv(envVar); key != "" {
-				config.APIKey = key
-				break
-			}
-		}
+	// Load API key based on the (first) provider
+	if names := ProviderChain(config.Provider); len(names) > 0 {
+		config.APIKey = APIKeyFromEnv(names[0])
 	}
 
 	// Load custom template path if set
@@ -2,10 +2,7 @@ package ai
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"regexp"
-	"strings"
 
 	"github.com/google/generative-ai-go/genai"
 	"google.golang.org/api/option"
@@ -16,6 +13,7 @@ type GeminiProvider struct {
 	client         *genai.Client
 	model          string
 	templateConfig *TemplateConfig
+	generation     GenerationConfig
 }
 
 // NewGeminiProvider creates a new Gemini AI provider
@@ -31,7 +29,7 @@ func NewGeminiProvider(apiKey string, model string, templateConfig *TemplateConf
 	}
 
 	if model == "" {
-		model = "gemini-2.5-flash-lite-preview-09-2025" // default model
+		model = providerInfo["gemini"].DefaultModel
 	}
 
 	return &GeminiProvider{
@@ -69,6 +67,12 @@ func (g *GeminiProvider) ApplySuggestion(ctx context.Context, req *SuggestionReq
 
 	// Configure model for JSON output
 	model.ResponseMIMEType = "application/json"
+	if g.generation.Temperature != nil {
+		model.SetTemperature(float32(*g.generation.Temperature))
+	}
+	if g.generation.MaxTokens > 0 {
+		model.SetMaxOutputTokens(int32(g.generation.MaxTokens))
+	}
 
 	resp, err := model.GenerateContent(ctx, genai.Text(prompt))
 	if err != nil {
@@ -102,40 +106,5 @@ func parseGeminiResponse(resp *genai.GenerateContentResponse) (*SuggestionRespon
 		return nil, fmt.Errorf("no text in Gemini response")
 	}
 
-	// Parse JSON response
-	var result struct {
-		Patch       string   `json:"patch"`
-		Explanation string   `json:"explanation"`
-		Confidence  float64  `json:"confidence"`
-		Warnings    []string `json:"warnings"`
-	}
-
-	// Clean up response text (remove markdown code blocks if present)
-	responseText = strings.TrimSpace(responseText)
-	re := regexp.MustCompile("(?s)```(?:json)?\\s*\\n?(.*)```")
-	if matches := re.FindStringSubmatch(responseText); len(matches) > 1 {
-		responseText = matches[1]
-	}
-	responseText = strings.TrimSpace(responseText)
-
-	if err := json.Unmarshal([]byte(responseText), &result); err != nil {
-		return nil, fmt.Errorf("failed to parse Gemini JSON response: %w\nResponse: %s", err, responseText)
-	}
-
-	// Validate the response
-	if result.Patch == "" {
-		return nil, fmt.Errorf("gemini returned empty patch")
-	}
-
-	// Ensure warnings is not nil
-	if result.Warnings == nil {
-		result.Warnings = []string{}
-	}
-
-	return &SuggestionResponse{
-		Patch:       result.Patch,
-		Explanation: result.Explanation,
-		Confidence:  result.Confidence,
-		Warnings:    result.Warnings,
-	}, nil
+	return parseSuggestionJSON(responseText, "Gemini")
 }
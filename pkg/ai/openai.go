@@ -0,0 +1,89 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+const openAIBaseURL = "https://api.openai.com/v1"
+
+// OpenAIProvider implements AIProvider using the OpenAI chat completions API
+type OpenAIProvider struct {
+	apiKey         string
+	model          string
+	baseURL        string
+	httpClient     *http.Client
+	templateConfig *TemplateConfig
+	generation     GenerationConfig
+}
+
+// NewOpenAIProvider creates a new OpenAI provider
+func NewOpenAIProvider(apiKey string, model string, templateConfig *TemplateConfig) (*OpenAIProvider, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("API key is required")
+	}
+
+	if model == "" {
+		model = providerInfo["openai"].DefaultModel
+	}
+
+	return &OpenAIProvider{
+		apiKey:         apiKey,
+		model:          model,
+		baseURL:        openAIBaseURL,
+		httpClient:     &http.Client{Timeout: defaultHTTPTimeout},
+		templateConfig: templateConfig,
+	}, nil
+}
+
+// Name returns the provider name
+func (o *OpenAIProvider) Name() string {
+	return "openai"
+}
+
+// Model returns the model name being used
+func (o *OpenAIProvider) Model() string {
+	return o.model
+}
+
+// ApplySuggestion uses OpenAI to generate an adapted patch for the suggestion
+func (o *OpenAIProvider) ApplySuggestion(ctx context.Context, req *SuggestionRequest) (*SuggestionResponse, error) {
+	prompt, err := BuildPrompt(req, o.templateConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build prompt: %w", err)
+	}
+
+	payload := map[string]any{
+		"model": o.model,
+		"messages": []map[string]string{
+			{"role": "user", "content": prompt},
+		},
+		"response_format": map[string]string{"type": "json_object"},
+	}
+	if o.generation.Temperature != nil {
+		payload["temperature"] = *o.generation.Temperature
+	}
+	if o.generation.MaxTokens > 0 {
+		payload["max_completion_tokens"] = o.generation.MaxTokens
+	}
+
+	var resp struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+
+	headers := map[string]string{"Authorization": "Bearer " + o.apiKey}
+	if err := postJSON(ctx, o.httpClient, o.baseURL+"/chat/completions", headers, payload, &resp); err != nil {
+		return nil, fmt.Errorf("openai API call failed: %w", err)
+	}
+
+	if len(resp.Choices) == 0 || resp.Choices[0].Message.Content == "" {
+		return nil, fmt.Errorf("no response from OpenAI")
+	}
+
+	return parseSuggestionJSON(resp.Choices[0].Message.Content, "OpenAI")
+}
@@ -1,6 +1,12 @@
 package ai
 
-import "context"
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
 
 // AIProvider defines the interface for AI code assistance
 type AIProvider interface {
@@ -8,7 +14,7 @@ type AIProvider interface {
 	// returns an adapted patch that can be applied to the current file
 	ApplySuggestion(ctx context.Context, req *SuggestionRequest) (*SuggestionResponse, error)
 
-	// Name returns the provider name (e.g., "gemini", "openai", "claude")
+	// Name returns the provider name (e.g., "gemini", "openai", "anthropic")
 	Name() string
 
 	// Model returns the model name being used (e.g., "gemini-2.5-flash-lite-preview-09-2025", "gpt-4")
@@ -50,3 +56,45 @@ type SuggestionResponse struct {
 	// Any warnings the AI identified
 	Warnings []string
 }
+
+// codeFenceRe matches a response wrapped in a markdown code block
+var codeFenceRe = regexp.MustCompile("(?s)```(?:json)?\\s*\\n?(.*)```")
+
+// parseSuggestionJSON parses the JSON object the prompt template asks models
+// to answer with. source names the provider in error messages.
+func parseSuggestionJSON(responseText, source string) (*SuggestionResponse, error) {
+	var result struct {
+		Patch       string   `json:"patch"`
+		Explanation string   `json:"explanation"`
+		Confidence  float64  `json:"confidence"`
+		Warnings    []string `json:"warnings"`
+	}
+
+	// Clean up response text (remove markdown code blocks if present)
+	responseText = strings.TrimSpace(responseText)
+	if matches := codeFenceRe.FindStringSubmatch(responseText); len(matches) > 1 {
+		responseText = matches[1]
+	}
+	responseText = strings.TrimSpace(responseText)
+
+	if err := json.Unmarshal([]byte(responseText), &result); err != nil {
+		return nil, fmt.Errorf("failed to parse %s JSON response: %w\nResponse: %s", source, err, responseText)
+	}
+
+	// Validate the response
+	if result.Patch == "" {
+		return nil, fmt.Errorf("%s returned empty patch", strings.ToLower(source))
+	}
+
+	// Ensure warnings is not nil
+	if result.Warnings == nil {
+		result.Warnings = []string{}
+	}
+
+	return &SuggestionResponse{
+		Patch:       result.Patch,
+		Explanation: result.Explanation,
+		Confidence:  result.Confidence,
+		Warnings:    result.Warnings,
+	}, nil
+}
@@ -0,0 +1,398 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/googleapi"
+)
+
+const samplePatchJSON = `{"patch": "--- a/main.go\n+++ b/main.go\n", "explanation": "done", "confidence": 0.9}`
+
+func sampleRequest() *SuggestionRequest {
+	return &SuggestionRequest{
+		ReviewComment:      "Use a constant",
+		SuggestedCode:      "const x = 1",
+		FilePath:           "main.go",
+		CurrentFileContent: "package main\n",
+		FileLanguage:       "go",
+	}
+}
+
+func TestParseSuggestionJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{"plain JSON", samplePatchJSON, false},
+		{"fenced JSON", "```json\n" + samplePatchJSON + "\n```", false},
+		{"empty patch", `{"patch": "", "explanation": "nothing"}`, true},
+		{"not JSON", "sorry, I cannot help", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := parseSuggestionJSON(tt.input, "Test")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSuggestionJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if resp.Explanation != "done" || resp.Confidence != 0.9 {
+				t.Errorf("parseSuggestionJSON() = %+v", resp)
+			}
+			if resp.Warnings == nil {
+				t.Errorf("parseSuggestionJSON() should default Warnings to an empty slice")
+			}
+		})
+	}
+}
+
+func TestOpenAIProviderApplySuggestion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chat/completions" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer test-key" {
+			t.Errorf("Authorization = %q, want %q", got, "Bearer test-key")
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		if body["model"] != "gpt-test" {
+			t.Errorf("model = %v, want gpt-test", body["model"])
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{
+				{"message": map[string]string{"content": samplePatchJSON}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	provider, err := NewOpenAIProvider("test-key", "gpt-test", nil)
+	if err != nil {
+		t.Fatalf("NewOpenAIProvider() error = %v", err)
+	}
+	provider.baseURL = server.URL
+
+	resp, err := provider.ApplySuggestion(context.Background(), sampleRequest())
+	if err != nil {
+		t.Fatalf("ApplySuggestion() error = %v", err)
+	}
+	if !strings.HasPrefix(resp.Patch, "--- a/main.go") {
+		t.Errorf("ApplySuggestion() patch = %q", resp.Patch)
+	}
+}
+
+func TestAnthropicProviderApplySuggestion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/messages" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.Header.Get("x-api-key"); got != "test-key" {
+			t.Errorf("x-api-key = %q, want %q", got, "test-key")
+		}
+		if r.Header.Get("anthropic-version") == "" {
+			t.Errorf("anthropic-version header is missing")
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"content": []map[string]string{
+				{"type": "text", "text": samplePatchJSON},
+			},
+		})
+	}))
+	defer server.Close()
+
+	provider, err := NewAnthropicProvider("test-key", "", nil)
+	if err != nil {
+		t.Fatalf("NewAnthropicProvider() error = %v", err)
+	}
+	provider.baseURL = server.URL
+
+	if provider.Model() != providerInfo["anthropic"].DefaultModel {
+		t.Errorf("Model() = %q, want default %q", provider.Model(), providerInfo["anthropic"].DefaultModel)
+	}
+
+	resp, err := provider.ApplySuggestion(context.Background(), sampleRequest())
+	if err != nil {
+		t.Fatalf("ApplySuggestion() error = %v", err)
+	}
+	if resp.Explanation != "done" {
+		t.Errorf("ApplySuggestion() explanation = %q, want %q", resp.Explanation, "done")
+	}
+}
+
+func TestProviderGenerationSettings(t *testing.T) {
+	temperature := 0.2
+	tests := []struct {
+		name       string
+		generation GenerationConfig
+		wantTemp   any
+		wantTokens any
+	}{
+		{"defaults", GenerationConfig{}, nil, float64(anthropicMaxTokens)},
+		{"overridden", GenerationConfig{Temperature: &temperature, MaxTokens: 1024}, 0.2, float64(1024)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body map[string]any
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Fatalf("failed to decode request: %v", err)
+				}
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"content": []map[string]string{
+						{"type": "text", "text": samplePatchJSON},
+					},
+				})
+			}))
+			defer server.Close()
+
+			provider, err := NewAnthropicProvider("test-key", "", nil)
+			if err != nil {
+				t.Fatalf("NewAnthropicProvider() error = %v", err)
+			}
+			provider.baseURL = server.URL
+			provider.generation = tt.generation
+
+			if _, err := provider.ApplySuggestion(context.Background(), sampleRequest()); err != nil {
+				t.Fatalf("ApplySuggestion() error = %v", err)
+			}
+			if body["temperature"] != tt.wantTemp {
+				t.Errorf("temperature = %v, want %v", body["temperature"], tt.wantTemp)
+			}
+			if body["max_tokens"] != tt.wantTokens {
+				t.Errorf("max_tokens = %v, want %v", body["max_tokens"], tt.wantTokens)
+			}
+		})
+	}
+}
+
+func TestProviderAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error": "invalid model"}`, http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	provider, err := NewOpenAIProvider("test-key", "bogus", nil)
+	if err != nil {
+		t.Fatalf("NewOpenAIProvider() error = %v", err)
+	}
+	provider.baseURL = server.URL
+
+	_, err = provider.ApplySuggestion(context.Background(), sampleRequest())
+	if err == nil || !strings.Contains(err.Error(), "HTTP 400") {
+		t.Errorf("ApplySuggestion() error = %v, want HTTP 400 error", err)
+	}
+}
+
+func TestNewProviderFromConfig(t *testing.T) {
+	t.Setenv("ANTHROPIC_API_KEY", "fallback-key")
+	t.Setenv("GEMINI_API_KEY", "")
+	t.Setenv("GOOGLE_API_KEY", "")
+
+	tests := []struct {
+		provider string
+		wantName string
+		wantErr  bool
+	}{
+		{"openai", "openai", false},
+		{"anthropic", "anthropic", false},
+		{"claude", "anthropic", false},
+		{"unknown", "", true},
+		{"openai,anthropic", "openai", false},
+		{" openai , claude ", "openai", false},
+		{"openai,gemini", "", true}, // no key for the fallback
+		{"openai,unknown", "", true},
+		{",", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			provider, err := NewProviderFromConfig(&Config{Provider: tt.provider, APIKey: "key"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewProviderFromConfig(%q) error = %v, wantErr %v", tt.provider, err, tt.wantErr)
+			}
+			if err == nil && provider.Name() != tt.wantName {
+				t.Errorf("NewProviderFromConfig(%q).Name() = %q, want %q", tt.provider, provider.Name(), tt.wantName)
+			}
+		})
+	}
+}
+
+func TestGetProviderMetadataAlias(t *testing.T) {
+	meta, ok := GetProviderMetadata("claude")
+	if !ok || len(meta.EnvVars) == 0 || meta.EnvVars[0] != "ANTHROPIC_API_KEY" {
+		t.Errorf("GetProviderMetadata(%q) = %+v, %v", "claude", meta, ok)
+	}
+}
+
+func TestIsKnownModel(t *testing.T) {
+	tests := []struct {
+		provider string
+		model    string
+		want     bool
+	}{
+		{"openai", "gpt-4o-mini", true},
+		{"anthropic", "claude-sonnet-4-5", true},
+		{"claude", "claude-sonnet-4-5", true},
+		{"openai", "gpt-bogus", false},
+		{"gemini", "gpt-4o", false},
+		{"unknown", "gpt-4o", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider+"/"+tt.model, func(t *testing.T) {
+			if got := IsKnownModel(tt.provider, tt.model); got != tt.want {
+				t.Errorf("IsKnownModel(%q, %q) = %v, want %v", tt.provider, tt.model, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultModelIsSupported(t *testing.T) {
+	for _, name := range ProviderNames() {
+		meta, _ := GetProviderMetadata(name)
+		if !IsKnownModel(name, meta.DefaultModel) {
+			t.Errorf("default model %q of %s is missing from SupportedModels", meta.DefaultModel, name)
+		}
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"rate limited", &HTTPError{StatusCode: http.StatusTooManyRequests}, true},
+		{"server error", &HTTPError{StatusCode: http.StatusBadGateway}, true},
+		{"wrapped server error", fmt.Errorf("openai API call failed: %w", &HTTPError{StatusCode: 503}), true},
+		{"bad request", &HTTPError{StatusCode: http.StatusBadRequest}, false},
+		{"unauthorized", &HTTPError{StatusCode: http.StatusUnauthorized}, false},
+		{"google api rate limited", &googleapi.Error{Code: 429}, true},
+		{"google api not found", &googleapi.Error{Code: 404}, false},
+		{"plain error", errors.New("no response from OpenAI"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.want {
+				t.Errorf("IsRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProviderRateLimitIsRetryable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error": "rate limited"}`, http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	provider, err := NewAnthropicProvider("test-key", "", nil)
+	if err != nil {
+		t.Fatalf("NewAnthropicProvider() error = %v", err)
+	}
+	provider.baseURL = server.URL
+
+	_, err = provider.ApplySuggestion(context.Background(), sampleRequest())
+	if !IsRetryable(err) {
+		t.Errorf("ApplySuggestion() error = %v, want a retryable error", err)
+	}
+}
+
+// stubProvider answers with a fixed response or error and counts its calls
+type stubProvider struct {
+	name  string
+	resp  *SuggestionResponse
+	err   error
+	calls int
+}
+
+func (s *stubProvider) ApplySuggestion(_ context.Context, _ *SuggestionRequest) (*SuggestionResponse, error) {
+	s.calls++
+	return s.resp, s.err
+}
+
+func (s *stubProvider) Name() string  { return s.name }
+func (s *stubProvider) Model() string { return s.name + "-model" }
+
+func TestChainProviderFallsThrough(t *testing.T) {
+	quota := &HTTPError{StatusCode: http.StatusTooManyRequests, Body: "quota exceeded"}
+	first := &stubProvider{name: "first", err: quota}
+	second := &stubProvider{name: "second", resp: &SuggestionResponse{Patch: "patch"}}
+
+	chain, err := NewChainProvider(first, second)
+	if err != nil {
+		t.Fatalf("NewChainProvider() returned error: %v", err)
+	}
+	if chain.Name() != "first" || chain.Model() != "first-model" {
+		t.Errorf("before any request, chain reports %s/%s, want first/first-model", chain.Name(), chain.Model())
+	}
+
+	resp, err := chain.ApplySuggestion(context.Background(), sampleRequest())
+	if err != nil {
+		t.Fatalf("ApplySuggestion() returned error: %v", err)
+	}
+	if resp.Patch != "patch" {
+		t.Errorf("ApplySuggestion() patch = %q, want the second provider's", resp.Patch)
+	}
+	if first.calls != 1 || second.calls != 1 {
+		t.Errorf("calls = %d/%d, want 1/1", first.calls, second.calls)
+	}
+	if chain.Name() != "second" {
+		t.Errorf("Name() after fallback = %q, want second", chain.Name())
+	}
+}
+
+func TestChainProviderStopsAtFirstSuccess(t *testing.T) {
+	first := &stubProvider{name: "first", resp: &SuggestionResponse{Patch: "patch"}}
+	second := &stubProvider{name: "second", err: errors.New("unused")}
+
+	chain, _ := NewChainProvider(first, second)
+	if _, err := chain.ApplySuggestion(context.Background(), sampleRequest()); err != nil {
+		t.Fatalf("ApplySuggestion() returned error: %v", err)
+	}
+	if second.calls != 0 {
+		t.Errorf("second provider called %d times, want 0", second.calls)
+	}
+}
+
+func TestChainProviderAllFail(t *testing.T) {
+	first := &stubProvider{name: "first", err: errors.New("bad request")}
+	second := &stubProvider{name: "second", err: &HTTPError{StatusCode: http.StatusServiceUnavailable}}
+
+	chain, _ := NewChainProvider(first, second)
+	_, err := chain.ApplySuggestion(context.Background(), sampleRequest())
+	if err == nil {
+		t.Fatal("ApplySuggestion() should fail when every provider fails")
+	}
+	if !strings.Contains(err.Error(), "first: bad request") || !strings.Contains(err.Error(), "second:") {
+		t.Errorf("error should mention every provider, got %v", err)
+	}
+	if !IsRetryable(err) {
+		t.Errorf("IsRetryable() = false, want true when a provider failed transiently")
+	}
+}
+
+func TestNewChainProvider(t *testing.T) {
+	if _, err := NewChainProvider(); err == nil {
+		t.Error("NewChainProvider() without providers should return an error")
+	}
+	single := &stubProvider{name: "only"}
+	if got, _ := NewChainProvider(single); got != AIProvider(single) {
+		t.Errorf("NewChainProvider() with one provider = %v, want the provider itself", got)
+	}
+}
@@ -0,0 +1,49 @@
+package ai
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/googleapis/gax-go/v2/apierror"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/grpc/codes"
+)
+
+// IsRetryable reports whether err from ApplySuggestion is a transient provider
+// failure worth retrying: rate limiting (429) or a server-side error (5xx).
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return retryableStatus(httpErr.StatusCode)
+	}
+
+	// Gemini errors come from the Google API client libraries
+	var apiErr *apierror.APIError
+	if errors.As(err, &apiErr) {
+		if code := apiErr.HTTPCode(); code > 0 {
+			return retryableStatus(code)
+		}
+		if st := apiErr.GRPCStatus(); st != nil {
+			switch st.Code() {
+			case codes.ResourceExhausted, codes.Unavailable, codes.Internal:
+				return true
+			}
+		}
+		return false
+	}
+
+	var googleErr *googleapi.Error
+	if errors.As(err, &googleErr) {
+		return retryableStatus(googleErr.Code)
+	}
+
+	return false
+}
+
+func retryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= 500
+}
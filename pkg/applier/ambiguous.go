@@ -0,0 +1,94 @@
+package applier
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/chmouel/gh-prreview/pkg/github"
+	"github.com/chmouel/gh-prreview/pkg/ui"
+)
+
+// ambiguousContextLines is the number of lines shown around each occurrence
+// when asking which one a suggestion replaces
+const ambiguousContextLines = 2
+
+// errMatchSkipped is returned when the user picks none of the occurrences of
+// an ambiguous match
+var errMatchSkipped = errors.New("no occurrence chosen")
+
+// AmbiguousMatchError is returned when the code a suggestion replaces is not
+// at its reviewed position and appears several times in the file, so that
+// the occurrence to replace cannot be told
+type AmbiguousMatchError struct {
+	Lines []int // 1-based first line of each occurrence
+}
+
+func (e *AmbiguousMatchError) Error() string {
+	lines := make([]string, len(e.Lines))
+	for i, line := range e.Lines {
+		lines[i] = strconv.Itoa(line)
+	}
+	return fmt.Sprintf("ambiguous match, %d locations (lines %s) - apply interactively to choose one",
+		len(e.Lines), strings.Join(lines, ", "))
+}
+
+// matchPicker chooses which of the occurrences starting at matches (0-based)
+// a suggestion replaces, each spanning size lines of fileLines. It returns
+// the chosen start, or errMatchSkipped.
+type matchPicker func(comment *github.ReviewComment, fileLines []string, matches []int, size int) (int, error)
+
+// resolveAmbiguousMatch picks the occurrence to replace when the content
+// search found several: the user chooses in interactive mode, otherwise the
+// match is an *AmbiguousMatchError
+func (a *Applier) resolveAmbiguousMatch(comment *github.ReviewComment, fileLines []string, matches []int, size int) (int, error) {
+	if a.pickMatch == nil {
+		lines := make([]int, len(matches))
+		for i, start := range matches {
+			lines[i] = start + 1
+		}
+		return -1, &AmbiguousMatchError{Lines: lines}
+	}
+	return a.pickMatch(comment, fileLines, matches, size)
+}
+
+// promptForMatch shows each occurrence with the lines around it and asks
+// which one to replace
+func promptForMatch(comment *github.ReviewComment, fileLines []string, matches []int, size int) (int, error) {
+	fmt.Printf("\n%s\n", ui.Colorize(ui.ColorYellow,
+		fmt.Sprintf("The code to replace appears %d times in %s:", len(matches), comment.Path)))
+	for i, start := range matches {
+		fmt.Printf("\n%s\n", ui.Colorize(ui.ColorCyan, fmt.Sprintf("[%d] line %d", i+1, start+1)))
+		from := max(start-ambiguousContextLines, 0)
+		to := min(start+size+ambiguousContextLines, len(fileLines))
+		for n := from; n < to; n++ {
+			marker := " "
+			if n >= start && n < start+size {
+				marker = ">"
+			}
+			fmt.Printf("  %s %4d  %s\n", marker, n+1, fileLines[n])
+		}
+	}
+
+	fmt.Printf("\n%s ", ui.Colorize(ui.ColorYellow, fmt.Sprintf("Replace which occurrence? [1-%d/s]", len(matches))))
+	response, err := stdin.ReadString('\n')
+	if err != nil {
+		return -1, errMatchSkipped
+	}
+	choice, ok := parseMatchChoice(response, len(matches))
+	if !ok {
+		return -1, errMatchSkipped
+	}
+	return matches[choice], nil
+}
+
+// parseMatchChoice turns the answer to promptForMatch into a 0-based index
+// among n occurrences; anything but a number in range means none
+func parseMatchChoice(response string, n int) (int, bool) {
+	choice, err := strconv.Atoi(strings.TrimSpace(response))
+	if err != nil || choice < 1 || choice > n {
+		return -1, false
+	}
+	return choice - 1, true
+}
@@ -0,0 +1,111 @@
+package applier
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/chmouel/gh-prreview/pkg/github"
+)
+
+// duplicatedFile has the reviewed line twice, neither at its reviewed
+// position (line 2 in the hunk below)
+const duplicatedFile = "package main\n\n// header\n\nfunc a() {\n\tretries := 3\n}\n\nfunc b() {\n\tretries := 3\n}\n"
+
+func duplicatedComment() *github.ReviewComment {
+	return &github.ReviewComment{
+		ID:            1,
+		Path:          "main.go",
+		DiffHunk:      "@@ -1,1 +1,2 @@\n package main\n+\tretries := 3",
+		SuggestedCode: "\tconst retries = 3\n",
+	}
+}
+
+func TestEditContentAmbiguousMatch(t *testing.T) {
+	_, err := New().editContent(duplicatedComment(), duplicatedFile)
+
+	var ambiguous *AmbiguousMatchError
+	if !errors.As(err, &ambiguous) {
+		t.Fatalf("editContent() error = %v, want an *AmbiguousMatchError", err)
+	}
+	if !reflect.DeepEqual(ambiguous.Lines, []int{6, 10}) {
+		t.Errorf("ambiguous lines = %v, want [6 10]", ambiguous.Lines)
+	}
+	if want := "ambiguous match, 2 locations (lines 6, 10) - apply interactively to choose one"; err.Error() != want {
+		t.Errorf("error = %q, want %q", err, want)
+	}
+}
+
+func TestEditContentPickedMatch(t *testing.T) {
+	a := New()
+	var offered []int
+	a.pickMatch = func(_ *github.ReviewComment, _ []string, matches []int, size int) (int, error) {
+		offered = matches
+		return matches[1], nil
+	}
+
+	edit, err := a.editContent(duplicatedComment(), duplicatedFile)
+	if err != nil {
+		t.Fatalf("editContent() error = %v", err)
+	}
+	if !reflect.DeepEqual(offered, []int{5, 9}) {
+		t.Errorf("offered matches = %v, want [5 9]", offered)
+	}
+	want := "package main\n\n// header\n\nfunc a() {\n\tretries := 3\n}\n\nfunc b() {\n\tconst retries = 3\n}\n"
+	if edit.content != want {
+		t.Errorf("content =\n%s\nwant:\n%s", edit.content, want)
+	}
+}
+
+func TestEditContentMatchSkipped(t *testing.T) {
+	a := New()
+	a.pickMatch = func(*github.ReviewComment, []string, []int, int) (int, error) {
+		return -1, errMatchSkipped
+	}
+
+	if _, err := a.editContent(duplicatedComment(), duplicatedFile); !errors.Is(err, errMatchSkipped) {
+		t.Errorf("editContent() error = %v, want errMatchSkipped", err)
+	}
+}
+
+func TestAlreadyAppliedDoesNotPickMatch(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.WriteFile("main.go", []byte(duplicatedFile), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	a := New()
+	a.pickMatch = func(*github.ReviewComment, []string, []int, int) (int, error) {
+		t.Fatal("alreadyApplied asked which occurrence to replace")
+		return -1, nil
+	}
+	if a.alreadyApplied(duplicatedComment()) {
+		t.Error("alreadyApplied() = true for an ambiguous match, want false")
+	}
+	if a.pickMatch == nil {
+		t.Error("alreadyApplied() did not restore pickMatch")
+	}
+}
+
+func TestParseMatchChoice(t *testing.T) {
+	tests := []struct {
+		response string
+		want     int
+		wantOK   bool
+	}{
+		{"1", 0, true},
+		{" 3 ", 2, true},
+		{"0", -1, false},
+		{"4", -1, false},
+		{"s", -1, false},
+		{"", -1, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseMatchChoice(tt.response, 3)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("parseMatchChoice(%q, 3) = %d, %v, want %d, %v", tt.response, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
@@ -3,7 +3,10 @@ package applier
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"math/rand/v2"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -14,149 +17,268 @@ import (
 	"github.com/chmouel/gh-prreview/pkg/ai"
 	"github.com/chmouel/gh-prreview/pkg/diffhunk"
 	"github.com/chmouel/gh-prreview/pkg/github"
+	"github.com/chmouel/gh-prreview/pkg/log"
+	"github.com/chmouel/gh-prreview/pkg/state"
 	"github.com/chmouel/gh-prreview/pkg/ui"
 )
 
+// aiMaxAttempts is how many times a transient AI provider failure is tried
+const aiMaxAttempts = 3
+
+// aiRetryBaseDelay is the wait before the first AI provider retry
+var aiRetryBaseDelay = 2 * time.Second
+
+// stdin is shared by all the prompts, a reader per prompt could buffer and
+// lose the answers typed ahead for the next ones
+var stdin = bufio.NewReader(os.Stdin)
+
 // errEditApplied is a sentinel error indicating that a patch was successfully applied via the edit flow
 var errEditApplied = fmt.Errorf("patch applied after editing")
 
 type Applier struct {
-	debug        bool
-	aiProvider   ai.AIProvider
-	githubClient *github.Client
+	aiProvider    ai.AIProvider
+	githubClient  *github.Client
+	prNumber      int
+	followRenames bool
+	renamedPaths  map[string]string
+	appliedStore  *state.AppliedStore
+	appliedRanges map[int64]state.AppliedSuggestion
+	stage         bool
+	commit        CommitMode
+	pickMatch     matchPicker             // chooses among duplicate matches, nil outside interactive mode
+	squashed      []*github.ReviewComment // applied suggestions awaiting the CommitSquash commit
+	outcomeStore  *state.OutcomeStore
+	wordDiff      bool
+	notify        bool
+	formatter     func(path string) ([]string, bool)
+	resultSink    io.Writer
+	resolved      map[int64]bool // comments whose thread was resolved during the run
+	keepThreads   bool           // never prompt for or auto-resolve threads
+	workDir       string         // checkout the suggestions are applied to, the current directory when empty
 }
 
 func New() *Applier {
 	return &Applier{}
 }
 
-// SetDebug enables or disables debug output
-func (a *Applier) SetDebug(debug bool) {
-	a.debug = debug
-}
-
 // SetAIProvider configures the AI provider for intelligent application
 func (a *Applier) SetAIProvider(provider ai.AIProvider) {
 	a.aiProvider = provider
 }
 
+// SetFollowRenames enables looking up renamed files in git history when a
+// suggestion targets a path that no longer exists
+func (a *Applier) SetFollowRenames(follow bool) {
+	a.followRenames = follow
+}
+
+// SetAppliedStore configures where applied-but-unresolved suggestions are
+// recorded, so that resolve can later check the change is still present
+func (a *Applier) SetAppliedStore(store *state.AppliedStore) {
+	a.appliedStore = store
+}
+
+// SetStage enables staging each file with `git add` after a successful apply
+func (a *Applier) SetStage(stage bool) {
+	a.stage = stage
+}
+
+// SetOutcomeStore configures where apply outcomes are recorded, building the
+// history used for per-author acceptance rates
+func (a *Applier) SetOutcomeStore(store *state.OutcomeStore) {
+	a.outcomeStore = store
+}
+
+// SetWordDiff enables highlighting the changed words of modified lines in the
+// diffs shown before applying
+func (a *Applier) SetWordDiff(wordDiff bool) {
+	a.wordDiff = wordDiff
+}
+
+// SetResolvePrompting controls whether threads are offered for resolving
+// after an apply, or auto-resolved with AI. It is on by default; turning it
+// off leaves every thread open, for edits that are not pushed yet.
+func (a *Applier) SetResolvePrompting(enabled bool) {
+	a.keepThreads = !enabled
+}
+
 // SetGitHubClient sets the GitHub client for resolving threads
 func (a *Applier) SetGitHubClient(client *github.Client) {
 	a.githubClient = client
 }
 
-// debugLog prints debug messages if debug mode is enabled
+// SetPRNumber sets the pull request the suggestions belong to, needed to
+// reply to their threads
+func (a *Applier) SetPRNumber(prNumber int) {
+	a.prNumber = prNumber
+}
+
+// debugLog prints debug messages at the debug log level
 func (a *Applier) debugLog(format string, args ...interface{}) {
-	if a.debug {
-		fmt.Fprintf(os.Stderr, "[DEBUG] "+format+"\n", args...)
-	}
+	log.Debugf(format, args...)
 }
 
 // ApplyAll applies all suggestions without prompting
-func (a *Applier) ApplyAll(suggestions []*github.ReviewComment) error {
-	applied := 0
-	failed := 0
+func (a *Applier) ApplyAll(suggestions []*github.ReviewComment) (*Summary, error) {
+	summary := NewSummary(len(suggestions))
 
 	for _, suggestion := range suggestions {
-		if err := a.applySuggestion(suggestion); err != nil {
-			fmt.Printf("%sFailed to apply suggestion for %s:%d: %v\n",
-				ui.EmojiText("❌ ", ""), suggestion.Path, suggestion.Line, err)
-			failed++
-		} else {
-			fmt.Printf("%sApplied suggestion to %s:%d\n",
-				ui.EmojiText("✅ ", ""), suggestion.Path, suggestion.Line)
-			applied++
+		err := a.applySuggestion(suggestion)
+		switch {
+		case errors.Is(err, ErrAlreadyApplied):
+			a.reportAlreadyApplied(summary, suggestion)
+		case err != nil:
+			fmt.Printf("%sFailed to apply suggestion for %s: %v\n",
+				ui.EmojiText("❌ ", "FAIL: "), suggestion.LocationLabel(), err)
+			a.finishSuggestion(summary, suggestion, state.OutcomeFailed, err)
+		default:
+			fmt.Printf("%sApplied suggestion to %s\n",
+				ui.EmojiText("✅ ", "OK: "), suggestion.LocationLabel())
 
 			// Show git diff of what was applied
 			a.showGitDiff(suggestion.Path)
+			a.formatFile(suggestion.Path)
+			a.stageFile(suggestion.Path)
+			a.commitApplied(suggestion)
+			a.recordApplied(suggestion)
+			a.finishSuggestion(summary, suggestion, state.OutcomeApplied, nil)
 		}
 	}
+	a.commitSquashed()
 
-	fmt.Printf("\nApplied %d/%d suggestions (%d failed)\n", applied, len(suggestions), failed)
+	return summary, nil
+}
+
+// Apply applies a single suggestion without printing or prompting, for
+// callers with their own UI such as the browse TUI. The file is staged and
+// the result recorded the same way as with ApplyAll. ErrAlreadyApplied is
+// returned, without recording anything, when the suggestion is in place.
+func (a *Applier) Apply(suggestion *github.ReviewComment) error {
+	if err := a.applySuggestion(suggestion); err != nil {
+		if errors.Is(err, ErrAlreadyApplied) {
+			return err
+		}
+		a.recordOutcome(suggestion, state.OutcomeFailed)
+		return err
+	}
+	a.stageFile(suggestion.Path)
+	a.recordApplied(suggestion)
+	a.recordOutcome(suggestion, state.OutcomeApplied)
 	return nil
 }
 
 // ApplyInteractive prompts the user for each suggestion using an interactive selector
-func (a *Applier) ApplyInteractive(suggestions []*github.ReviewComment) error {
-	applied := 0
-	skipped := 0
+func (a *Applier) ApplyInteractive(suggestions []*github.ReviewComment) (*Summary, error) {
+	a.pickMatch = promptForMatch
+	defer func() { a.pickMatch = nil }()
+
+	summary := NewSummary(len(suggestions))
 	remaining := make([]*github.ReviewComment, len(suggestions))
 	copy(remaining, suggestions)
 
+selection:
 	for len(remaining) > 0 {
-		// Use interactive selector to choose next suggestion
-		renderer := &suggestionRenderer{aiAvailable: a.aiProvider != nil}
-		selected, err := ui.SelectFromList(remaining, renderer)
+		// Use interactive selector to choose the next suggestions: either the
+		// highlighted one, or all marked with space, processed in list order
+		renderer := &suggestionRenderer{applier: a, aiAvailable: a.aiProvider != nil}
+		marked, err := ui.SelectManyFromList(remaining, renderer)
 		if err != nil {
 			fmt.Printf("\n%s\n", ui.Colorize(ui.ColorGray, "Selection cancelled"))
 			break
 		}
 
-		// Show detailed view of selected suggestion
-		a.showSuggestionDetails(selected, applied+skipped+1, len(suggestions))
-
-		// Prompt for action
-		action := a.promptForAction()
-
-		// Process the action
-		switch action {
-		case "apply":
-			if err := a.applySuggestion(selected); err != nil {
-				fmt.Printf("%sFailed to apply: %v\n", ui.EmojiText("❌ ", ""), err)
-				skipped++
-			} else {
-				fmt.Printf("%sApplied\n", ui.EmojiText("✅ ", ""))
-				applied++
-				a.showGitDiff(selected.Path)
-				a.promptToResolveThread(selected)
+		for _, selected := range marked {
+			// Show detailed view of selected suggestion
+			a.showSuggestionDetails(selected, len(summary.Suggestions)+1, len(suggestions))
+
+			// Prompt for action, offering a look at the reviewed commit when
+			// the branch has moved on since the review
+			original := a.reviewedElsewhere(selected)
+			action := a.promptForAction(original)
+			for action == "original" {
+				a.showAgainstOriginal(selected)
+				action = a.promptForAction(original)
 			}
-		case "ai":
-			if a.aiProvider == nil {
-				fmt.Printf("%sAI provider not configured\n", ui.EmojiText("❌ ", ""))
-				skipped++
-			} else {
-				if err := a.applyWithAI(selected, false); err != nil {
-					if err == errEditApplied {
-						applied++
-					} else {
-						fmt.Printf("%sAI application failed: %v\n", ui.EmojiText("❌ ", ""), err)
-						skipped++
-					}
+
+			// Process the action
+			switch action {
+			case "apply", "edit":
+				var err error
+				if action == "edit" {
+					err = a.applyEditedSuggestion(selected)
 				} else {
-					fmt.Printf("%sApplied with AI\n", ui.EmojiText("✅ ", ""))
-					applied++
+					err = a.applySuggestion(selected)
+				}
+				switch {
+				case errors.Is(err, ErrAlreadyApplied):
+					a.reportAlreadyApplied(summary, selected)
+				case errors.Is(err, os.ErrNotExist):
+					a.handleRemovedFile(summary, selected)
+				case errors.Is(err, errMatchSkipped):
+					fmt.Printf("%sSkipped\n", ui.EmojiText("⏭️  ", "SKIP: "))
+					a.finishSuggestion(summary, selected, state.OutcomeSkipped, nil)
+				case err != nil:
+					fmt.Printf("%sFailed to apply: %v\n", ui.EmojiText("❌ ", "FAIL: "), err)
+					a.finishSuggestion(summary, selected, state.OutcomeFailed, err)
+				default:
+					fmt.Printf("%sApplied\n", ui.EmojiText("✅ ", "OK: "))
 					a.showGitDiff(selected.Path)
+					a.formatFile(selected.Path)
+					a.stageFile(selected.Path)
+					a.commitApplied(selected)
 					a.promptToResolveThread(selected)
+					a.finishSuggestion(summary, selected, state.OutcomeApplied, nil)
 				}
+			case "ai":
+				if a.aiProvider == nil {
+					fmt.Printf("%sAI provider not configured\n", ui.EmojiText("❌ ", "FAIL: "))
+					a.finishSuggestion(summary, selected, state.OutcomeSkipped, nil)
+				} else if a.alreadyApplied(selected) {
+					a.reportAlreadyApplied(summary, selected)
+				} else {
+					if err := a.applyWithAI(selected, false); err != nil {
+						if err == errEditApplied {
+							a.finishSuggestion(summary, selected, state.OutcomeApplied, nil)
+							a.commitApplied(selected)
+						} else {
+							fmt.Printf("%sAI application failed: %v\n", ui.EmojiText("❌ ", "FAIL: "), err)
+							a.finishSuggestion(summary, selected, state.OutcomeFailed, err)
+						}
+					} else {
+						fmt.Printf("%sApplied with AI\n", ui.EmojiText("✅ ", "OK: "))
+						a.showGitDiff(selected.Path)
+						a.formatFile(selected.Path)
+						a.stageFile(selected.Path)
+						a.commitApplied(selected)
+						a.promptToResolveThread(selected)
+						a.finishSuggestion(summary, selected, state.OutcomeApplied, nil)
+					}
+				}
+			case "skip":
+				fmt.Printf("%sSkipped\n", ui.EmojiText("⏭️  ", "SKIP: "))
+				a.finishSuggestion(summary, selected, state.OutcomeSkipped, nil)
+			case "quit":
+				fmt.Printf("\n%s\n", ui.Colorize(ui.ColorGray, "Stopped"))
+				break selection
 			}
-		case "skip":
-			fmt.Printf("%sSkipped\n", ui.EmojiText("⏭️  ", ""))
-			skipped++
-		case "quit":
-			fmt.Printf("\nStopped. Applied %d/%d suggestions\n", applied, len(suggestions))
-			return nil
-		}
-
-		// Remove the processed suggestion from remaining
-		for i, s := range remaining {
-			if s.ID == selected.ID {
-				remaining = append(remaining[:i], remaining[i+1:]...)
-				break
+
+			// Remove the processed suggestion from remaining
+			for i, s := range remaining {
+				if s.ID == selected.ID {
+					remaining = append(remaining[:i], remaining[i+1:]...)
+					break
+				}
 			}
 		}
 	}
+	a.commitSquashed()
 
-	fmt.Printf("\n%s\n", ui.Colorize(ui.ColorGray, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━"))
-	fmt.Printf("%s Applied %s, Skipped %s\n",
-		ui.Colorize(ui.ColorCyan, "Summary:"),
-		ui.Colorize(ui.ColorGreen, fmt.Sprintf("%d", applied)),
-		ui.Colorize(ui.ColorYellow, fmt.Sprintf("%d", skipped)))
-	return nil
+	return summary, nil
 }
 
 // showSuggestionDetails displays full details of a selected suggestion
 func (a *Applier) showSuggestionDetails(suggestion *github.ReviewComment, index, total int) {
-	fileLocation := fmt.Sprintf("%s:%d", suggestion.Path, suggestion.Line)
+	fileLocation := suggestion.LocationLabel()
 	clickableLocation := ui.CreateHyperlink(suggestion.HTMLURL, fileLocation)
 
 	header := fmt.Sprintf("[%d/%d] %s by @%s", index, total, clickableLocation, suggestion.Author)
@@ -173,19 +295,24 @@ func (a *Applier) showSuggestionDetails(suggestion *github.ReviewComment, index,
 		if err == nil && rendered != "" {
 			fmt.Println(rendered)
 		} else {
-			wrappedComment := ui.WrapText(commentText, 80)
+			wrappedComment := ui.WrapText(commentText, ui.TextWidth())
 			fmt.Printf("%s\n", wrappedComment)
 		}
 	}
 
 	// Show the suggestion
 	fmt.Printf("\n%s\n", "Suggested change:")
-	fmt.Println(ui.ColorizeCode(suggestion.SuggestedCode))
+	if suggestion.IsDeletion {
+		fmt.Println(ui.Colorize(ui.ColorRed, "(delete the commented lines)"))
+	} else {
+		language := ui.CodeFenceLanguageFromPath(suggestion.Path)
+		fmt.Println(ui.WrapCode(ui.HighlightCode(suggestion.SuggestedCode, language), ui.TerminalWidth()))
+	}
 
 	// Show context
 	if suggestion.DiffHunk != "" {
 		fmt.Printf("\n%s\n", "Context:")
-		fmt.Println(ui.ColorizeDiff(suggestion.DiffHunk))
+		fmt.Println(a.colorizeDiff(suggestion.DiffHunk))
 	}
 
 	// Show thread comments
@@ -193,14 +320,14 @@ func (a *Applier) showSuggestionDetails(suggestion *github.ReviewComment, index,
 		fmt.Printf("\n%s\n", ui.Colorize(ui.ColorCyan, "Thread replies:"))
 		for i, threadComment := range suggestion.ThreadComments {
 			fmt.Printf("\n  %s\n", ui.Colorize(ui.ColorGray, fmt.Sprintf("└─ Reply %d by @%s:", i+1, threadComment.Author)))
-			rendered, err := ui.RenderMarkdown(threadComment.Body)
+			rendered, err := ui.RenderMarkdownWidth(threadComment.Body, ui.IndentedTextWidth(5))
 			if err == nil && rendered != "" {
 				lines := strings.Split(rendered, "\n")
 				for _, line := range lines {
 					fmt.Printf("     %s\n", line)
 				}
 			} else {
-				wrappedReply := ui.WrapText(threadComment.Body, 75)
+				wrappedReply := ui.WrapText(threadComment.Body, ui.IndentedTextWidth(5))
 				lines := strings.Split(wrappedReply, "\n")
 				for _, line := range lines {
 					fmt.Printf("     %s\n", line)
@@ -210,19 +337,28 @@ func (a *Applier) showSuggestionDetails(suggestion *github.ReviewComment, index,
 	}
 }
 
-// promptForAction prompts user for action on the selected suggestion
-func (a *Applier) promptForAction() string {
-	prompt := "Apply this suggestion? [y/s/q] (yes/skip/quit)"
+// promptForAction prompts user for action on the selected suggestion. With
+// original, the suggestion can also be shown against the reviewed commit.
+func (a *Applier) promptForAction(original bool) string {
+	keys := []string{"y", "e", "s"}
+	names := []string{"yes", "edit", "skip"}
 	if a.aiProvider != nil {
-		prompt = "Apply this suggestion? [y/s/a/q] (yes/skip/ai-apply/quit)"
+		keys = append(keys, "a")
+		names = append(names, "ai-apply")
+	}
+	if original {
+		keys = append(keys, "o")
+		names = append(names, "original")
 	}
+	keys = append(keys, "q")
+	names = append(names, "quit")
+	prompt := fmt.Sprintf("Apply this suggestion? [%s] (%s)", strings.Join(keys, "/"), strings.Join(names, "/"))
 
 	for {
 		fmt.Printf("\n%s ", prompt)
-		var response string
-		_, err := fmt.Scanln(&response)
+		response, err := stdin.ReadString('\n')
 		if err != nil {
-			// If scanln fails (e.g., EOF), treat as quit
+			// If reading fails (e.g., EOF), treat as quit
 			return "quit"
 		}
 
@@ -231,17 +367,24 @@ func (a *Applier) promptForAction() string {
 		switch response {
 		case "y", "yes":
 			return "apply"
+		case "e", "edit":
+			return "edit"
 		case "a", "ai", "ai-apply":
 			if a.aiProvider != nil {
 				return "ai"
 			}
-			fmt.Printf("%sAI provider not configured, please choose again\n", ui.EmojiText("❌ ", ""))
+			fmt.Printf("%sAI provider not configured, please choose again\n", ui.EmojiText("❌ ", "FAIL: "))
+		case "o", "original":
+			if original {
+				return "original"
+			}
+			fmt.Printf("%sAlready at the reviewed commit, please choose again\n", ui.EmojiText("❌ ", "FAIL: "))
 		case "s", "skip", "n", "no", "":
 			return "skip"
 		case "q", "quit":
 			return "quit"
 		default:
-			fmt.Printf("%sUnrecognized input, skipping\n", ui.EmojiText("⏭️  ", ""))
+			fmt.Printf("%sUnrecognized input, skipping\n", ui.EmojiText("⏭️  ", "SKIP: "))
 			return "skip"
 		}
 	}
@@ -251,49 +394,39 @@ func (a *Applier) promptForAction() string {
 func (a *Applier) applySuggestion(comment *github.ReviewComment) error {
 	a.debugLog("Applying suggestion for comment ID=%d, Path=%s, Line=%d", comment.ID, comment.Path, comment.Line)
 
+	if err := a.followRenamedFile(comment); err != nil {
+		return err
+	}
+
 	// Read the current file
-	fileContent, err := os.ReadFile(comment.Path)
+	fileContent, err := os.ReadFile(a.path(comment.Path))
 	if err != nil {
 		return fmt.Errorf("failed to read file %s: %w", comment.Path, err)
 	}
-	fileLines := strings.Split(string(fileContent), "\n")
 
-	// Find the lines to replace
-	targetLine, removeCount, err := a.findReplacementTarget(comment, fileLines)
+	edit, err := a.editContent(comment, string(fileContent))
 	if err != nil {
 		return err
 	}
 
-	a.debugLog("Replacing %d lines starting at line %d with suggested code", removeCount, targetLine+1)
-
-	// Prepare the new lines
-	suggestionLines := strings.Split(strings.TrimSuffix(comment.SuggestedCode, "\n"), "\n")
-
-	// Construct the new file content
-	var newFileLines []string
-
-	// Add lines before the change
-	newFileLines = append(newFileLines, fileLines[:targetLine]...)
-
-	// Add the suggested lines
-	newFileLines = append(newFileLines, suggestionLines...)
-
-	// Add lines after the change
-	if targetLine+removeCount < len(fileLines) {
-		newFileLines = append(newFileLines, fileLines[targetLine+removeCount:]...)
+	if err := os.WriteFile(a.path(comment.Path), []byte(edit.content), 0o644); err != nil {
+		return fmt.Errorf("failed to write file %s: %w", comment.Path, err)
 	}
 
-	// Join lines and write back to file
-	// Note: This assumes \n line endings. For mixed line endings, we might want to detect the file's EOL.
-	newContent := strings.Join(newFileLines, "\n")
-
-	// Preserve trailing newline if the original file had one
-	if strings.HasSuffix(string(fileContent), "\n") && !strings.HasSuffix(newContent, "\n") {
-		newContent += "\n"
+	if a.appliedRanges == nil {
+		a.appliedRanges = make(map[int64]state.AppliedSuggestion)
 	}
-
-	if err := os.WriteFile(comment.Path, []byte(newContent), 0o644); err != nil {
-		return fmt.Errorf("failed to write file %s: %w", comment.Path, err)
+	// The path is recorded absolute, for resolve to find the file from any
+	// directory
+	recordedPath := a.path(comment.Path)
+	if abs, err := filepath.Abs(recordedPath); err == nil {
+		recordedPath = abs
+	}
+	a.appliedRanges[comment.ID] = state.AppliedSuggestion{
+		Path:      recordedPath,
+		StartLine: edit.start + 1,
+		EndLine:   edit.start + len(edit.added),
+		Lines:     edit.added,
 	}
 
 	a.debugLog("Successfully applied suggestion to %s", comment.Path)
@@ -356,8 +489,8 @@ func (a *Applier) findReplacementTarget(comment *github.ReviewComment, fileLines
 	if !strategy1Valid {
 		a.debugLog("Trying Strategy 2 (content matching)")
 
-		matchStart := -1
-		// Search for the block of lines
+		// Search for every occurrence of the block of lines
+		var matches []int
 		for i := 0; i <= len(fileLines)-len(addedLines); i++ {
 			match := true
 			for j := 0; j < len(addedLines); j++ {
@@ -367,17 +500,24 @@ func (a *Applier) findReplacementTarget(comment *github.ReviewComment, fileLines
 				}
 			}
 			if match {
-				matchStart = i
-				a.debugLog("Strategy 2: Found content match at line %d (0-based)", matchStart)
-				break
+				a.debugLog("Strategy 2: Found content match at line %d (0-based)", i)
+				matches = append(matches, i)
 			}
 		}
 
-		if matchStart == -1 {
+		switch len(matches) {
+		case 0:
 			return -1, 0, fmt.Errorf("could not find the code to replace in current file (looking for %d lines starting with %q)",
 				len(addedLines), addedLines[0])
+		case 1:
+			targetLine = matches[0]
+		default:
+			chosen, err := a.resolveAmbiguousMatch(comment, fileLines, matches, len(addedLines))
+			if err != nil {
+				return -1, 0, err
+			}
+			targetLine = chosen
 		}
-		targetLine = matchStart
 	}
 
 	// Final verification (redundant if we just searched, but good for safety)
@@ -490,8 +630,21 @@ func (a *Applier) saveMismatchDiff(comment *github.ReviewComment, fileLines []st
 	return diffFile
 }
 
+// colorizeDiff colors a diff for display, word by word when enabled
+func (a *Applier) colorizeDiff(diff string) string {
+	if a.wordDiff {
+		return ui.ColorizeDiffWords(diff)
+	}
+	return ui.ColorizeDiff(diff)
+}
+
 // showGitDiff shows the git diff for a file after applying changes
 func (a *Applier) showGitDiff(filePath string) {
+	a.showGitDiffTitled(filePath, "Applied changes:")
+}
+
+// showGitDiffTitled shows the git diff for a file under title
+func (a *Applier) showGitDiffTitled(filePath, title string) {
 	args := []string{"diff"}
 	if ui.ColorsEnabled() {
 		args = append(args, "--color=always")
@@ -500,7 +653,7 @@ func (a *Applier) showGitDiff(filePath string) {
 	}
 	args = append(args, filePath)
 
-	cmd := exec.Command("git", args...)
+	cmd := exec.Command("git", a.gitArgs(args...)...)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		// Don't fail, just skip showing diff
@@ -508,7 +661,7 @@ func (a *Applier) showGitDiff(filePath string) {
 	}
 
 	if len(output) > 0 && strings.TrimSpace(string(output)) != "" {
-		fmt.Printf("\n%s\n", ui.Colorize(ui.ColorCyan, "Applied changes:"))
+		fmt.Printf("\n%s\n", ui.Colorize(ui.ColorCyan, title))
 		fmt.Print(string(output))
 	}
 }
@@ -517,8 +670,12 @@ func (a *Applier) showGitDiff(filePath string) {
 func (a *Applier) applyWithAI(comment *github.ReviewComment, autoApply bool) error {
 	ctx := context.Background()
 
+	if err := a.followRenamedFile(comment); err != nil {
+		return err
+	}
+
 	// Read current file
-	fileContent, err := os.ReadFile(comment.Path)
+	fileContent, err := os.ReadFile(a.path(comment.Path))
 	if err != nil {
 		return fmt.Errorf("failed to read file: %w", err)
 	}
@@ -551,8 +708,8 @@ func (a *Applier) applyWithAI(comment *github.ReviewComment, autoApply bool) err
 	s.Suffix = fmt.Sprintf(" Analyzing code and generating patch with %s (%s)...", providerName, modelName)
 	s.Start()
 
-	// Call AI provider
-	resp, err := a.aiProvider.ApplySuggestion(ctx, req)
+	// Call AI provider, retrying transient failures
+	resp, err := a.requestAISuggestion(ctx, req, s)
 
 	// Stop spinner
 	s.Stop()
@@ -576,18 +733,17 @@ func (a *Applier) applyWithAI(comment *github.ReviewComment, autoApply bool) err
 
 	// Show the generated patch
 	fmt.Printf("\n%s\n", ui.Colorize(ui.ColorCyan, "Generated patch:"))
-	fmt.Println(ui.ColorizeDiff(resp.Patch))
+	fmt.Println(a.colorizeDiff(resp.Patch))
 
 	a.debugLog("AI-generated patch:\n%s", resp.Patch)
 
 	// Ask for confirmation (unless auto-apply mode)
 	patchToApply := resp.Patch
 	if !autoApply {
-		reader := bufio.NewReader(os.Stdin)
 	confirmationLoop:
 		for {
 			fmt.Printf("\n%s ", ui.Colorize(ui.ColorYellow, "Apply this AI-generated patch? [y/n/e] (yes/no/edit)"))
-			response, err := reader.ReadString('\n')
+			response, err := stdin.ReadString('\n')
 			if err != nil {
 				return fmt.Errorf("failed to read input: %w", err)
 			}
@@ -603,10 +759,10 @@ func (a *Applier) applyWithAI(comment *github.ReviewComment, autoApply bool) err
 			case "e", "edit":
 				// Apply patch and open file for editing
 				if err := a.applyPatchAndEditFile(patchToApply, comment.Path, comment); err != nil {
-					fmt.Printf("%sFailed to apply and edit: %v\n", ui.EmojiText("❌ ", ""), err)
+					fmt.Printf("%sFailed to apply and edit: %v\n", ui.EmojiText("❌ ", "FAIL: "), err)
 					// Ask if they want to try with original patch
 					fmt.Printf("Try applying without editing? [y/n] ")
-					continueResp, _ := reader.ReadString('\n')
+					continueResp, _ := stdin.ReadString('\n')
 					continueResp = strings.ToLower(strings.TrimSpace(continueResp))
 					if continueResp == "y" || continueResp == "yes" {
 						break confirmationLoop
@@ -621,20 +777,16 @@ func (a *Applier) applyWithAI(comment *github.ReviewComment, autoApply bool) err
 		}
 	}
 
-	// Apply the AI-generated patch
-	cmd := exec.Command("git", "apply", "--unidiff-zero", "-")
-	cmd.Stdin = strings.NewReader(patchToApply)
-	output, err := cmd.CombinedOutput()
-	if err != nil {
+	// Apply the AI-generated patch, retrying with laxer whitespace handling
+	if _, err := a.applyPatchFallback(patchToApply); err != nil {
 		// Save failed AI patch for debugging
 		patchFile := fmt.Sprintf("/tmp/gh-prreview-ai-patch-%d.patch", comment.ID)
 		patchContent := fmt.Sprintf("# AI-generated patch for comment ID %d\n", comment.ID)
 		patchContent += fmt.Sprintf("# File: %s\n", comment.Path)
 		patchContent += fmt.Sprintf("# AI Provider: %s\n", a.aiProvider.Name())
 		patchContent += fmt.Sprintf("# Confidence: %.0f%%\n", resp.Confidence*100)
-		patchContent += fmt.Sprintf("# Error: %v\n", err)
-		patchContent += "# git apply output:\n"
-		for _, line := range strings.Split(string(output), "\n") {
+		patchContent += "# git apply error:\n"
+		for _, line := range strings.Split(err.Error(), "\n") {
 			patchContent += fmt.Sprintf("# %s\n", line)
 		}
 		patchContent += "#\n# Generated patch:\n#\n"
@@ -643,46 +795,76 @@ func (a *Applier) applyWithAI(comment *github.ReviewComment, autoApply bool) err
 		if err := os.WriteFile(patchFile, []byte(patchContent), 0o644); err != nil {
 			a.debugLog("Failed to save AI patch to %s: %v", patchFile, err)
 		}
-		return fmt.Errorf("failed to apply AI-generated patch (saved to %s): %w\nOutput: %s",
-			patchFile, err, string(output))
+		return fmt.Errorf("failed to apply AI-generated patch, whitespace fixes included (saved to %s): %w",
+			patchFile, err)
 	}
 
 	return nil
 }
 
+// requestAISuggestion calls the AI provider, retrying with a jittered
+// exponential backoff when the failure is transient (rate limit, server error).
+// Other errors are returned straight away.
+func (a *Applier) requestAISuggestion(ctx context.Context, req *ai.SuggestionRequest, s *spinner.Spinner) (*ai.SuggestionResponse, error) {
+	// The spinner goroutine reads Suffix while it runs
+	s.Lock()
+	suffix := s.Suffix
+	s.Unlock()
+	for attempt := 1; ; attempt++ {
+		resp, err := a.aiProvider.ApplySuggestion(ctx, req)
+		if err == nil || attempt >= aiMaxAttempts || !ai.IsRetryable(err) {
+			return resp, err
+		}
+
+		delay := aiRetryDelay(attempt)
+		a.debugLog("AI provider attempt %d/%d failed: %v (retrying in %s)", attempt, aiMaxAttempts, err, delay)
+		s.Lock()
+		s.Suffix = fmt.Sprintf("%s retrying %d/%d...", suffix, attempt+1, aiMaxAttempts)
+		s.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(delay):
+		}
+	}
+}
+
+// aiRetryDelay returns the wait before retrying after the given failed
+// attempt: the base delay doubled for each attempt, plus up to 50% jitter
+func aiRetryDelay(attempt int) time.Duration {
+	delay := aiRetryBaseDelay << (attempt - 1)
+	if delay <= 0 {
+		return 0
+	}
+	return delay + rand.N(delay/2+1)
+}
+
 // applyPatchAndEditFile applies a patch and then opens the file for further editing
 func (a *Applier) applyPatchAndEditFile(patch string, filePath string, comment *github.ReviewComment) error {
-	// First, apply the patch
-	fmt.Printf("\n%s\n", ui.Colorize(ui.ColorCyan, "Applying patch to file..."))
-	cmd := exec.Command("git", "apply", "--unidiff-zero", "-")
-	cmd.Stdin = strings.NewReader(patch)
-	output, err := cmd.CombinedOutput()
+	// Keep the file as it is, to put it back when the change is rejected.
+	// Neither git checkout -- (the staged copy) nor HEAD is right: earlier
+	// suggestions of the run may be in the file, staged or not.
+	original, err := os.ReadFile(a.path(filePath))
 	if err != nil {
-		return fmt.Errorf("failed to apply patch: %w\nOutput: %s", err, string(output))
+		return fmt.Errorf("failed to read file %s: %w", filePath, err)
 	}
 
-	fmt.Printf("%sPatch applied. Opening file for additional edits...\n", ui.EmojiText("✅ ", ""))
-
-	// Get editor from environment, default to vi
-	editor := os.Getenv("EDITOR")
-	if editor == "" {
-		editor = "vi"
+	// First, apply the patch
+	fmt.Printf("\n%s\n", ui.Colorize(ui.ColorCyan, "Applying patch to file..."))
+	if _, err := a.applyPatchFallback(patch); err != nil {
+		return fmt.Errorf("failed to apply patch: %w", err)
 	}
 
-	// Open the file in editor
-	editorParts := strings.Fields(editor)
-	editorCmd := exec.Command(editorParts[0], append(editorParts[1:], filePath)...)
-	editorCmd.Stdin = os.Stdin
-	editorCmd.Stdout = os.Stdout
-	editorCmd.Stderr = os.Stderr
+	fmt.Printf("%sPatch applied. Opening file for additional edits...\n", ui.EmojiText("✅ ", "OK: "))
 
-	if err := editorCmd.Run(); err != nil {
+	// Open the file in editor, at the commented line
+	if err := ui.LaunchEditor(a.path(filePath), comment.Line); err != nil {
 		// Editor failed, revert the patch
-		fmt.Printf("%sEditor exited with error: %v\n", ui.EmojiText("❌ ", ""), err)
+		fmt.Printf("%s%v\n", ui.EmojiText("❌ ", "FAIL: "), err)
 		fmt.Printf("Reverting changes...\n")
-		revertCmd := exec.Command("git", "checkout", "--", filePath)
-		if revertErr := revertCmd.Run(); revertErr != nil {
-			fmt.Printf("%sFailed to revert changes: %v\n", ui.EmojiText("❌ ", ""), revertErr)
+		if revertErr := a.restoreFile(filePath, original); revertErr != nil {
+			fmt.Printf("%sFailed to revert changes: %v\n", ui.EmojiText("❌ ", "FAIL: "), revertErr)
 			return fmt.Errorf("editor failed and revert failed: %w", revertErr)
 		}
 		return fmt.Errorf("editor failed")
@@ -694,13 +876,11 @@ func (a *Applier) applyPatchAndEditFile(patch string, filePath string, comment *
 
 	// Ask if they want to keep the changes
 	fmt.Printf("\n%s ", ui.Colorize(ui.ColorYellow, "Keep these changes? [y/n]"))
-	reader := bufio.NewReader(os.Stdin)
-	response, err := reader.ReadString('\n')
+	response, err := stdin.ReadString('\n')
 	if err != nil {
 		// Revert on error
-		revertCmd := exec.Command("git", "checkout", "--", filePath)
-		if revertErr := revertCmd.Run(); revertErr != nil {
-			fmt.Printf("%sFailed to revert changes: %v\n", ui.EmojiText("❌ ", ""), revertErr)
+		if revertErr := a.restoreFile(filePath, original); revertErr != nil {
+			fmt.Printf("%sFailed to revert changes: %v\n", ui.EmojiText("❌ ", "FAIL: "), revertErr)
 			return fmt.Errorf("failed to revert changes: %w", revertErr)
 		}
 		return fmt.Errorf("failed to read input: %w", err)
@@ -710,15 +890,16 @@ func (a *Applier) applyPatchAndEditFile(patch string, filePath string, comment *
 	if response != "y" && response != "yes" {
 		// Revert the changes
 		fmt.Printf("Reverting changes...\n")
-		revertCmd := exec.Command("git", "checkout", "--", filePath)
-		if err := revertCmd.Run(); err != nil {
+		if err := a.restoreFile(filePath, original); err != nil {
 			return fmt.Errorf("failed to revert changes: %w", err)
 		}
-		fmt.Printf("%sChanges reverted\n", ui.EmojiText("❌ ", ""))
+		fmt.Printf("%sChanges reverted\n", ui.EmojiText("❌ ", "FAIL: "))
 		return fmt.Errorf("changes discarded by user")
 	}
 
-	fmt.Printf("%sChanges kept\n", ui.EmojiText("✅ ", ""))
+	fmt.Printf("%sChanges kept\n", ui.EmojiText("✅ ", "OK: "))
+	a.formatFile(filePath)
+	a.stageFile(filePath)
 
 	// Prompt to resolve thread
 	a.promptToResolveThread(comment)
@@ -726,6 +907,11 @@ func (a *Applier) applyPatchAndEditFile(patch string, filePath string, comment *
 	return nil
 }
 
+// restoreFile writes back the content a file had before a rejected change
+func (a *Applier) restoreFile(filePath string, content []byte) error {
+	return os.WriteFile(a.path(filePath), content, 0o644)
+}
+
 // promptToResolveThread asks user if they want to mark the review thread as resolved
 func (a *Applier) promptToResolveThread(comment *github.ReviewComment) {
 	// Only prompt if we have a GitHub client and thread ID
@@ -733,70 +919,172 @@ func (a *Applier) promptToResolveThread(comment *github.ReviewComment) {
 		return
 	}
 
+	if a.keepThreads {
+		a.recordApplied(comment)
+		return
+	}
+
 	// Don't prompt if already resolved
 	if comment.IsResolved() {
 		return
 	}
 
 	fmt.Printf("\n%s ", ui.Colorize(ui.ColorYellow, "Mark this review thread as resolved? [y/n]"))
-	reader := bufio.NewReader(os.Stdin)
-	response, err := reader.ReadString('\n')
+	response, err := stdin.ReadString('\n')
 	if err != nil {
+		a.recordApplied(comment)
 		return
 	}
 
 	response = strings.ToLower(strings.TrimSpace(response))
 	if response == "y" || response == "yes" {
 		if err := a.githubClient.ResolveThread(comment.ThreadID); err != nil {
-			fmt.Printf("%sFailed to resolve thread: %v\n", ui.EmojiText("❌ ", ""), err)
+			fmt.Printf("%sFailed to resolve thread: %v\n", ui.EmojiText("❌ ", "FAIL: "), err)
+			a.recordApplied(comment)
 		} else {
-			fmt.Printf("%sReview thread marked as resolved\n", ui.EmojiText("✅ ", ""))
+			fmt.Printf("%sReview thread marked as resolved\n", ui.EmojiText("✅ ", "OK: "))
+			a.markResolved(comment)
 		}
+		return
+	}
+
+	a.recordApplied(comment)
+}
+
+// recordApplied saves the line range an applied suggestion ended up at, for
+// threads left unresolved, so a later resolve can verify the change is still there
+func (a *Applier) recordApplied(comment *github.ReviewComment) {
+	record, ok := a.appliedRanges[comment.ID]
+	if !ok || a.appliedStore == nil || a.githubClient == nil || comment.ThreadID == "" || comment.IsResolved() {
+		return
+	}
+	if len(record.Lines) == 0 {
+		// Suggestions that only delete lines leave nothing to check for
+		return
+	}
+
+	repo, err := a.githubClient.GetRepo()
+	if err != nil {
+		a.debugLog("Not recording applied suggestion %d: %v", comment.ID, err)
+		return
+	}
+
+	record.Repo = repo
+	record.CommentID = comment.ID
+	record.ThreadID = comment.ThreadID
+	record.AppliedAt = time.Now()
+	if err := a.appliedStore.Record(record); err != nil {
+		a.debugLog("Failed to record applied suggestion %d: %v", comment.ID, err)
+		return
+	}
+	a.debugLog("Recorded applied suggestion %d at %s:%d-%d", comment.ID, record.Path, record.StartLine, record.EndLine)
+}
+
+// alreadyApplied reports whether the file already contains the suggestion,
+// for the AI paths that don't go through applySuggestion
+func (a *Applier) alreadyApplied(comment *github.ReviewComment) bool {
+	fileContent, err := os.ReadFile(a.path(comment.Path))
+	if err != nil {
+		return false
+	}
+	// Not the place to ask which occurrence is meant: the answer would be
+	// lost, an ambiguous match just counts as not applied
+	pickMatch := a.pickMatch
+	a.pickMatch = nil
+	defer func() { a.pickMatch = pickMatch }()
+	_, err = a.editContent(comment, string(fileContent))
+	return errors.Is(err, ErrAlreadyApplied)
+}
+
+// reportAlreadyApplied notes a suggestion found already in place. No outcome
+// is recorded: the run that applied it already did.
+func (a *Applier) reportAlreadyApplied(summary *Summary, comment *github.ReviewComment) {
+	fmt.Printf("%sAlready applied: %s\n", ui.EmojiText("⏭️  ", "SKIP: "), comment.LocationLabel())
+	summary.add(comment, ResultAlreadyApplied, nil)
+	a.writeResult(comment, ResultAlreadyApplied, nil)
+}
+
+// finishSuggestion adds what happened to a suggestion to the run summary, the
+// outcome history and the result sink
+func (a *Applier) finishSuggestion(summary *Summary, comment *github.ReviewComment, result string, err error) {
+	summary.add(comment, result, err)
+	a.recordOutcome(comment, result)
+	a.writeResult(comment, result, err)
+}
+
+// recordOutcome appends what happened to a suggestion to the outcome history
+func (a *Applier) recordOutcome(comment *github.ReviewComment, result string) {
+	if a.outcomeStore == nil || a.githubClient == nil {
+		return
+	}
+
+	repo, err := a.githubClient.GetRepo()
+	if err != nil {
+		a.debugLog("Not recording outcome for suggestion %d: %v", comment.ID, err)
+		return
+	}
+
+	outcome := state.Outcome{
+		Repo:      repo,
+		CommentID: comment.ID,
+		Author:    comment.Author,
+		Result:    result,
+		At:        time.Now(),
+	}
+	if err := a.outcomeStore.Record(outcome); err != nil {
+		a.debugLog("Failed to record outcome for suggestion %d: %v", comment.ID, err)
 	}
 }
 
 // ApplyAllWithAI applies all suggestions using AI without prompting
-func (a *Applier) ApplyAllWithAI(suggestions []*github.ReviewComment) error {
+func (a *Applier) ApplyAllWithAI(suggestions []*github.ReviewComment) (*Summary, error) {
 	if a.aiProvider == nil {
-		return fmt.Errorf("AI provider not configured")
+		return nil, fmt.Errorf("AI provider not configured")
 	}
 
-	applied := 0
-	failed := 0
+	summary := NewSummary(len(suggestions))
 
 	for _, suggestion := range suggestions {
 		fmt.Printf("\n%s\n", ui.Colorize(ui.ColorGray, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━"))
-		fmt.Printf("%s %s:%d by @%s\n",
+		fmt.Printf("%s %s by @%s\n",
 			ui.Colorize(ui.ColorCyan, "Processing:"),
-			suggestion.Path, suggestion.Line, suggestion.Author)
+			suggestion.LocationLabel(), suggestion.Author)
+
+		if a.alreadyApplied(suggestion) {
+			a.reportAlreadyApplied(summary, suggestion)
+			continue
+		}
 
 		if err := a.applyWithAI(suggestion, true); err != nil {
-			fmt.Printf("%sFailed: %v\n", ui.EmojiText("❌ ", ""), err)
-			failed++
+			fmt.Printf("%sFailed: %v\n", ui.EmojiText("❌ ", "FAIL: "), err)
+			a.finishSuggestion(summary, suggestion, state.OutcomeFailed, err)
 		} else {
-			fmt.Printf("%sApplied successfully\n", ui.EmojiText("✅ ", ""))
-			applied++
+			fmt.Printf("%sApplied successfully\n", ui.EmojiText("✅ ", "OK: "))
 
 			// Show git diff of what was applied
 			a.showGitDiff(suggestion.Path)
+			a.formatFile(suggestion.Path)
+			a.stageFile(suggestion.Path)
+			a.commitApplied(suggestion)
 
 			// Automatically resolve thread when possible
-			if a.githubClient != nil && suggestion.ThreadID != "" && !suggestion.IsResolved() {
+			if a.keepThreads {
+				a.recordApplied(suggestion)
+			} else if a.githubClient != nil && suggestion.ThreadID != "" && !suggestion.IsResolved() {
 				if err := a.githubClient.ResolveThread(suggestion.ThreadID); err != nil {
-					fmt.Printf("%sFailed to auto-resolve thread: %v\n", ui.EmojiText("⚠️  ", ""), err)
+					fmt.Printf("%sFailed to auto-resolve thread: %v\n", ui.EmojiText("⚠️  ", "Warning: "), err)
 				} else {
-					fmt.Printf("%sReview thread auto-resolved\n", ui.EmojiText("✅ ", ""))
+					fmt.Printf("%sReview thread auto-resolved\n", ui.EmojiText("✅ ", "OK: "))
+					a.markResolved(suggestion)
 				}
 			}
+			a.finishSuggestion(summary, suggestion, state.OutcomeApplied, nil)
 		}
 	}
+	a.commitSquashed()
+	a.notifyFinished(summary)
 
-	fmt.Printf("\n%s\n", ui.Colorize(ui.ColorGray, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━"))
-	fmt.Printf("%s Applied %s, Failed %s\n",
-		ui.Colorize(ui.ColorCyan, "Summary:"),
-		ui.Colorize(ui.ColorGreen, fmt.Sprintf("%d", applied)),
-		ui.Colorize(ui.ColorRed, fmt.Sprintf("%d", failed)))
-	return nil
+	return summary, nil
 }
 
 // detectLanguage detects programming language from file extension
@@ -844,17 +1132,36 @@ func detectLanguage(filePath string) string {
 
 // suggestionRenderer implements ui.ItemRenderer for ReviewComments in the apply context
 type suggestionRenderer struct {
+	applier     *Applier // checks the drift in its work dir
 	aiAvailable bool
+	drift       map[int64]string // CanApply reason by comment ID, "" when clean
+}
+
+// driftReason returns why the suggestion no longer applies to the local file,
+// or "" when it does. Results are cached: the renderer lives for one selection.
+func (r *suggestionRenderer) driftReason(comment *github.ReviewComment) string {
+	if !comment.HasSuggestion {
+		return ""
+	}
+	if reason, ok := r.drift[comment.ID]; ok {
+		return reason
+	}
+	if r.drift == nil {
+		r.drift = make(map[int64]string)
+	}
+	_, reason := r.applier.CanApply(comment)
+	r.drift[comment.ID] = reason
+	return reason
 }
 
 func (r *suggestionRenderer) Title(comment *github.ReviewComment) string {
 	style := ui.NewSuggestionListStyle(comment.Author, comment.IsResolved())
-	return style.FormatSuggestionTitle(comment.Path, comment.Line)
+	return style.FormatSuggestionTitle(comment.LocationLabel())
 }
 
 func (r *suggestionRenderer) Description(comment *github.ReviewComment) string {
 	style := ui.NewSuggestionListStyle(comment.Author, comment.IsResolved())
-	return style.FormatSuggestionDescription(comment.HasSuggestion, comment.IsOutdated)
+	return style.FormatSuggestionDescription(comment.HasSuggestion, comment.IsOutdated, r.driftReason(comment) != "")
 }
 
 func (r *suggestionRenderer) Preview(comment *github.ReviewComment) string {
@@ -873,13 +1180,17 @@ func (r *suggestionRenderer) PreviewWithHighlight(comment *github.ReviewComment,
 		statusColor = ui.ColorGreen
 	}
 	preview.WriteString(ui.Colorize(ui.ColorCyan, fmt.Sprintf("Author: @%s\n", comment.Author)))
-	preview.WriteString(ui.Colorize(ui.ColorCyan, fmt.Sprintf("Location: %s:%d\n", comment.Path, comment.Line)))
+	preview.WriteString(ui.Colorize(ui.ColorCyan, fmt.Sprintf("Location: %s\n", comment.LocationLabel())))
 	preview.WriteString(ui.Colorize(ui.ColorCyan, fmt.Sprintf("Status: %s\n", ui.Colorize(statusColor, status))))
 
 	if comment.IsOutdated {
 		preview.WriteString(ui.Colorize(ui.ColorYellow, ui.EmojiText("⚠️  OUTDATED\n", "OUTDATED\n")))
 	}
 
+	if reason := r.driftReason(comment); reason != "" {
+		preview.WriteString(ui.Colorize(ui.ColorYellow, ui.EmojiText("⚠ drifted: ", "drifted: ")+reason+"\n"))
+	}
+
 	if r.aiAvailable {
 		preview.WriteString(ui.Colorize(ui.ColorGreen, ui.EmojiText("🤖 AI available\n", "AI available\n")))
 	}
@@ -904,14 +1215,15 @@ func (r *suggestionRenderer) PreviewWithHighlight(comment *github.ReviewComment,
 	// Suggested code (truncated)
 	if comment.HasSuggestion && comment.SuggestedCode != "" && lines < maxLines {
 		preview.WriteString(ui.Colorize(ui.ColorCyan, "\n--- Suggested Code ---\n"))
-		codeLines := strings.Split(comment.SuggestedCode, "\n")
+		highlighted := ui.HighlightCode(comment.SuggestedCode, ui.CodeFenceLanguageFromPath(comment.Path))
+		codeLines := strings.Split(ui.WrapCode(highlighted, ui.TerminalWidth()), "\n")
 		shown := 0
 		for _, line := range codeLines {
 			if lines >= maxLines-2 || shown >= 6 {
 				preview.WriteString(ui.Colorize(ui.ColorGray, "...\n"))
 				break
 			}
-			preview.WriteString(ui.Colorize(ui.ColorGreen, line+"\n"))
+			preview.WriteString(line + "\n")
 			lines++
 			shown++
 		}
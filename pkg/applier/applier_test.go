@@ -0,0 +1,86 @@
+package applier
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/briandowns/spinner"
+	"github.com/chmouel/gh-prreview/pkg/ai"
+)
+
+// fakeProvider returns the queued errors in order, then succeeds
+type fakeProvider struct {
+	errs  []error
+	calls int
+}
+
+func (f *fakeProvider) ApplySuggestion(_ context.Context, _ *ai.SuggestionRequest) (*ai.SuggestionResponse, error) {
+	f.calls++
+	if f.calls <= len(f.errs) {
+		return nil, f.errs[f.calls-1]
+	}
+	return &ai.SuggestionResponse{Explanation: "done"}, nil
+}
+
+func (f *fakeProvider) Name() string  { return "fake" }
+func (f *fakeProvider) Model() string { return "fake-model" }
+
+func TestRequestAISuggestionRetries(t *testing.T) {
+	original := aiRetryBaseDelay
+	aiRetryBaseDelay = 0
+	t.Cleanup(func() { aiRetryBaseDelay = original })
+
+	rateLimited := &ai.HTTPError{StatusCode: 429}
+	unauthorized := &ai.HTTPError{StatusCode: 401}
+
+	tests := []struct {
+		name      string
+		errs      []error
+		wantCalls int
+		wantErr   error
+	}{
+		{"succeeds first time", nil, 1, nil},
+		{"recovers after transient failures", []error{rateLimited, rateLimited}, 3, nil},
+		{"gives up after max attempts", []error{rateLimited, rateLimited, rateLimited}, aiMaxAttempts, rateLimited},
+		{"fails fast on non-retryable error", []error{unauthorized}, 1, unauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &fakeProvider{errs: tt.errs}
+			a := New()
+			a.SetAIProvider(provider)
+			s := spinner.New(spinner.CharSets[11], time.Second)
+			s.Suffix = " Analyzing"
+
+			resp, err := a.requestAISuggestion(context.Background(), &ai.SuggestionRequest{}, s)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("requestAISuggestion() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (resp == nil || resp.Explanation != "done") {
+				t.Errorf("requestAISuggestion() response = %+v, want success", resp)
+			}
+			if provider.calls != tt.wantCalls {
+				t.Errorf("provider called %d time(s), want %d", provider.calls, tt.wantCalls)
+			}
+			if len(tt.errs) > 1 && s.Suffix != " Analyzing retrying 3/3..." {
+				t.Errorf("spinner suffix = %q, want retry count", s.Suffix)
+			}
+		})
+	}
+}
+
+func TestAIRetryDelay(t *testing.T) {
+	original := aiRetryBaseDelay
+	aiRetryBaseDelay = time.Second
+	t.Cleanup(func() { aiRetryBaseDelay = original })
+
+	for attempt, base := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second} {
+		delay := aiRetryDelay(attempt)
+		if delay < base || delay > base+base/2 {
+			t.Errorf("aiRetryDelay(%d) = %s, want between %s and %s", attempt, delay, base, base+base/2)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package applier
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/chmouel/gh-prreview/pkg/github"
+	"github.com/chmouel/gh-prreview/pkg/ui"
+)
+
+// CommitMode is how applied suggestions are committed
+type CommitMode int
+
+const (
+	// CommitNone leaves the changes in the working tree
+	CommitNone CommitMode = iota
+	// CommitEach makes one commit per applied suggestion
+	CommitEach
+	// CommitSquash makes a single commit at the end of the run
+	CommitSquash
+)
+
+// SetCommit sets how applied suggestions are committed
+func (a *Applier) SetCommit(mode CommitMode) {
+	a.commit = mode
+}
+
+// CommitMessage is the message of the commit of an applied suggestion,
+// referencing the review comment it comes from
+func CommitMessage(comment *github.ReviewComment) string {
+	message := fmt.Sprintf("Apply review suggestion from @%s (comment %d)", comment.Author, comment.ID)
+	if comment.HTMLURL != "" {
+		message += "\n\n" + comment.HTMLURL
+	}
+	return message
+}
+
+// SquashCommitMessage is the message of the single commit of --commit-squash,
+// listing every applied suggestion
+func SquashCommitMessage(comments []*github.ReviewComment) string {
+	if len(comments) == 1 {
+		return CommitMessage(comments[0])
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Apply %d review suggestions\n\n", len(comments))
+	for _, comment := range comments {
+		fmt.Fprintf(&b, "- @%s (comment %d)", comment.Author, comment.ID)
+		if comment.HTMLURL != "" {
+			fmt.Fprintf(&b, " %s", comment.HTMLURL)
+		}
+		b.WriteByte('\n')
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// commitApplied commits the file of an applied suggestion, or keeps the
+// suggestion for the final commit in CommitSquash mode
+func (a *Applier) commitApplied(comment *github.ReviewComment) {
+	switch a.commit {
+	case CommitEach:
+		a.commitFiles([]string{comment.Path}, CommitMessage(comment))
+	case CommitSquash:
+		a.squashed = append(a.squashed, comment)
+	}
+}
+
+// commitSquashed makes the single commit of CommitSquash mode, once all
+// suggestions of the run are processed
+func (a *Applier) commitSquashed() {
+	if a.commit != CommitSquash || len(a.squashed) == 0 {
+		return
+	}
+
+	var paths []string
+	for _, comment := range a.squashed {
+		if !slices.Contains(paths, comment.Path) {
+			paths = append(paths, comment.Path)
+		}
+	}
+	a.commitFiles(paths, SquashCommitMessage(a.squashed))
+	a.squashed = nil
+}
+
+// commitFiles stages paths and commits them, and only them: changes staged
+// before the run are left out of the commit
+func (a *Applier) commitFiles(paths []string, message string) {
+	if output, err := a.git(append([]string{"add", "--"}, paths...)...); err != nil {
+		fmt.Printf("%sFailed to stage %s: %v %s\n", ui.EmojiText("❌ ", "FAIL: "),
+			strings.Join(paths, ", "), err, strings.TrimSpace(string(output)))
+		return
+	}
+
+	args := append([]string{"commit", "--quiet", "-m", message, "--"}, paths...)
+	if output, err := a.git(args...); err != nil {
+		fmt.Printf("%sFailed to commit %s: %v %s\n", ui.EmojiText("❌ ", "FAIL: "),
+			strings.Join(paths, ", "), err, strings.TrimSpace(string(output)))
+		return
+	}
+
+	subject, _, _ := strings.Cut(message, "\n")
+	fmt.Printf("%sCommitted: %s\n", ui.EmojiText("📝 ", ""), subject)
+}
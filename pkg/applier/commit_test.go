@@ -0,0 +1,103 @@
+package applier
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/chmouel/gh-prreview/pkg/github"
+)
+
+func TestCommitMessage(t *testing.T) {
+	comment := &github.ReviewComment{
+		ID:      123,
+		Author:  "alice",
+		HTMLURL: "https://github.com/owner/repo/pull/7#discussion_r123",
+	}
+	want := "Apply review suggestion from @alice (comment 123)\n\nhttps://github.com/owner/repo/pull/7#discussion_r123"
+	if got := CommitMessage(comment); got != want {
+		t.Errorf("CommitMessage() = %q, want %q", got, want)
+	}
+
+	comment.HTMLURL = ""
+	if got := CommitMessage(comment); got != "Apply review suggestion from @alice (comment 123)" {
+		t.Errorf("CommitMessage() without URL = %q", got)
+	}
+}
+
+func TestSquashCommitMessage(t *testing.T) {
+	comments := []*github.ReviewComment{
+		{ID: 1, Author: "alice", HTMLURL: "https://example.com/1"},
+		{ID: 2, Author: "Copilot"},
+	}
+	want := "Apply 2 review suggestions\n\n- @alice (comment 1) https://example.com/1\n- @Copilot (comment 2)"
+	if got := SquashCommitMessage(comments); got != want {
+		t.Errorf("SquashCommitMessage() = %q, want %q", got, want)
+	}
+
+	if got, want := SquashCommitMessage(comments[:1]), CommitMessage(comments[0]); got != want {
+		t.Errorf("SquashCommitMessage() of one suggestion = %q, want %q", got, want)
+	}
+}
+
+func TestCommitEach(t *testing.T) {
+	calls := fakeGit(t, nil, nil)
+	comment := &github.ReviewComment{ID: 1, Author: "alice", Path: "main.go"}
+
+	a := New()
+	a.SetCommit(CommitEach)
+	a.commitApplied(comment)
+	a.commitSquashed()
+
+	want := [][]string{
+		{"add", "--", "main.go"},
+		{"commit", "--quiet", "-m", CommitMessage(comment), "--", "main.go"},
+	}
+	if !reflect.DeepEqual(*calls, want) {
+		t.Errorf("git calls = %v, want %v", *calls, want)
+	}
+}
+
+func TestCommitSquash(t *testing.T) {
+	calls := fakeGit(t, nil, nil)
+	comments := []*github.ReviewComment{
+		{ID: 1, Author: "alice", Path: "main.go"},
+		{ID: 2, Author: "bob", Path: "util.go"},
+		{ID: 3, Author: "alice", Path: "main.go"},
+	}
+
+	a := New()
+	a.SetCommit(CommitSquash)
+	for _, comment := range comments {
+		a.commitApplied(comment)
+	}
+	if len(*calls) != 0 {
+		t.Fatalf("git ran before the end of the run: %v", *calls)
+	}
+	a.commitSquashed()
+
+	want := [][]string{
+		{"add", "--", "main.go", "util.go"},
+		{"commit", "--quiet", "-m", SquashCommitMessage(comments), "--", "main.go", "util.go"},
+	}
+	if !reflect.DeepEqual(*calls, want) {
+		t.Errorf("git calls = %v, want %v", *calls, want)
+	}
+
+	// Nothing is left for a second commit
+	a.commitSquashed()
+	if len(*calls) != 2 {
+		t.Errorf("second commitSquashed() ran git: %v", (*calls)[2:])
+	}
+}
+
+func TestCommitNone(t *testing.T) {
+	calls := fakeGit(t, nil, nil)
+
+	a := New()
+	a.commitApplied(&github.ReviewComment{ID: 1, Path: "main.go"})
+	a.commitSquashed()
+
+	if len(*calls) != 0 {
+		t.Errorf("git calls = %v, want none without a commit mode", *calls)
+	}
+}
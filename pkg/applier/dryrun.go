@@ -0,0 +1,51 @@
+package applier
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/chmouel/gh-prreview/pkg/github"
+	"github.com/chmouel/gh-prreview/pkg/ui"
+)
+
+// DryRun reports what ApplyAll would do with suggestions, and the commits it
+// would make in a commit mode, without writing files or running git. Each
+// suggestion is checked against the file on disk, so suggestions that only
+// apply after an earlier one of the run may be reported as failing.
+func (a *Applier) DryRun(suggestions []*github.ReviewComment) {
+	var applicable []*github.ReviewComment
+	for _, suggestion := range suggestions {
+		label := suggestion.LocationLabel()
+		content, err := os.ReadFile(a.path(suggestion.Path))
+		if err == nil {
+			_, err = a.editContent(suggestion, string(content))
+		}
+		switch {
+		case errors.Is(err, ErrAlreadyApplied):
+			fmt.Printf("%sAlready applied: %s\n", ui.EmojiText("⏭️  ", "SKIP: "), label)
+		case err != nil:
+			fmt.Printf("%sWould fail: %s: %v\n", ui.EmojiText("❌ ", "FAIL: "), label, err)
+		default:
+			fmt.Printf("%sWould apply suggestion to %s\n", ui.EmojiText("✅ ", "OK: "), label)
+			applicable = append(applicable, suggestion)
+			if a.commit == CommitEach {
+				printCommitMessage(CommitMessage(suggestion))
+			}
+		}
+	}
+
+	if a.commit == CommitSquash && len(applicable) > 0 {
+		fmt.Println()
+		printCommitMessage(SquashCommitMessage(applicable))
+	}
+}
+
+// printCommitMessage shows a commit that a dry run would make
+func printCommitMessage(message string) {
+	fmt.Printf("%sWould commit:\n", ui.EmojiText("📝 ", ""))
+	for _, line := range strings.Split(message, "\n") {
+		fmt.Printf("    %s\n", line)
+	}
+}
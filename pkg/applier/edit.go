@@ -0,0 +1,68 @@
+package applier
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/chmouel/gh-prreview/pkg/github"
+	"github.com/chmouel/gh-prreview/pkg/ui"
+)
+
+// launchEditor opens a file in the user's editor; a variable so tests can
+// replace the editor
+var launchEditor = ui.LaunchEditor
+
+// editSuggestedCode opens the suggested code of comment in the user's editor
+// and returns it as edited. The temporary file has the extension of the
+// commented file, so the editor highlights the code.
+func editSuggestedCode(comment *github.ReviewComment) (string, error) {
+	tmpFile, err := os.CreateTemp("", "gh-prreview-suggestion-*"+filepath.Ext(comment.Path))
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer func() {
+		_ = os.Remove(tmpFile.Name())
+	}()
+
+	code := comment.SuggestedCode
+	if code != "" && !strings.HasSuffix(code, "\n") {
+		code += "\n"
+	}
+	if _, err := tmpFile.WriteString(code); err != nil {
+		_ = tmpFile.Close()
+		return "", fmt.Errorf("failed to write suggestion: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return "", fmt.Errorf("failed to close temporary file: %w", err)
+	}
+
+	if err := launchEditor(tmpFile.Name(), 1); err != nil {
+		return "", err
+	}
+
+	edited, err := os.ReadFile(tmpFile.Name())
+	if err != nil {
+		return "", fmt.Errorf("failed to read edited suggestion: %w", err)
+	}
+	return string(edited), nil
+}
+
+// applyEditedSuggestion lets the user edit the suggested code, then applies
+// the edited code in place of the suggestion, to the same lines. Emptying the
+// code deletes the lines, as an empty suggestion does on GitHub.
+func (a *Applier) applyEditedSuggestion(comment *github.ReviewComment) error {
+	code, err := editSuggestedCode(comment)
+	if err != nil {
+		return err
+	}
+
+	suggested, deletion := comment.SuggestedCode, comment.IsDeletion
+	defer func() {
+		comment.SuggestedCode, comment.IsDeletion = suggested, deletion
+	}()
+	comment.SuggestedCode = code
+	comment.IsDeletion = strings.TrimSpace(code) == ""
+	return a.applySuggestion(comment)
+}
@@ -0,0 +1,81 @@
+package applier
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chmouel/gh-prreview/pkg/github"
+)
+
+// fakeEditor replaces the editor with one that checks the file it is given
+// and replaces its content with edited
+func fakeEditor(t *testing.T, wantContent, edited string) {
+	t.Helper()
+	original := launchEditor
+	launchEditor = func(path string, line int) error {
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("reading editor file: %v", err)
+		}
+		if string(got) != wantContent {
+			t.Errorf("editor opened with %q, want %q", got, wantContent)
+		}
+		if filepath.Ext(path) != ".go" {
+			t.Errorf("editor file %s does not keep the .go extension", path)
+		}
+		return os.WriteFile(path, []byte(edited), 0o644)
+	}
+	t.Cleanup(func() { launchEditor = original })
+}
+
+func TestApplyEditedSuggestion(t *testing.T) {
+	tests := []struct {
+		name   string
+		edited string
+		want   string
+	}{
+		{
+			name:   "edited code replaces the suggestion",
+			edited: "\tconst retries = 5\n",
+			want:   "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tconst retries = 5\n\tfmt.Println(retries)\n}\n",
+		},
+		{
+			name:   "emptied code deletes the lines",
+			edited: "",
+			want:   "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(retries)\n}\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			if err := os.WriteFile("main.go", []byte(patchTestFile), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			fakeEditor(t, "\tconst retries = 3\n", tt.edited)
+
+			comment := &github.ReviewComment{
+				ID:            1,
+				Path:          "main.go",
+				DiffHunk:      "@@ -5,2 +5,3 @@\n func main() {\n+\tretries := 3",
+				HasSuggestion: true,
+				SuggestedCode: "\tconst retries = 3",
+			}
+			if err := New().applyEditedSuggestion(comment); err != nil {
+				t.Fatalf("applyEditedSuggestion() error = %v", err)
+			}
+
+			got, err := os.ReadFile("main.go")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("file = %q, want %q", got, tt.want)
+			}
+			if comment.SuggestedCode != "\tconst retries = 3" || comment.IsDeletion {
+				t.Errorf("comment not restored: SuggestedCode = %q, IsDeletion = %v", comment.SuggestedCode, comment.IsDeletion)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package applier
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/chmouel/gh-prreview/pkg/ui"
+)
+
+// formatCommand runs a formatter in dir, so that it finds the configuration
+// of that checkout, and returns its combined output. It is a variable so
+// tests can replace the formatters.
+var formatCommand = func(dir, name string, args ...string) ([]byte, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	return cmd.CombinedOutput()
+}
+
+// SetFormatter configures how the formatter run on each file after a
+// suggestion is applied to it is chosen: formatter returns the command for a
+// path, which is appended to it, or false to leave the file alone. A nil
+// formatter turns formatting off.
+func (a *Applier) SetFormatter(formatter func(path string) ([]string, bool)) {
+	a.formatter = formatter
+}
+
+// formatFile runs the configured formatter on path and shows the diff once it
+// changed the file. A failing formatter is only reported: the applied
+// suggestion stays in place.
+func (a *Applier) formatFile(path string) {
+	if a.formatter == nil {
+		return
+	}
+	args, ok := a.formatter(path)
+	if !ok {
+		a.debugLog("No formatter for %s", path)
+		return
+	}
+	command := strings.Join(args, " ")
+
+	before, err := os.ReadFile(a.path(path))
+	if err != nil {
+		a.debugLog("Not formatting %s: %v", path, err)
+		return
+	}
+	if output, err := formatCommand(a.workDir, args[0], append(args[1:], a.path(path))...); err != nil {
+		fmt.Printf("%sFailed to format %s with %s, keeping the suggestion as applied: %v %s\n",
+			ui.EmojiText("⚠️  ", "Warning: "), path, command, err, strings.TrimSpace(string(output)))
+		return
+	}
+
+	after, err := os.ReadFile(a.path(path))
+	if err != nil || bytes.Equal(before, after) {
+		a.debugLog("%s left %s unchanged", command, path)
+		return
+	}
+	fmt.Printf("%sFormatted %s with %s\n", ui.EmojiText("🧹 ", ""), path, command)
+	a.showGitDiffTitled(path, "Changes after formatting:")
+}
@@ -0,0 +1,116 @@
+package applier
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fakeFormatter replaces formatCommand with one upper-casing the file it is
+// given, or failing with err, and records the commands run
+func fakeFormatter(t *testing.T, err error) *[][]string {
+	t.Helper()
+	var calls [][]string
+	original := formatCommand
+	formatCommand = func(_, name string, args ...string) ([]byte, error) {
+		calls = append(calls, append([]string{name}, args...))
+		if err != nil {
+			return []byte("syntax error"), err
+		}
+		path := args[len(args)-1]
+		data, readErr := os.ReadFile(path)
+		if readErr != nil {
+			return nil, readErr
+		}
+		return nil, os.WriteFile(path, []byte(strings.ToUpper(string(data))), 0o644)
+	}
+	t.Cleanup(func() { formatCommand = original })
+	return &calls
+}
+
+func goFormatter(path string) ([]string, bool) {
+	if !strings.HasSuffix(path, ".go") {
+		return nil, false
+	}
+	return []string{"gofmt", "-w"}, true
+}
+
+func TestFormatFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+	for _, name := range []string{"main.go", "notes.txt"} {
+		if err := os.WriteFile(name, []byte("x := 1\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	calls := fakeFormatter(t, nil)
+
+	a := New()
+	a.SetFormatter(goFormatter)
+	a.formatFile("main.go")
+	a.formatFile("notes.txt")
+
+	if want := [][]string{{"gofmt", "-w", "main.go"}}; !reflect.DeepEqual(*calls, want) {
+		t.Errorf("formatter calls = %v, want %v", *calls, want)
+	}
+	if got, _ := os.ReadFile("main.go"); string(got) != "X := 1\n" {
+		t.Errorf("main.go = %q, want it formatted", got)
+	}
+	if got, _ := os.ReadFile("notes.txt"); string(got) != "x := 1\n" {
+		t.Errorf("notes.txt = %q, want it untouched", got)
+	}
+}
+
+func TestFormatFileFailureKeepsSuggestion(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.WriteFile("main.go", []byte("x := 1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	fakeFormatter(t, errors.New("exit status 2"))
+
+	a := New()
+	a.SetFormatter(goFormatter)
+	out := captureStdout(t, func() { a.formatFile("main.go") })
+
+	if !strings.Contains(out, "Failed to format main.go with gofmt -w") {
+		t.Errorf("output = %q, want a warning about the formatter", out)
+	}
+	if got, _ := os.ReadFile("main.go"); string(got) != "x := 1\n" {
+		t.Errorf("main.go = %q, want the applied content kept", got)
+	}
+}
+
+func TestFormatFileInWorkDir(t *testing.T) {
+	workDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(workDir, "main.go"), []byte("x := 1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var ranIn string
+	original := formatCommand
+	formatCommand = func(dir, _ string, _ ...string) ([]byte, error) {
+		ranIn = dir
+		return nil, nil
+	}
+	t.Cleanup(func() { formatCommand = original })
+
+	a := New()
+	a.SetWorkDir(workDir)
+	a.SetFormatter(goFormatter)
+	a.formatFile("main.go")
+
+	if ranIn != workDir {
+		t.Errorf("formatter ran in %q, want the work dir %q", ranIn, workDir)
+	}
+}
+
+func TestFormatFileDisabled(t *testing.T) {
+	calls := fakeFormatter(t, nil)
+
+	New().formatFile("main.go")
+
+	if len(*calls) != 0 {
+		t.Errorf("formatFile without a formatter ran %v", *calls)
+	}
+}
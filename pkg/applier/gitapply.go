@@ -0,0 +1,63 @@
+package applier
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/chmouel/gh-prreview/pkg/ui"
+)
+
+// patchStrategy is one way of running git apply on an AI-generated patch
+type patchStrategy struct {
+	name string
+	args []string
+}
+
+// patchStrategies are tried in order until one applies. AI patches often get
+// whitespace wrong, in the added lines or in the context they quote. A
+// three-way merge is not attempted: the patches carry no blob IDs, so git has
+// no base to merge against and would only repeat the plain application.
+var patchStrategies = []patchStrategy{
+	{"exact", []string{"--unidiff-zero"}},
+	{"whitespace fix", []string{"--unidiff-zero", "--whitespace=fix"}},
+	{"ignoring whitespace", []string{"--unidiff-zero", "--whitespace=fix", "--ignore-whitespace"}},
+}
+
+// applyPatchFallback runs git apply on patch with each of patchStrategies in
+// turn and returns the name of the one that worked. When all fail, the error
+// carries the output of the first, exact, attempt.
+func (a *Applier) applyPatchFallback(patch string) (string, error) {
+	tmpFile, err := os.CreateTemp("", "gh-prreview-patch-*.patch")
+	if err != nil {
+		return "", fmt.Errorf("failed to create patch file: %w", err)
+	}
+	defer func() {
+		_ = os.Remove(tmpFile.Name())
+	}()
+	if _, err := tmpFile.WriteString(patch); err != nil {
+		_ = tmpFile.Close()
+		return "", fmt.Errorf("failed to write patch file: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return "", fmt.Errorf("failed to close patch file: %w", err)
+	}
+
+	var firstErr error
+	for i, strategy := range patchStrategies {
+		args := append(append([]string{"apply"}, strategy.args...), tmpFile.Name())
+		output, err := a.git(args...)
+		if err == nil {
+			if i > 0 {
+				fmt.Printf("%sPatch applied with git apply %s (%s)\n",
+					ui.EmojiText("🔧 ", ""), strings.Join(strategy.args, " "), strategy.name)
+			}
+			return strategy.name, nil
+		}
+		a.debugLog("git apply (%s) failed: %v %s", strategy.name, err, strings.TrimSpace(string(output)))
+		if firstErr == nil {
+			firstErr = fmt.Errorf("%w\nOutput: %s", err, string(output))
+		}
+	}
+	return "", firstErr
+}
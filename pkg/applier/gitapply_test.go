@@ -0,0 +1,72 @@
+package applier
+
+import (
+	"errors"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// fakeGitApply replaces gitCommand with a git apply that succeeds once the
+// given flag is passed, checking the patch file it is handed
+func fakeGitApply(t *testing.T, succeedWith string) *[][]string {
+	t.Helper()
+	var calls [][]string
+	original := gitCommand
+	gitCommand = func(args ...string) ([]byte, error) {
+		calls = append(calls, args)
+		patch, err := os.ReadFile(args[len(args)-1])
+		if err != nil || string(patch) != "the patch" {
+			t.Errorf("git apply got patch %q, %v", patch, err)
+		}
+		if succeedWith != "" && slices.Contains(args, succeedWith) {
+			return nil, nil
+		}
+		return []byte("error: patch does not apply"), errors.New("exit status 1")
+	}
+	t.Cleanup(func() { gitCommand = original })
+	return &calls
+}
+
+func TestApplyPatchFallback(t *testing.T) {
+	tests := []struct {
+		name         string
+		succeedWith  string
+		wantStrategy string
+		wantCalls    int
+	}{
+		{"exact", "--unidiff-zero", "exact", 1},
+		{"whitespace fix", "--whitespace=fix", "whitespace fix", 2},
+		{"ignoring whitespace", "--ignore-whitespace", "ignoring whitespace", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := fakeGitApply(t, tt.succeedWith)
+
+			got, err := New().applyPatchFallback("the patch")
+			if err != nil {
+				t.Fatalf("applyPatchFallback() error = %v", err)
+			}
+			if got != tt.wantStrategy {
+				t.Errorf("applyPatchFallback() = %q, want %q", got, tt.wantStrategy)
+			}
+			if len(*calls) != tt.wantCalls {
+				t.Errorf("git was run %d times, want %d", len(*calls), tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestApplyPatchFallbackAllFail(t *testing.T) {
+	calls := fakeGitApply(t, "")
+
+	_, err := New().applyPatchFallback("the patch")
+	if err == nil || !strings.Contains(err.Error(), "patch does not apply") {
+		t.Errorf("applyPatchFallback() error = %v, want the git apply output", err)
+	}
+	if len(*calls) != len(patchStrategies) {
+		t.Errorf("git was run %d times, want every strategy tried", len(*calls))
+	}
+}
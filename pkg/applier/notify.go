@@ -0,0 +1,56 @@
+package applier
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+)
+
+// notifyTitle is the title of the desktop notification sent at the end of a
+// batch
+const notifyTitle = "gh prreview"
+
+// SetNotify enables the bell and desktop notification at the end of
+// ApplyAllWithAI, for long batches left to run unattended
+func (a *Applier) SetNotify(notify bool) {
+	a.notify = notify
+}
+
+// notifyCommands lists the commands that can show a desktop notification on
+// goos, in order of preference
+func notifyCommands(goos, title, body string) [][]string {
+	if goos == "darwin" {
+		return [][]string{{"terminal-notifier", "-title", title, "-message", body}}
+	}
+	return [][]string{{"notify-send", title, body}}
+}
+
+// notificationBody summarizes a run for the notification
+func notificationBody(summary *Summary) string {
+	return fmt.Sprintf("AI apply finished: %d applied, %d failed of %d suggestion(s)",
+		summary.Applied, summary.Failed, summary.Total)
+}
+
+// notifyFinished rings the terminal bell and sends a desktop notification
+// with the counts of the run, when enabled. The bell goes to stderr, which
+// stays on the terminal when the summary is printed as JSON.
+func (a *Applier) notifyFinished(summary *Summary) {
+	if !a.notify {
+		return
+	}
+	fmt.Fprint(os.Stderr, "\a")
+
+	body := notificationBody(summary)
+	for _, command := range notifyCommands(runtime.GOOS, notifyTitle, body) {
+		path, err := exec.LookPath(command[0])
+		if err != nil {
+			continue
+		}
+		if output, err := exec.Command(path, command[1:]...).CombinedOutput(); err != nil {
+			a.debugLog("%s failed: %v: %s", command[0], err, output)
+		}
+		return
+	}
+	a.debugLog("No notification tool found, only rang the bell")
+}
@@ -0,0 +1,25 @@
+package applier
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNotifyCommands(t *testing.T) {
+	darwin := notifyCommands("darwin", "title", "body")
+	if len(darwin) != 1 || !slices.Equal(darwin[0], []string{"terminal-notifier", "-title", "title", "-message", "body"}) {
+		t.Errorf("notifyCommands(darwin) = %v, want terminal-notifier", darwin)
+	}
+	linux := notifyCommands("linux", "title", "body")
+	if len(linux) != 1 || !slices.Equal(linux[0], []string{"notify-send", "title", "body"}) {
+		t.Errorf("notifyCommands(linux) = %v, want notify-send", linux)
+	}
+}
+
+func TestNotificationBody(t *testing.T) {
+	summary := &Summary{Total: 5, Applied: 3, Failed: 1, Skipped: 1}
+	want := "AI apply finished: 3 applied, 1 failed of 5 suggestion(s)"
+	if got := notificationBody(summary); got != want {
+		t.Errorf("notificationBody() = %q, want %q", got, want)
+	}
+}
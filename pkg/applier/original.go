@@ -0,0 +1,56 @@
+package applier
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/chmouel/gh-prreview/pkg/github"
+	"github.com/chmouel/gh-prreview/pkg/ui"
+)
+
+// shortCommit abbreviates a commit ID the way git log --oneline does
+func shortCommit(commit string) string {
+	if len(commit) > 7 {
+		return commit[:7]
+	}
+	return commit
+}
+
+// reviewedElsewhere reports whether the local HEAD is not the commit the
+// comment was made against, e.g. because the PR was force-pushed since
+func (a *Applier) reviewedElsewhere(comment *github.ReviewComment) bool {
+	if comment.OriginalCommitID == "" {
+		return false
+	}
+	head, err := a.git("rev-parse", "HEAD")
+	if err != nil {
+		a.debugLog("Could not resolve HEAD: %v", err)
+		return false
+	}
+	return strings.TrimSpace(string(head)) != comment.OriginalCommitID
+}
+
+// showAgainstOriginal prints the suggestion as a patch against the file as it
+// was in the commit the comment was made on
+func (a *Applier) showAgainstOriginal(comment *github.ReviewComment) {
+	commit := shortCommit(comment.OriginalCommitID)
+	content, err := a.git("show", comment.OriginalCommitID+":"+comment.Path)
+	if err != nil {
+		fmt.Printf("%sCould not read %s at %s (try git fetch): %s\n",
+			ui.EmojiText("❌ ", "FAIL: "), comment.Path, commit, strings.TrimSpace(string(content)))
+		return
+	}
+
+	patch, err := BuildPatch(comment, content)
+	if err != nil {
+		fmt.Printf("%sCould not place the suggestion in %s at %s: %v\n", ui.EmojiText("❌ ", "FAIL: "), comment.Path, commit, err)
+		return
+	}
+
+	fmt.Printf("\n%s\n", ui.Colorize(ui.ColorCyan, fmt.Sprintf("Suggestion against %s at %s (the reviewed commit):", comment.Path, commit)))
+	if patch == "" {
+		fmt.Println(ui.Colorize(ui.ColorGray, "(already in place at that commit)"))
+		return
+	}
+	fmt.Println(a.colorizeDiff(patch))
+}
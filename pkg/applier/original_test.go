@@ -0,0 +1,38 @@
+package applier
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/chmouel/gh-prreview/pkg/github"
+)
+
+func TestReviewedElsewhere(t *testing.T) {
+	tests := []struct {
+		name    string
+		commit  string
+		head    string
+		headErr error
+		want    bool
+	}{
+		{name: "same commit", commit: "abc123", head: "abc123\n", want: false},
+		{name: "force-pushed", commit: "abc123", head: "def456\n", want: true},
+		{name: "no commit recorded", commit: "", head: "def456\n", want: false},
+		{name: "not a git repository", commit: "abc123", headErr: errors.New("not a git repository"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := gitCommand
+			gitCommand = func(args ...string) ([]byte, error) {
+				return []byte(tt.head), tt.headErr
+			}
+			t.Cleanup(func() { gitCommand = original })
+
+			comment := &github.ReviewComment{OriginalCommitID: tt.commit}
+			if got := New().reviewedElsewhere(comment); got != tt.want {
+				t.Errorf("reviewedElsewhere() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
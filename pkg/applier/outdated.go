@@ -0,0 +1,43 @@
+package applier
+
+import (
+	"errors"
+	"os"
+
+	"github.com/chmouel/gh-prreview/pkg/github"
+)
+
+// RecheckOutdated recomputes the IsOutdated flag of suggestions from the
+// working tree in workDir (the current directory when empty) instead of
+// GitHub's diff, which goes stale after a local rebase. A suggestion is
+// current when the code it replaces is still in the local file, where the
+// diff hunk puts it or moved elsewhere, or when the suggestion is already in
+// place. It returns the suggestions whose flag changed.
+func RecheckOutdated(suggestions []*github.ReviewComment, workDir string) []*github.ReviewComment {
+	a := New()
+	a.SetWorkDir(workDir)
+	var changed []*github.ReviewComment
+	for _, suggestion := range suggestions {
+		if !suggestion.HasSuggestion {
+			continue
+		}
+		outdated := !a.inWorkingTree(suggestion)
+		if outdated != suggestion.IsOutdated {
+			suggestion.IsOutdated = outdated
+			changed = append(changed, suggestion)
+		}
+	}
+	return changed
+}
+
+// inWorkingTree reports whether the code a suggestion replaces, or the
+// suggested code, is found in the local file by the matching apply uses
+func (a *Applier) inWorkingTree(comment *github.ReviewComment) bool {
+	content, err := os.ReadFile(a.path(comment.Path))
+	if err != nil {
+		return false
+	}
+	_, err = a.editContent(comment, string(content))
+	var ambiguous *AmbiguousMatchError
+	return err == nil || errors.Is(err, ErrAlreadyApplied) || errors.As(err, &ambiguous)
+}
@@ -0,0 +1,44 @@
+package applier
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/chmouel/gh-prreview/pkg/github"
+)
+
+func TestRecheckOutdated(t *testing.T) {
+	// The checkout is in another directory, like a git worktree
+	workDir := t.TempDir()
+	// The file after a rebase moved main() two lines down
+	rebased := "package main\n\nimport \"fmt\"\n\n// main prints\n// the retries\nfunc main() {\n\tretries := 3\n\tfmt.Println(retries)\n}\n"
+	if err := os.WriteFile(filepath.Join(workDir, "main.go"), []byte(rebased), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	hunk := "@@ -5,2 +5,3 @@\n func main() {\n+\tretries := 3"
+	moved := &github.ReviewComment{ID: 1, Path: "main.go", DiffHunk: hunk, HasSuggestion: true, SuggestedCode: "\tconst retries = 3", IsOutdated: true}
+	current := &github.ReviewComment{ID: 2, Path: "main.go", DiffHunk: hunk, HasSuggestion: true, SuggestedCode: "\tconst retries = 3"}
+	drifted := &github.ReviewComment{ID: 3, Path: "main.go", DiffHunk: "@@ -5,2 +5,3 @@\n func main() {\n+\tretries := 5", HasSuggestion: true, SuggestedCode: "\tconst retries = 5"}
+	gone := &github.ReviewComment{ID: 4, Path: "gone.go", DiffHunk: hunk, HasSuggestion: true, SuggestedCode: "x"}
+	discussion := &github.ReviewComment{ID: 5, Path: "gone.go", DiffHunk: hunk}
+
+	changed := RecheckOutdated([]*github.ReviewComment{moved, current, drifted, gone, discussion}, workDir)
+
+	var changedIDs []int64
+	for _, comment := range changed {
+		changedIDs = append(changedIDs, comment.ID)
+	}
+	if len(changedIDs) != 3 || changedIDs[0] != 1 || changedIDs[1] != 3 || changedIDs[2] != 4 {
+		t.Errorf("RecheckOutdated() changed %v, want [1 3 4]", changedIDs)
+	}
+	for _, tt := range []struct {
+		comment *github.ReviewComment
+		want    bool
+	}{{moved, false}, {current, false}, {drifted, true}, {gone, true}, {discussion, false}} {
+		if tt.comment.IsOutdated != tt.want {
+			t.Errorf("comment %d IsOutdated = %v, want %v", tt.comment.ID, tt.comment.IsOutdated, tt.want)
+		}
+	}
+}
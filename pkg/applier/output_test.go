@@ -0,0 +1,71 @@
+package applier
+
+import (
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/chmouel/gh-prreview/pkg/github"
+	"github.com/chmouel/gh-prreview/pkg/ui"
+)
+
+// captureStdout returns what fn prints on stdout
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	original := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = original }()
+
+	done := make(chan string)
+	go func() {
+		out, _ := io.ReadAll(r)
+		done <- string(out)
+	}()
+	fn()
+	_ = w.Close()
+	return <-done
+}
+
+func TestApplyAllOutputWithoutColors(t *testing.T) {
+	ui.SetColorEnabled(false)
+	t.Cleanup(func() { ui.SetColorEnabled(true) })
+	t.Chdir(t.TempDir())
+	if err := os.WriteFile("main.go", []byte(patchTestFile), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// Staging fails so that the failure path of stageFile prints too
+	fakeGit(t, map[string]bool{"main.go": true}, errors.New("index.lock exists"))
+
+	suggestions := []*github.ReviewComment{
+		{ID: 1, Path: "main.go", DiffHunk: "@@ -5,2 +5,3 @@\n func main() {\n+\tretries := 3", SuggestedCode: "\tconst retries = 3\n"},
+		{ID: 2, Path: "main.go", DiffHunk: "@@ -5,2 +5,3 @@\n func main() {\n+\tretries := 5", SuggestedCode: "\tconst retries = 5\n"},
+		{ID: 3, Path: "main.go", DiffHunk: "@@ -1,1 +1,1 @@\n+package main", SuggestedCode: "package main\n"},
+	}
+
+	a := New()
+	a.SetStage(true)
+	out := captureStdout(t, func() {
+		if _, err := a.ApplyAll(suggestions); err != nil {
+			t.Errorf("ApplyAll() error = %v", err)
+		}
+		a.DryRun(suggestions)
+	})
+
+	for _, want := range []string{"OK: Applied", "FAIL: Failed to apply", "FAIL: Failed to stage"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output does not contain %q:\n%s", want, out)
+		}
+	}
+	for _, r := range out {
+		if r > unicode.MaxASCII {
+			t.Fatalf("output has non-ASCII %q with colors disabled:\n%s", r, out)
+		}
+	}
+}
@@ -0,0 +1,290 @@
+package applier
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/chmouel/gh-prreview/pkg/diffhunk"
+	"github.com/chmouel/gh-prreview/pkg/github"
+)
+
+// ErrAlreadyApplied is returned when the file already contains the suggested
+// code, typically because an earlier run applied it
+var ErrAlreadyApplied = errors.New("suggestion already applied")
+
+// patchContextLines is the number of unchanged lines around a change in
+// patches built by BuildPatch, as git diff does by default
+const patchContextLines = 3
+
+// inPlaceDistance is how far from its reviewed position suggested code is
+// still taken as applied, to allow for lines added or removed above it since
+const inPlaceDistance = 10
+
+// suggestionEdit describes a suggestion applied to a file's content
+type suggestionEdit struct {
+	content  string   // full file content after the change
+	lines    []string // file lines before the change
+	start    int      // 0-based index of the first replaced line
+	removed  []string // lines replaced by the suggestion
+	added    []string // suggested lines
+	trailing bool     // whether the file ends with a newline
+}
+
+// editContent locates the code a suggestion replaces in fileContent and
+// returns the resulting content, without touching the file
+func (a *Applier) editContent(comment *github.ReviewComment, fileContent string) (*suggestionEdit, error) {
+	fileLines := strings.Split(fileContent, "\n")
+
+	// Find the lines to replace
+	targetLine, removeCount, err := a.findReplacementTarget(comment, fileLines)
+	if err != nil {
+		if suggestionInPlace(comment, fileLines) {
+			return nil, ErrAlreadyApplied
+		}
+		return nil, err
+	}
+
+	a.debugLog("Replacing %d lines starting at line %d with suggested code", removeCount, targetLine+1)
+
+	// Prepare the new lines, none when the suggestion deletes the target.
+	// Trailing newlines of the suggestion are not lines of their own: the
+	// end of the file keeps the final newline state of the original.
+	var suggestionLines []string
+	if !comment.IsDeletion {
+		suggestionLines = strings.Split(strings.TrimRight(comment.SuggestedCode, "\n"), "\n")
+	}
+
+	if slices.Equal(fileLines[targetLine:targetLine+removeCount], suggestionLines) {
+		return nil, ErrAlreadyApplied
+	}
+
+	// Work on the lines without the empty element that follows a final
+	// newline, so that the newline is added back exactly when the original
+	// file had one
+	trailing := strings.HasSuffix(fileContent, "\n")
+	lines := fileLines
+	if trailing {
+		lines = fileLines[:len(fileLines)-1]
+	}
+	removeEnd := min(targetLine+removeCount, len(lines))
+
+	// Construct the new file content
+	newFileLines := make([]string, 0, len(lines)-removeCount+len(suggestionLines))
+	newFileLines = append(newFileLines, lines[:targetLine]...)
+	newFileLines = append(newFileLines, suggestionLines...)
+	newFileLines = append(newFileLines, lines[removeEnd:]...)
+
+	// Note: This assumes \n line endings. For mixed line endings, we might want to detect the file's EOL.
+	newContent := strings.Join(newFileLines, "\n")
+	if trailing && len(newFileLines) > 0 {
+		newContent += "\n"
+	}
+
+	return &suggestionEdit{
+		content:  newContent,
+		lines:    lines,
+		start:    targetLine,
+		removed:  lines[targetLine:removeEnd],
+		added:    suggestionLines,
+		trailing: trailing,
+	}, nil
+}
+
+// BuildPatch returns the unified diff that applying comment's suggestion to
+// fileContent would produce: the same change apply makes, but as a patch
+// usable with `git apply`. Nothing is written. An empty patch means the
+// suggestion is already in place.
+func BuildPatch(comment *github.ReviewComment, fileContent []byte) (string, error) {
+	edit, err := New().editContent(comment, string(fileContent))
+	if errors.Is(err, ErrAlreadyApplied) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return edit.unifiedDiff(comment.Path), nil
+}
+
+// CanApply reports whether comment's suggestion still applies cleanly to the
+// file on disk, in the work dir when one is set, with a short reason when it
+// does not. It runs the same matching as apply without writing anything. A
+// suggestion that is already in place counts as applicable, since apply
+// reports it instead of failing.
+func (a *Applier) CanApply(comment *github.ReviewComment) (bool, string) {
+	if !comment.HasSuggestion {
+		return false, "no suggestion"
+	}
+
+	fileContent, err := os.ReadFile(a.path(comment.Path))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, "file not found"
+		}
+		return false, err.Error()
+	}
+
+	_, err = New().editContent(comment, string(fileContent))
+	var ambiguous *AmbiguousMatchError
+	switch {
+	case errors.As(err, &ambiguous):
+		return false, fmt.Sprintf("code found at %d places", len(ambiguous.Lines))
+	case err != nil && !errors.Is(err, ErrAlreadyApplied):
+		return false, "code changed since the review"
+	}
+	return true, ""
+}
+
+// suggestionInPlace reports whether the suggested code is already in the file
+// where the code it replaces was expected: at the position of the diff hunk,
+// or, if the code moved a little, at the only place near it where it appears.
+// Code found elsewhere in the file, or several times, is not in place.
+func suggestionInPlace(comment *github.ReviewComment, fileLines []string) bool {
+	if comment.IsDeletion {
+		return deletionInPlace(comment, fileLines)
+	}
+	if strings.TrimSpace(comment.SuggestedCode) == "" {
+		return false
+	}
+	suggestion := strings.Split(strings.TrimSuffix(comment.SuggestedCode, "\n"), "\n")
+
+	matchesAt := func(start int) bool {
+		if start < 0 || start+len(suggestion) > len(fileLines) {
+			return false
+		}
+		for i, line := range suggestion {
+			if fileLines[start+i] != line {
+				return false
+			}
+		}
+		return true
+	}
+
+	position := -1
+	if hunk, err := diffhunk.ParseDiffHunk(comment.DiffHunk); err == nil {
+		for _, line := range hunk.Lines {
+			if line.Type == diffhunk.Add {
+				position = diffhunk.GetZeroBased(line.NewLineNumber)
+				break
+			}
+		}
+	}
+	if position < 0 {
+		switch {
+		case comment.StartLine > 0:
+			position = diffhunk.GetZeroBased(comment.StartLine)
+		case comment.Line > 0:
+			position = diffhunk.GetZeroBased(comment.Line)
+		}
+	}
+	if position < 0 {
+		return false
+	}
+	if matchesAt(position) {
+		return true
+	}
+
+	matches := 0
+	for i := position - inPlaceDistance; i <= position+inPlaceDistance; i++ {
+		if matchesAt(i) {
+			matches++
+		}
+	}
+	return matches == 1
+}
+
+// deletionInPlace reports whether the lines a deletion suggestion removes are
+// already gone: the line above them in the diff hunk is found near its
+// reviewed position, only once, and is no longer followed by them
+func deletionInPlace(comment *github.ReviewComment, fileLines []string) bool {
+	hunk, err := diffhunk.ParseDiffHunk(comment.DiffHunk)
+	if err != nil {
+		return false
+	}
+	var above *diffhunk.DiffLine
+	var removed []string
+	for i, line := range hunk.Lines {
+		if line.Type != diffhunk.Add {
+			continue
+		}
+		if removed == nil && i > 0 && hunk.Lines[i-1].Type == diffhunk.Context {
+			above = hunk.Lines[i-1]
+		}
+		removed = append(removed, line.Text)
+	}
+	if above == nil {
+		return false
+	}
+
+	position := diffhunk.GetZeroBased(above.NewLineNumber)
+	found := -1
+	for i := max(position-inPlaceDistance, 0); i <= position+inPlaceDistance && i < len(fileLines); i++ {
+		if fileLines[i] != above.Text {
+			continue
+		}
+		if found >= 0 {
+			return false
+		}
+		found = i
+	}
+	if found < 0 {
+		return false
+	}
+	next := fileLines[found+1 : min(found+1+len(removed), len(fileLines))]
+	return !slices.Equal(next, removed)
+}
+
+// unifiedDiff formats the edit as a single-hunk unified diff of path
+func (e *suggestionEdit) unifiedDiff(path string) string {
+	before := max(0, e.start-patchContextLines)
+	afterStart := e.start + len(e.removed)
+	after := min(len(e.lines), afterStart+patchContextLines)
+
+	leading := e.lines[before:e.start]
+	var trailingContext []string
+	if afterStart < len(e.lines) {
+		trailingContext = e.lines[afterStart:after]
+	}
+	// Without a final newline, the last line of the file is marked in the patch
+	noNewline := !e.trailing && after == len(e.lines)
+
+	oldCount := len(leading) + len(e.removed) + len(trailingContext)
+	newCount := len(leading) + len(e.added) + len(trailingContext)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- a/%s\n+++ b/%s\n", path, path)
+	fmt.Fprintf(&b, "@@ -%s +%s @@\n", hunkRange(before, oldCount), hunkRange(before, newCount))
+	for _, line := range leading {
+		b.WriteString(" " + line + "\n")
+	}
+	for i, line := range e.removed {
+		b.WriteString("-" + line + "\n")
+		if noNewline && len(trailingContext) == 0 && i == len(e.removed)-1 {
+			b.WriteString("\\ No newline at end of file\n")
+		}
+	}
+	for i, line := range e.added {
+		b.WriteString("+" + line + "\n")
+		if noNewline && len(trailingContext) == 0 && i == len(e.added)-1 {
+			b.WriteString("\\ No newline at end of file\n")
+		}
+	}
+	for i, line := range trailingContext {
+		b.WriteString(" " + line + "\n")
+		if noNewline && i == len(trailingContext)-1 {
+			b.WriteString("\\ No newline at end of file\n")
+		}
+	}
+	return b.String()
+}
+
+// hunkRange formats the start,count of a hunk header from a 0-based start.
+// An empty range refers to the line before it, as in diff(1).
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
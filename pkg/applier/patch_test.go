@@ -0,0 +1,434 @@
+package applier
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chmouel/gh-prreview/pkg/github"
+	"github.com/chmouel/gh-prreview/pkg/parser"
+)
+
+const patchTestFile = "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tretries := 3\n\tfmt.Println(retries)\n}\n"
+
+func TestBuildPatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		comment *github.ReviewComment
+		want    string
+	}{
+		{
+			name:    "single line replaced in the middle of a file",
+			content: patchTestFile,
+			comment: &github.ReviewComment{
+				Path:          "main.go",
+				DiffHunk:      "@@ -5,2 +5,3 @@\n func main() {\n+\tretries := 3",
+				SuggestedCode: "\tconst retries = 3\n",
+			},
+			want: "--- a/main.go\n+++ b/main.go\n@@ -3,6 +3,6 @@\n" +
+				" import \"fmt\"\n \n func main() {\n-\tretries := 3\n+\tconst retries = 3\n \tfmt.Println(retries)\n }\n",
+		},
+		{
+			name:    "change near the top has less leading context",
+			content: patchTestFile,
+			comment: &github.ReviewComment{
+				Path:          "main.go",
+				DiffHunk:      "@@ -1,1 +1,1 @@\n+package main",
+				SuggestedCode: "package app\n",
+			},
+			want: "--- a/main.go\n+++ b/main.go\n@@ -1,4 +1,4 @@\n-package main\n+package app\n \n import \"fmt\"\n \n",
+		},
+		{
+			name:    "last line of a file without a trailing newline",
+			content: "a\nb\nc",
+			comment: &github.ReviewComment{
+				Path:          "x.txt",
+				DiffHunk:      "@@ -3,1 +3,1 @@\n+c",
+				SuggestedCode: "c2\nc3",
+			},
+			want: "--- a/x.txt\n+++ b/x.txt\n@@ -1,3 +1,4 @@\n a\n b\n-c\n\\ No newline at end of file\n+c2\n+c3\n\\ No newline at end of file\n",
+		},
+		{
+			name:    "empty suggestion deletes the line",
+			content: patchTestFile,
+			comment: &github.ReviewComment{
+				Path:       "main.go",
+				DiffHunk:   "@@ -5,2 +5,3 @@\n func main() {\n+\tretries := 3",
+				IsDeletion: true,
+			},
+			want: "--- a/main.go\n+++ b/main.go\n@@ -3,6 +3,5 @@\n" +
+				" import \"fmt\"\n \n func main() {\n-\tretries := 3\n \tfmt.Println(retries)\n }\n",
+		},
+		{
+			name:    "suggestion already in place",
+			content: patchTestFile,
+			comment: &github.ReviewComment{
+				Path:          "main.go",
+				DiffHunk:      "@@ -5,2 +5,3 @@\n func main() {\n+\tretries := 3",
+				SuggestedCode: "\tretries := 3",
+			},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BuildPatch(tt.comment, []byte(tt.content))
+			if err != nil {
+				t.Fatalf("BuildPatch() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("BuildPatch() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildPatchCodeNotFound(t *testing.T) {
+	comment := &github.ReviewComment{
+		Path:          "main.go",
+		DiffHunk:      "@@ -5,2 +5,3 @@\n func main() {\n+\tattempts := 5",
+		SuggestedCode: "\tconst attempts = 5",
+	}
+	if _, err := BuildPatch(comment, []byte(patchTestFile)); err == nil || !strings.Contains(err.Error(), "could not find the code to replace") {
+		t.Errorf("BuildPatch() error = %v, want code not found error", err)
+	}
+}
+
+// TestBuildPatchAppliesWithGit checks the patch is accepted by git apply and
+// produces the same content as applying the suggestion directly
+func TestBuildPatchAppliesWithGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	comment := &github.ReviewComment{
+		Path:          "main.go",
+		DiffHunk:      "@@ -5,2 +5,3 @@\n func main() {\n+\tretries := 3",
+		SuggestedCode: "\tconst retries = 3\n\tconst delay = 1",
+	}
+	patch, err := BuildPatch(comment, []byte(patchTestFile))
+	if err != nil {
+		t.Fatalf("BuildPatch() error = %v", err)
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte(patchTestFile), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command("git", "apply", "-")
+	cmd.Dir = dir
+	cmd.Stdin = strings.NewReader(patch)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git apply failed: %v\n%s\npatch:\n%s", err, output, patch)
+	}
+
+	got, err := os.ReadFile(filepath.Join(dir, "main.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	edit, err := New().editContent(comment, patchTestFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != edit.content {
+		t.Errorf("git apply result =\n%s\nwant\n%s", got, edit.content)
+	}
+}
+
+func TestApply(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.WriteFile("main.go", []byte(patchTestFile), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	comment := &github.ReviewComment{
+		ID:            1,
+		Path:          "main.go",
+		DiffHunk:      "@@ -5,2 +5,3 @@\n func main() {\n+\tretries := 3",
+		SuggestedCode: "\tconst retries = 3\n",
+	}
+	if err := New().Apply(comment); err != nil {
+		t.Fatalf("Apply() returned error: %v", err)
+	}
+
+	got, err := os.ReadFile("main.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Replace(patchTestFile, "\tretries := 3", "\tconst retries = 3", 1)
+	if string(got) != want {
+		t.Errorf("file after Apply() =\n%s\nwant\n%s", got, want)
+	}
+
+	missing := &github.ReviewComment{ID: 2, Path: "gone.go", SuggestedCode: "x"}
+	if err := New().Apply(missing); err == nil {
+		t.Error("Apply() on a missing file should return an error")
+	}
+}
+
+func TestApplyMarkdownSuggestionWithCodeBlock(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.WriteFile("README.md", []byte("# Tool\n\nRun make.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	body := "Show the command:\n````suggestion\nRun:\n\n```bash\nmake test\n```\n````"
+	comment := &github.ReviewComment{
+		ID:            1,
+		Path:          "README.md",
+		DiffHunk:      "@@ -1,3 +1,3 @@\n # Tool\n \n+Run make.",
+		SuggestedCode: parser.ParseSuggestion(body),
+	}
+	if err := New().Apply(comment); err != nil {
+		t.Fatalf("Apply() returned error: %v", err)
+	}
+
+	got, err := os.ReadFile("README.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "# Tool\n\nRun:\n\n```bash\nmake test\n```\n"
+	if string(got) != want {
+		t.Errorf("file after Apply() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestApplyAllTwice(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.WriteFile("main.go", []byte(patchTestFile), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	suggestions := []*github.ReviewComment{
+		{
+			ID:            1,
+			Path:          "main.go",
+			Line:          6,
+			DiffHunk:      "@@ -5,2 +5,3 @@\n func main() {\n+\tretries := 3",
+			SuggestedCode: "\tconst retries = 3\n",
+		},
+		{
+			ID:            2,
+			Path:          "main.go",
+			Line:          1,
+			DiffHunk:      "@@ -1,1 +1,1 @@\n+package main",
+			SuggestedCode: "package app\n",
+		},
+	}
+
+	first, err := New().ApplyAll(suggestions)
+	if err != nil {
+		t.Fatalf("first ApplyAll() error = %v", err)
+	}
+	if first.Applied != 2 {
+		t.Fatalf("first run applied %d suggestions, want 2: %+v", first.Applied, first.Suggestions)
+	}
+	afterFirst, err := os.ReadFile("main.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := New().ApplyAll(suggestions)
+	if err != nil {
+		t.Fatalf("second ApplyAll() error = %v", err)
+	}
+	if second.AlreadyApplied != 2 || second.Applied != 0 || second.Failed != 0 {
+		t.Errorf("second run = %d already applied, %d applied, %d failed, want 2, 0, 0: %+v",
+			second.AlreadyApplied, second.Applied, second.Failed, second.Suggestions)
+	}
+	for _, result := range second.Suggestions {
+		if result.Result != ResultAlreadyApplied {
+			t.Errorf("comment %d result = %q, want %q", result.CommentID, result.Result, ResultAlreadyApplied)
+		}
+	}
+
+	afterSecond, err := os.ReadFile("main.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(afterSecond) != string(afterFirst) {
+		t.Errorf("second run changed the file:\n%s\nwant\n%s", afterSecond, afterFirst)
+	}
+}
+
+func TestSuggestionInPlaceAmbiguous(t *testing.T) {
+	comment := &github.ReviewComment{
+		Path:          "main.go",
+		DiffHunk:      "@@ -1,1 +1,1 @@\n+\tx := 1",
+		SuggestedCode: "}\n",
+	}
+	lines := strings.Split("func a() {\n}\n\nfunc b() {\n}", "\n")
+	if suggestionInPlace(comment, lines) {
+		t.Error("suggestionInPlace() = true for code found several times, want false")
+	}
+}
+
+func TestSuggestionInPlaceElsewhere(t *testing.T) {
+	comment := &github.ReviewComment{
+		Path:          "main.go",
+		DiffHunk:      "@@ -1,1 +1,1 @@\n+\treturn err",
+		SuggestedCode: "\treturn nil\n",
+	}
+	lines := make([]string, 0, 30)
+	lines = append(lines, "\treturn err")
+	for range 2 * inPlaceDistance {
+		lines = append(lines, "")
+	}
+	lines = append(lines, "\treturn nil")
+	if suggestionInPlace(comment, lines) {
+		t.Error("suggestionInPlace() = true for code far from the reviewed position, want false")
+	}
+
+	moved := append([]string{"// added since the review"}, lines[1:]...)
+	moved[1] = "\treturn nil"
+	if !suggestionInPlace(comment, moved) {
+		t.Error("suggestionInPlace() = false for code a line below the reviewed position, want true")
+	}
+}
+
+func TestApplyDeletion(t *testing.T) {
+	t.Chdir(t.TempDir())
+	content := "package main\n\nfunc main() {\n\t// TODO: remove\n\tdebug()\n\trun()\n}\n"
+	if err := os.WriteFile("main.go", []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	comment := &github.ReviewComment{
+		ID:            1,
+		Path:          "main.go",
+		DiffHunk:      "@@ -3,2 +3,4 @@\n func main() {\n+\t// TODO: remove\n+\tdebug()",
+		HasSuggestion: true,
+		IsDeletion:    true,
+	}
+	if err := New().Apply(comment); err != nil {
+		t.Fatalf("Apply() returned error: %v", err)
+	}
+
+	got, err := os.ReadFile("main.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "package main\n\nfunc main() {\n\trun()\n}\n"; string(got) != want {
+		t.Errorf("file after Apply() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestApplyDeletionTwice(t *testing.T) {
+	t.Chdir(t.TempDir())
+	content := "package main\n\nfunc main() {\n\t// TODO: remove\n\tdebug()\n\trun()\n}\n"
+	if err := os.WriteFile("main.go", []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	comment := &github.ReviewComment{
+		ID:            1,
+		Path:          "main.go",
+		DiffHunk:      "@@ -3,2 +3,4 @@\n func main() {\n+\t// TODO: remove\n+\tdebug()",
+		HasSuggestion: true,
+		IsDeletion:    true,
+	}
+	if err := New().Apply(comment); err != nil {
+		t.Fatalf("first Apply() returned error: %v", err)
+	}
+	if err := New().Apply(comment); !errors.Is(err, ErrAlreadyApplied) {
+		t.Fatalf("second Apply() error = %v, want ErrAlreadyApplied", err)
+	}
+}
+
+func TestEditContentFinalNewline(t *testing.T) {
+	lastLine := "@@ -3,1 +3,1 @@\n+c"
+	tests := []struct {
+		name       string
+		content    string
+		diffHunk   string
+		suggestion string
+		deletion   bool
+		want       string
+	}{
+		{"last line with final newline", "a\nb\nc\n", lastLine, "c2", false, "a\nb\nc2\n"},
+		{"last line without final newline", "a\nb\nc", lastLine, "c2", false, "a\nb\nc2"},
+		{"suggestion ending in a newline, file without one", "a\nb\nc", lastLine, "c2\n", false, "a\nb\nc2"},
+		{"suggestion ending in newlines, file with one", "a\nb\nc\n", lastLine, "c2\n\n", false, "a\nb\nc2\n"},
+		{"middle line without final newline", "a\nb\nc", "@@ -2,1 +2,1 @@\n+b", "b2\n", false, "a\nb2\nc"},
+		{"deleted last line with final newline", "a\nb\nc\n", lastLine, "", true, "a\nb\n"},
+		{"deleted last line without final newline", "a\nb\nc", lastLine, "", true, "a\nb"},
+		{"deleted only line", "a\n", "@@ -1,1 +1,1 @@\n+a", "", true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comment := &github.ReviewComment{
+				Path:          "x.txt",
+				DiffHunk:      tt.diffHunk,
+				SuggestedCode: tt.suggestion,
+				IsDeletion:    tt.deletion,
+			}
+			edit, err := New().editContent(comment, tt.content)
+			if err != nil {
+				t.Fatalf("editContent() error = %v", err)
+			}
+			if edit.content != tt.want {
+				t.Errorf("editContent() content = %q, want %q", edit.content, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanApply(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.WriteFile("main.go", []byte(patchTestFile), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	hunk := "@@ -5,2 +5,3 @@\n func main() {\n+\tretries := 3"
+	tests := []struct {
+		name       string
+		comment    *github.ReviewComment
+		wantOK     bool
+		wantReason string
+	}{
+		{
+			name:    "clean",
+			comment: &github.ReviewComment{Path: "main.go", DiffHunk: hunk, HasSuggestion: true, SuggestedCode: "\tconst retries = 3"},
+			wantOK:  true,
+		},
+		{
+			name:    "already applied",
+			comment: &github.ReviewComment{Path: "main.go", DiffHunk: hunk, HasSuggestion: true, SuggestedCode: "\tretries := 3"},
+			wantOK:  true,
+		},
+		{
+			name:       "drifted",
+			comment:    &github.ReviewComment{Path: "main.go", DiffHunk: "@@ -5,2 +5,3 @@\n func main() {\n+\tretries := 5", HasSuggestion: true, SuggestedCode: "\tconst retries = 5"},
+			wantReason: "code changed since the review",
+		},
+		{
+			name:       "missing file",
+			comment:    &github.ReviewComment{Path: "gone.go", DiffHunk: hunk, HasSuggestion: true, SuggestedCode: "x"},
+			wantReason: "file not found",
+		},
+		{
+			name:       "no suggestion",
+			comment:    &github.ReviewComment{Path: "main.go", DiffHunk: hunk},
+			wantReason: "no suggestion",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, reason := New().CanApply(tt.comment)
+			if ok != tt.wantOK || reason != tt.wantReason {
+				t.Errorf("CanApply() = %v, %q, want %v, %q", ok, reason, tt.wantOK, tt.wantReason)
+			}
+		})
+	}
+
+	if got, _ := os.ReadFile("main.go"); string(got) != patchTestFile {
+		t.Errorf("CanApply() modified the file:\n%s", got)
+	}
+}
@@ -0,0 +1,46 @@
+package applier
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/chmouel/gh-prreview/pkg/github"
+	"github.com/chmouel/gh-prreview/pkg/state"
+	"github.com/chmouel/gh-prreview/pkg/ui"
+)
+
+// fileRemovedReply is posted on the thread when a suggestion is skipped
+// because its file was deleted locally
+const fileRemovedReply = "file removed, not applicable"
+
+// handleRemovedFile reports a suggestion whose file no longer exists locally
+// and offers to skip it and close its thread with a short reply
+func (a *Applier) handleRemovedFile(summary *Summary, comment *github.ReviewComment) {
+	fmt.Printf("%s%s no longer exists locally, the suggestion cannot be applied\n",
+		ui.EmojiText("⚠️  ", "Warning: "), comment.Path)
+
+	if a.keepThreads || a.githubClient == nil || a.prNumber == 0 || comment.ThreadID == "" || comment.IsResolved() {
+		fmt.Printf("%sSkipped\n", ui.EmojiText("⏭️  ", "SKIP: "))
+		a.finishSuggestion(summary, comment, state.OutcomeSkipped, nil)
+		return
+	}
+
+	fmt.Printf("%s ", ui.Colorize(ui.ColorYellow, fmt.Sprintf("Skip and resolve the thread with %q? [y/n]", fileRemovedReply)))
+	response, _ := stdin.ReadString('\n')
+	response = strings.ToLower(strings.TrimSpace(response))
+	if response != "y" && response != "yes" {
+		fmt.Printf("%sSkipped\n", ui.EmojiText("⏭️  ", "SKIP: "))
+		a.finishSuggestion(summary, comment, state.OutcomeSkipped, nil)
+		return
+	}
+
+	if _, err := a.githubClient.ReplyToReviewComment(a.prNumber, comment.ID, fileRemovedReply); err != nil {
+		fmt.Printf("%sFailed to reply: %v\n", ui.EmojiText("❌ ", "FAIL: "), err)
+	} else if err := a.githubClient.ResolveThread(comment.ThreadID); err != nil {
+		fmt.Printf("%sFailed to resolve thread: %v\n", ui.EmojiText("❌ ", "FAIL: "), err)
+	} else {
+		fmt.Printf("%sSkipped, review thread marked as resolved\n", ui.EmojiText("✅ ", "OK: "))
+		a.markResolved(comment)
+	}
+	a.finishSuggestion(summary, comment, state.OutcomeSkipped, nil)
+}
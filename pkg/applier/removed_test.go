@@ -0,0 +1,45 @@
+package applier
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/chmouel/gh-prreview/pkg/github"
+)
+
+func TestApplySuggestionRemovedFile(t *testing.T) {
+	comment := &github.ReviewComment{
+		ID:            1,
+		Path:          filepath.Join(t.TempDir(), "gone.go"),
+		Line:          1,
+		HasSuggestion: true,
+		SuggestedCode: "package gone",
+	}
+
+	err := New().applySuggestion(comment)
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("applySuggestion() error = %v, want one wrapping os.ErrNotExist", err)
+	}
+}
+
+func TestResolvePromptingDisabled(t *testing.T) {
+	app := New()
+	app.SetGitHubClient(&github.Client{})
+	app.SetPRNumber(1)
+	app.SetResolvePrompting(false)
+	comment := &github.ReviewComment{ID: 1, Path: "gone.go", ThreadID: "T_1"}
+
+	out := captureStdout(t, func() {
+		app.promptToResolveThread(comment)
+		app.handleRemovedFile(&Summary{}, comment)
+	})
+	if strings.Contains(out, "resolve") {
+		t.Errorf("output offers to resolve the thread with prompting disabled:\n%s", out)
+	}
+	if !strings.Contains(out, "Skipped") {
+		t.Errorf("output = %q, want the removed file suggestion skipped", out)
+	}
+}
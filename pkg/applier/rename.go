@@ -0,0 +1,98 @@
+package applier
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/chmouel/gh-prreview/pkg/github"
+	"github.com/chmouel/gh-prreview/pkg/ui"
+)
+
+// followRenamedFile points comment.Path at the file's current location when the
+// reviewed path no longer exists locally and git history shows it was renamed.
+// The user is asked to confirm each rename once; the answer is remembered for
+// later suggestions on the same file.
+func (a *Applier) followRenamedFile(comment *github.ReviewComment) error {
+	if !a.followRenames {
+		return nil
+	}
+	if _, err := os.Stat(a.path(comment.Path)); err == nil || !os.IsNotExist(err) {
+		return nil
+	}
+
+	if newPath, ok := a.renamedPaths[comment.Path]; ok {
+		if newPath == "" {
+			return fmt.Errorf("file %s no longer exists", comment.Path)
+		}
+		comment.Path = newPath
+		return nil
+	}
+
+	output, err := exec.Command("git", a.gitArgs("log", "-M", "--diff-filter=R", "--name-status", "--format=")...).Output()
+	if err != nil {
+		return fmt.Errorf("failed to look up renames for %s: %w", comment.Path, err)
+	}
+
+	newPath := resolveRename(parseRenames(string(output)), comment.Path)
+	if newPath == "" {
+		return fmt.Errorf("file %s no longer exists and no rename was found in git history", comment.Path)
+	}
+	if _, err := os.Stat(a.path(newPath)); err != nil {
+		return fmt.Errorf("file %s was renamed to %s, which does not exist either", comment.Path, newPath)
+	}
+
+	a.debugLog("Found rename %s -> %s", comment.Path, newPath)
+
+	if a.renamedPaths == nil {
+		a.renamedPaths = make(map[string]string)
+	}
+
+	fmt.Printf("\n%s ", ui.Colorize(ui.ColorYellow,
+		fmt.Sprintf("%s was renamed to %s. Apply the suggestion there? [y/n]", comment.Path, newPath)))
+	response, _ := stdin.ReadString('\n')
+	response = strings.ToLower(strings.TrimSpace(response))
+	if response != "y" && response != "yes" {
+		a.renamedPaths[comment.Path] = ""
+		return fmt.Errorf("file %s no longer exists (rename to %s declined)", comment.Path, newPath)
+	}
+
+	a.renamedPaths[comment.Path] = newPath
+	comment.Path = newPath
+	return nil
+}
+
+// parseRenames parses `git log --name-status` output (newest commit first) and
+// returns the (old, new) path pairs in the order the renames happened.
+func parseRenames(output string) [][2]string {
+	var renames [][2]string
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(strings.TrimSpace(line), "\t")
+		if len(fields) != 3 || !strings.HasPrefix(fields[0], "R") {
+			continue
+		}
+		renames = append(renames, [2]string{fields[1], fields[2]})
+	}
+
+	// git log lists the newest commit first, replay from the oldest
+	for i, j := 0, len(renames)-1; i < j; i, j = i+1, j-1 {
+		renames[i], renames[j] = renames[j], renames[i]
+	}
+	return renames
+}
+
+// resolveRename follows a chain of renames starting at path and returns the
+// final path, or an empty string if path was never renamed.
+func resolveRename(renames [][2]string, path string) string {
+	current := path
+	for _, rename := range renames {
+		if rename[0] == current {
+			current = rename[1]
+		}
+	}
+	if current == path {
+		return ""
+	}
+	return current
+}
@@ -0,0 +1,45 @@
+package applier
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRenames(t *testing.T) {
+	output := "R100\tpkg/b.go\tpkg/c.go\n\nM\tREADME.md\nR087\tpkg/a.go\tpkg/b.go\n"
+	got := parseRenames(output)
+	want := [][2]string{
+		{"pkg/a.go", "pkg/b.go"},
+		{"pkg/b.go", "pkg/c.go"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseRenames() = %v, want %v", got, want)
+	}
+}
+
+func TestResolveRename(t *testing.T) {
+	renames := [][2]string{
+		{"pkg/a.go", "pkg/b.go"},
+		{"docs/x.md", "docs/y.md"},
+		{"pkg/b.go", "pkg/c.go"},
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want string
+	}{
+		{"chained rename", "pkg/a.go", "pkg/c.go"},
+		{"single rename", "docs/x.md", "docs/y.md"},
+		{"rename from the middle of a chain", "pkg/b.go", "pkg/c.go"},
+		{"never renamed", "main.go", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resolveRename(renames, tt.path); got != tt.want {
+				t.Errorf("resolveRename(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
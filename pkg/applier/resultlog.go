@@ -0,0 +1,78 @@
+package applier
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/chmouel/gh-prreview/pkg/github"
+	"github.com/chmouel/gh-prreview/pkg/state"
+)
+
+// ResultRecord is the line written to the result sink for each suggestion
+// processed by ApplyAll, ApplyInteractive or ApplyAllWithAI, or skipped
+// before (WriteSkipped)
+type ResultRecord struct {
+	CommentID int64  `json:"comment_id"`
+	Path      string `json:"path"`
+	Line      int    `json:"line"`
+	Author    string `json:"author"`
+	Outcome   string `json:"outcome"` // a state.Outcome* value or ResultAlreadyApplied
+	Error     string `json:"error,omitempty"`
+	Resolved  bool   `json:"resolved"`
+}
+
+// SetResultSink makes the applier write one JSON line per processed
+// suggestion to w, as a trail independent of the terminal output
+func (a *Applier) SetResultSink(w io.Writer) {
+	a.resultSink = w
+}
+
+// markResolved notes a thread resolved during the run, for the result sink
+func (a *Applier) markResolved(comment *github.ReviewComment) {
+	if a.resolved == nil {
+		a.resolved = make(map[int64]bool)
+	}
+	a.resolved[comment.ID] = true
+}
+
+// writeResult appends the record of a suggestion to the result sink, if any.
+// Write errors are only logged: the run itself is not affected.
+func (a *Applier) writeResult(comment *github.ReviewComment, result string, err error) {
+	if a.resultSink == nil {
+		return
+	}
+
+	record := newResultRecord(comment, result)
+	record.Resolved = record.Resolved || a.resolved[comment.ID]
+	if err != nil {
+		record.Error = err.Error()
+	}
+	if err := json.NewEncoder(a.resultSink).Encode(record); err != nil {
+		a.debugLog("Failed to write result of suggestion %d: %v", comment.ID, err)
+	}
+}
+
+// WriteSkipped appends a skipped record to w for a suggestion left out before
+// reaching the applier, e.g. outdated or on a protected file, with the reason
+// as its error
+func WriteSkipped(w io.Writer, comment *github.ReviewComment, reason string) error {
+	record := newResultRecord(comment, state.OutcomeSkipped)
+	record.Error = reason
+	return json.NewEncoder(w).Encode(record)
+}
+
+// newResultRecord returns the record of comment with outcome result
+func newResultRecord(comment *github.ReviewComment, result string) ResultRecord {
+	line := comment.Line
+	if line == 0 {
+		line = comment.OriginalLine
+	}
+	return ResultRecord{
+		CommentID: comment.ID,
+		Path:      comment.Path,
+		Line:      line,
+		Author:    comment.Author,
+		Outcome:   result,
+		Resolved:  comment.IsResolved(),
+	}
+}
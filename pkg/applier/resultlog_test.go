@@ -0,0 +1,84 @@
+package applier
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/chmouel/gh-prreview/pkg/github"
+	"github.com/chmouel/gh-prreview/pkg/state"
+)
+
+func TestResultSink(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.WriteFile("main.go", []byte(patchTestFile), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	suggestions := []*github.ReviewComment{
+		{
+			ID:            1,
+			Path:          "main.go",
+			Line:          6,
+			Author:        "alice",
+			DiffHunk:      "@@ -5,2 +5,3 @@\n func main() {\n+\tretries := 3",
+			SuggestedCode: "\tconst retries = 3\n",
+		},
+		{
+			ID:            2,
+			Path:          "missing.go",
+			OriginalLine:  3,
+			Author:        "bob",
+			DiffHunk:      "@@ -1,3 +1,3 @@\n+x := 1",
+			SuggestedCode: "x := 2\n",
+		},
+	}
+
+	var sink bytes.Buffer
+	a := New()
+	a.SetResultSink(&sink)
+	a.markResolved(suggestions[0])
+	if _, err := a.ApplyAll(suggestions); err != nil {
+		t.Fatalf("ApplyAll() error = %v", err)
+	}
+
+	var records []ResultRecord
+	dec := json.NewDecoder(&sink)
+	for dec.More() {
+		var record ResultRecord
+		if err := dec.Decode(&record); err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		records = append(records, record)
+	}
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2: %+v", len(records), records)
+	}
+
+	applied := ResultRecord{CommentID: 1, Path: "main.go", Line: 6, Author: "alice", Outcome: state.OutcomeApplied, Resolved: true}
+	if records[0] != applied {
+		t.Errorf("first record = %+v, want %+v", records[0], applied)
+	}
+	failed := records[1]
+	if failed.CommentID != 2 || failed.Line != 3 || failed.Outcome != state.OutcomeFailed || failed.Error == "" || failed.Resolved {
+		t.Errorf("second record = %+v, want a failure of comment 2 at line 3 with an error", failed)
+	}
+}
+
+func TestWriteSkipped(t *testing.T) {
+	var sink bytes.Buffer
+	comment := &github.ReviewComment{ID: 3, Path: "vendor/a.go", OriginalLine: 7, Author: "carol"}
+	if err := WriteSkipped(&sink, comment, "outdated"); err != nil {
+		t.Fatalf("WriteSkipped() error = %v", err)
+	}
+
+	var record ResultRecord
+	if err := json.Unmarshal(sink.Bytes(), &record); err != nil {
+		t.Fatal(err)
+	}
+	want := ResultRecord{CommentID: 3, Path: "vendor/a.go", Line: 7, Author: "carol", Outcome: state.OutcomeSkipped, Error: "outdated"}
+	if record != want {
+		t.Errorf("record = %+v, want %+v", record, want)
+	}
+}
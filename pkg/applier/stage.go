@@ -0,0 +1,40 @@
+package applier
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/chmouel/gh-prreview/pkg/ui"
+)
+
+// gitCommand runs a git command and returns its combined output. It is a
+// variable so tests can replace git.
+var gitCommand = func(args ...string) ([]byte, error) {
+	return exec.Command("git", args...).CombinedOutput()
+}
+
+// stageFile stages path with `git add` when staging is enabled and the file
+// has local modifications, so each applied suggestion can be committed on its own
+func (a *Applier) stageFile(path string) {
+	if !a.stage {
+		return
+	}
+
+	status, err := a.git("status", "--porcelain", "--", path)
+	if err != nil {
+		fmt.Printf("%sFailed to check git status of %s: %v\n", ui.EmojiText("❌ ", "FAIL: "), path, err)
+		return
+	}
+	if strings.TrimSpace(string(status)) == "" {
+		a.debugLog("Not staging %s: no changes", path)
+		return
+	}
+
+	if output, err := a.git("add", "--", path); err != nil {
+		fmt.Printf("%sFailed to stage %s: %v %s\n", ui.EmojiText("❌ ", "FAIL: "), path, err, strings.TrimSpace(string(output)))
+		return
+	}
+
+	fmt.Printf("%sStaged %s\n", ui.EmojiText("📌 ", ""), path)
+}
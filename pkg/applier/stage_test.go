@@ -0,0 +1,74 @@
+package applier
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fakeGit replaces gitCommand for the duration of a test and records calls
+func fakeGit(t *testing.T, modified map[string]bool, addErr error) *[][]string {
+	t.Helper()
+	var calls [][]string
+	original := gitCommand
+	gitCommand = func(args ...string) ([]byte, error) {
+		calls = append(calls, args)
+		switch args[0] {
+		case "status":
+			path := args[len(args)-1]
+			if modified[path] {
+				return []byte(" M " + path + "\n"), nil
+			}
+			return nil, nil
+		case "add":
+			return nil, addErr
+		case "commit":
+			return nil, nil
+		}
+		return nil, errors.New("unexpected git command: " + strings.Join(args, " "))
+	}
+	t.Cleanup(func() { gitCommand = original })
+	return &calls
+}
+
+func TestStageFileStagesModifiedFiles(t *testing.T) {
+	calls := fakeGit(t, map[string]bool{"main.go": true}, nil)
+
+	a := New()
+	a.SetStage(true)
+	a.stageFile("main.go")
+	a.stageFile("unchanged.go")
+
+	want := [][]string{
+		{"status", "--porcelain", "--", "main.go"},
+		{"add", "--", "main.go"},
+		{"status", "--porcelain", "--", "unchanged.go"},
+	}
+	if !reflect.DeepEqual(*calls, want) {
+		t.Errorf("git calls = %v, want %v", *calls, want)
+	}
+}
+
+func TestStageFileDisabled(t *testing.T) {
+	calls := fakeGit(t, map[string]bool{"main.go": true}, nil)
+
+	a := New()
+	a.stageFile("main.go")
+
+	if len(*calls) != 0 {
+		t.Errorf("stageFile without --stage ran git: %v", *calls)
+	}
+}
+
+func TestStageFileAddFailure(t *testing.T) {
+	calls := fakeGit(t, map[string]bool{"main.go": true}, errors.New("index.lock exists"))
+
+	a := New()
+	a.SetStage(true)
+	a.stageFile("main.go")
+
+	if len(*calls) != 2 || (*calls)[1][0] != "add" {
+		t.Errorf("git calls = %v, want status followed by add", *calls)
+	}
+}
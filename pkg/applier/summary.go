@@ -0,0 +1,85 @@
+package applier
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/chmouel/gh-prreview/pkg/github"
+	"github.com/chmouel/gh-prreview/pkg/state"
+	"github.com/chmouel/gh-prreview/pkg/ui"
+)
+
+// ResultAlreadyApplied is the result of a suggestion found already in the
+// file, which is left untouched
+const ResultAlreadyApplied = "already_applied"
+
+// SuggestionResult is what happened to one suggestion during a run
+type SuggestionResult struct {
+	CommentID int64  `json:"comment_id"`
+	Location  string `json:"location"`
+	Author    string `json:"author"`
+	Result    string `json:"result"` // a state.Outcome* value or ResultAlreadyApplied
+	Error     string `json:"error,omitempty"`
+}
+
+// Summary is the end-of-run report of ApplyAll, ApplyInteractive and
+// ApplyAllWithAI
+type Summary struct {
+	Total          int                `json:"total"`
+	Applied        int                `json:"applied"`
+	Skipped        int                `json:"skipped"`
+	Failed         int                `json:"failed"`
+	AlreadyApplied int                `json:"already_applied"`
+	Suggestions    []SuggestionResult `json:"suggestions"`
+}
+
+// NewSummary returns an empty summary for a run over total suggestions
+func NewSummary(total int) *Summary {
+	return &Summary{Total: total, Suggestions: []SuggestionResult{}}
+}
+
+func (s *Summary) add(comment *github.ReviewComment, result string, err error) {
+	switch result {
+	case state.OutcomeApplied:
+		s.Applied++
+	case state.OutcomeSkipped:
+		s.Skipped++
+	case state.OutcomeFailed:
+		s.Failed++
+	case ResultAlreadyApplied:
+		s.AlreadyApplied++
+	}
+
+	entry := SuggestionResult{
+		CommentID: comment.ID,
+		Location:  comment.LocationLabel(),
+		Author:    comment.Author,
+		Result:    result,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	s.Suggestions = append(s.Suggestions, entry)
+}
+
+// AddSkipped counts a suggestion left out before the run, such as a
+// protected file, as skipped with reason as its error
+func (s *Summary) AddSkipped(comment *github.ReviewComment, reason string) {
+	s.Total++
+	s.add(comment, state.OutcomeSkipped, errors.New(reason))
+}
+
+// Print writes the human-readable summary line
+func (s *Summary) Print() {
+	fmt.Printf("\n%s\n", ui.Colorize(ui.ColorGray, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━"))
+	fmt.Printf("%s Applied %s/%d, Skipped %s, Failed %s\n",
+		ui.Colorize(ui.ColorCyan, "Summary:"),
+		ui.Colorize(ui.ColorGreen, fmt.Sprintf("%d", s.Applied)),
+		s.Total,
+		ui.Colorize(ui.ColorYellow, fmt.Sprintf("%d", s.Skipped)),
+		ui.Colorize(ui.ColorRed, fmt.Sprintf("%d", s.Failed)))
+	if s.AlreadyApplied > 0 {
+		fmt.Printf("%s %d suggestion(s) were already applied\n",
+			ui.Colorize(ui.ColorCyan, "Unchanged:"), s.AlreadyApplied)
+	}
+}
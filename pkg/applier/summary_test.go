@@ -0,0 +1,46 @@
+package applier
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/chmouel/gh-prreview/pkg/github"
+	"github.com/chmouel/gh-prreview/pkg/state"
+)
+
+func TestSummaryAdd(t *testing.T) {
+	summary := NewSummary(4)
+	summary.add(&github.ReviewComment{ID: 1, Path: "a.go", Line: 2, Author: "alice"}, state.OutcomeApplied, nil)
+	summary.add(&github.ReviewComment{ID: 2, Path: "b.go", Line: 5, Author: "bob"}, state.OutcomeFailed, errors.New("boom"))
+	summary.add(&github.ReviewComment{ID: 3, Path: "c.go", Line: 7, Author: "bob"}, state.OutcomeSkipped, nil)
+	summary.add(&github.ReviewComment{ID: 4, Path: "d.go", Line: 9, Author: "alice"}, ResultAlreadyApplied, nil)
+
+	if summary.Applied != 1 || summary.Failed != 1 || summary.Skipped != 1 || summary.AlreadyApplied != 1 {
+		t.Fatalf("counts = %d applied, %d failed, %d skipped, %d already applied, want 1 each",
+			summary.Applied, summary.Failed, summary.Skipped, summary.AlreadyApplied)
+	}
+
+	data, err := json.Marshal(summary)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	want := `{"total":4,"applied":1,"skipped":1,"failed":1,"already_applied":1,"suggestions":[` +
+		`{"comment_id":1,"location":"a.go:2","author":"alice","result":"applied"},` +
+		`{"comment_id":2,"location":"b.go:5","author":"bob","result":"failed","error":"boom"},` +
+		`{"comment_id":3,"location":"c.go:7","author":"bob","result":"skipped"},` +
+		`{"comment_id":4,"location":"d.go:9","author":"alice","result":"already_applied"}]}`
+	if string(data) != want {
+		t.Errorf("JSON = %s\nwant %s", data, want)
+	}
+}
+
+func TestNewSummaryEncodesEmptyList(t *testing.T) {
+	data, err := json.Marshal(NewSummary(0))
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if want := `{"total":0,"applied":0,"skipped":0,"failed":0,"already_applied":0,"suggestions":[]}`; string(data) != want {
+		t.Errorf("JSON = %s, want %s", data, want)
+	}
+}
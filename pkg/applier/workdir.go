@@ -0,0 +1,39 @@
+package applier
+
+import (
+	"path/filepath"
+)
+
+// SetWorkDir makes the applier work on the checkout in dir, e.g. a separate
+// git worktree, instead of the current directory: the files the review
+// comments name are read and written under dir, and git runs there
+func (a *Applier) SetWorkDir(dir string) {
+	if dir != "" {
+		if abs, err := filepath.Abs(dir); err == nil {
+			dir = abs
+		}
+	}
+	a.workDir = dir
+}
+
+// path returns where the file at the repository path p is on disk
+func (a *Applier) path(p string) string {
+	if a.workDir == "" || filepath.IsAbs(p) {
+		return p
+	}
+	return filepath.Join(a.workDir, p)
+}
+
+// gitArgs prefixes the arguments of a git command with -C when a work
+// directory is set, so git runs in it
+func (a *Applier) gitArgs(args ...string) []string {
+	if a.workDir == "" {
+		return args
+	}
+	return append([]string{"-C", a.workDir}, args...)
+}
+
+// git runs a git command in the work directory and returns its combined output
+func (a *Applier) git(args ...string) ([]byte, error) {
+	return gitCommand(a.gitArgs(args...)...)
+}
@@ -0,0 +1,103 @@
+package applier
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/chmouel/gh-prreview/pkg/github"
+)
+
+func TestApplySuggestionInWorkDir(t *testing.T) {
+	t.Chdir(t.TempDir())
+	workDir := t.TempDir()
+	path := filepath.Join(workDir, "main.go")
+	if err := os.WriteFile(path, []byte(patchTestFile), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var calls [][]string
+	original := gitCommand
+	gitCommand = func(args ...string) ([]byte, error) {
+		calls = append(calls, args)
+		return []byte(" M main.go\n"), nil
+	}
+	t.Cleanup(func() { gitCommand = original })
+
+	a := New()
+	a.SetWorkDir(workDir)
+	a.SetStage(true)
+	comment := &github.ReviewComment{ID: 1, Path: "main.go", DiffHunk: "@@ -5,2 +5,3 @@\n func main() {\n+\tretries := 3", SuggestedCode: "\tconst retries = 3\n"}
+	if err := a.Apply(comment); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "const retries = 3") {
+		t.Errorf("file in the work directory = %q, want the suggestion applied", content)
+	}
+	if _, err := os.Stat("main.go"); !os.IsNotExist(err) {
+		t.Errorf("a main.go was written in the current directory")
+	}
+	want := [][]string{
+		{"-C", workDir, "status", "--porcelain", "--", "main.go"},
+		{"-C", workDir, "add", "--", "main.go"},
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("git calls = %v, want %v", calls, want)
+	}
+	if got := a.appliedRanges[1].Path; got != path {
+		t.Errorf("recorded path = %q, want the absolute %q", got, path)
+	}
+}
+
+func TestAppliedPathIsAbsolute(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+	if err := os.WriteFile("main.go", []byte(patchTestFile), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	a := New()
+	comment := &github.ReviewComment{ID: 1, Path: "main.go", DiffHunk: "@@ -5,2 +5,3 @@\n func main() {\n+\tretries := 3", SuggestedCode: "\tconst retries = 3\n"}
+	if err := a.applySuggestion(comment); err != nil {
+		t.Fatalf("applySuggestion() error = %v", err)
+	}
+
+	record := a.appliedRanges[1]
+	if want := filepath.Join(dir, "main.go"); record.Path != want {
+		t.Errorf("recorded path = %q, want the absolute %q", record.Path, want)
+	}
+	// resolve may run from anywhere, such as a subdirectory
+	t.Chdir(t.TempDir())
+	if present, err := record.StillPresent(); err != nil || !present {
+		t.Errorf("StillPresent() from another directory = %v, %v; want true", present, err)
+	}
+}
+
+func TestCanApplyInWorkDir(t *testing.T) {
+	// The current directory has a drifted copy, the work dir a clean one
+	t.Chdir(t.TempDir())
+	if err := os.WriteFile("main.go", []byte("package main\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	workDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(workDir, "main.go"), []byte(patchTestFile), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	a := New()
+	a.SetWorkDir(workDir)
+	comment := &github.ReviewComment{ID: 1, Path: "main.go", HasSuggestion: true, DiffHunk: "@@ -5,2 +5,3 @@\n func main() {\n+\tretries := 3", SuggestedCode: "\tconst retries = 3\n"}
+	if ok, reason := a.CanApply(comment); !ok {
+		t.Errorf("CanApply() = false, %q, want the work dir copy to apply", reason)
+	}
+	renderer := &suggestionRenderer{applier: a}
+	if reason := renderer.driftReason(comment); reason != "" {
+		t.Errorf("driftReason() = %q, want no drift in the work dir", reason)
+	}
+}
@@ -0,0 +1,81 @@
+// Package config loads the optional gh-prreview configuration file.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// Config holds the user settings read from config.json
+type Config struct {
+	// ProtectedFiles are glob patterns of files apply refuses to modify
+	// without --force, typically generated code
+	ProtectedFiles []string `json:"protected_files"`
+	// Formatters maps file extensions (".go") to the command apply
+	// --format-after runs on the files it modified, overriding the defaults;
+	// an empty command turns formatting off for that extension
+	Formatters map[string]string `json:"formatters"`
+}
+
+// Path returns the location of the configuration file. It honors
+// $XDG_CONFIG_HOME and defaults to ~/.config/gh-prreview/config.json.
+func Path() (string, error) {
+	return configFile("config.json")
+}
+
+// ThemePath returns the location of the color theme file, next to the
+// configuration file: ~/.config/gh-prreview/theme.toml by default.
+func ThemePath() (string, error) {
+	return configFile("theme.toml")
+}
+
+// configFile returns the path of name in the gh-prreview configuration
+// directory
+func configFile(name string) (string, error) {
+	base := os.Getenv("XDG_CONFIG_HOME")
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to determine home directory: %w", err)
+		}
+		base = filepath.Join(home, ".config")
+	}
+	return filepath.Join(base, "gh-prreview", name), nil
+}
+
+// Load reads the configuration file from the default location. A missing
+// file yields an empty configuration.
+func Load() (*Config, error) {
+	configPath, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	return LoadFile(configPath)
+}
+
+// LoadFile reads the configuration from configPath. A missing file yields an
+// empty configuration.
+func LoadFile(configPath string) (*Config, error) {
+	cfg := &Config{}
+	data, err := os.ReadFile(configPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", configPath, err)
+	}
+
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", configPath, err)
+	}
+	for _, pattern := range cfg.ProtectedFiles {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid protected_files pattern %q in %s: %w", pattern, configPath, err)
+		}
+	}
+	return cfg, nil
+}
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		file    string
+		want    bool
+	}{
+		{"*.pb.go", "api/v1/service.pb.go", true},
+		{"*.pb.go", "service.pb.go", true},
+		{"*.pb.go", "api/v1/service.go", false},
+		{"**/generated/**", "pkg/generated/types.go", true},
+		{"**/generated/**", "generated/deep/nested/types.go", true},
+		{"**/generated/**", "pkg/generator/types.go", false},
+		{"vendor/", "vendor/github.com/x/y.go", true},
+		{"vendor/", "pkg/vendor/y.go", false},
+		{"docs/*.md", "docs/index.md", true},
+		{"docs/*.md", "docs/api/index.md", false},
+		{"./zz_generated.go", "zz_generated.go", true},
+		{"pkg/**/mock_*.go", "pkg/a/b/mock_client.go", true},
+		{"pkg/**/mock_*.go", "pkg/mock_client.go", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+"/"+tt.file, func(t *testing.T) {
+			if got := MatchGlob(tt.pattern, tt.file); got != tt.want {
+				t.Errorf("MatchGlob(%q, %q) = %v, want %v", tt.pattern, tt.file, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProtectedPattern(t *testing.T) {
+	cfg := &Config{ProtectedFiles: []string{"*.pb.go", "**/generated/**"}}
+
+	if pattern, ok := cfg.ProtectedPattern("pkg/generated/x.go"); !ok || pattern != "**/generated/**" {
+		t.Errorf("ProtectedPattern() = %q, %v; want %q, true", pattern, ok, "**/generated/**")
+	}
+	if _, ok := cfg.ProtectedPattern("main.go"); ok {
+		t.Errorf("ProtectedPattern(main.go) should not match")
+	}
+
+	var empty *Config
+	if _, ok := empty.ProtectedPattern("x.pb.go"); ok {
+		t.Errorf("ProtectedPattern() on a nil config should not match")
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	dir := t.TempDir()
+
+	cfg, err := LoadFile(filepath.Join(dir, "missing.json"))
+	if err != nil || len(cfg.ProtectedFiles) != 0 {
+		t.Fatalf("LoadFile() on a missing file = %+v, %v; want empty config", cfg, err)
+	}
+
+	valid := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(valid, []byte(`{"protected_files": ["*.pb.go"]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = LoadFile(valid)
+	if err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+	if len(cfg.ProtectedFiles) != 1 || cfg.ProtectedFiles[0] != "*.pb.go" {
+		t.Errorf("LoadFile() ProtectedFiles = %v", cfg.ProtectedFiles)
+	}
+
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalid, []byte(`{"protected_files": ["[a-"]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadFile(invalid); err == nil || !strings.Contains(err.Error(), "invalid protected_files pattern") {
+		t.Errorf("LoadFile() error = %v, want invalid pattern error", err)
+	}
+}
+
+func TestPathHonorsXDGConfigHome(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/tmp/xdg")
+	got, err := Path()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join("/tmp/xdg", "gh-prreview", "config.json"); got != want {
+		t.Errorf("Path() = %q, want %q", got, want)
+	}
+}
+
+func TestThemePathHonorsXDGConfigHome(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/tmp/xdg")
+	got, err := ThemePath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join("/tmp/xdg", "gh-prreview", "theme.toml"); got != want {
+		t.Errorf("ThemePath() = %q, want %q", got, want)
+	}
+}
+
+func TestFormatCommand(t *testing.T) {
+	cfg := &Config{Formatters: map[string]string{".go": "goimports -w", ".js": "", ".py": "black -q"}}
+
+	tests := []struct {
+		cfg    *Config
+		file   string
+		want   string
+		wantOK bool
+	}{
+		{nil, "main.go", "gofmt -w", true},
+		{nil, "web/App.TSX", "prettier --write", true},
+		{nil, "README.md", "", false},
+		{cfg, "main.go", "goimports -w", true},
+		{cfg, "app.js", "", false},
+		{cfg, "tool.py", "black -q", true},
+		{cfg, "app.ts", "prettier --write", true},
+	}
+
+	for _, tt := range tests {
+		args, ok := tt.cfg.FormatCommand(tt.file)
+		if got := strings.Join(args, " "); got != tt.want || ok != tt.wantOK {
+			t.Errorf("FormatCommand(%q) = %q, %v; want %q, %v", tt.file, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// defaultFormatters are the commands apply --format-after runs when the
+// configuration does not name one for the extension
+var defaultFormatters = map[string]string{
+	".go":  "gofmt -w",
+	".js":  "prettier --write",
+	".jsx": "prettier --write",
+	".ts":  "prettier --write",
+	".tsx": "prettier --write",
+}
+
+// FormatCommand returns the formatter command for file, chosen by its
+// extension, or false when there is none. The file path is to be appended to
+// the command's arguments.
+func (c *Config) FormatCommand(file string) ([]string, bool) {
+	ext := strings.ToLower(filepath.Ext(file))
+	command, ok := defaultFormatters[ext]
+	if c != nil {
+		if configured, found := c.Formatters[ext]; found {
+			command, ok = configured, true
+		}
+	}
+	args := strings.Fields(command)
+	if !ok || len(args) == 0 {
+		return nil, false
+	}
+	return args, true
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"path"
+	"strings"
+)
+
+// ProtectedPattern returns the first protected_files pattern matching file,
+// or false if the file is not protected.
+func (c *Config) ProtectedPattern(file string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	for _, pattern := range c.ProtectedFiles {
+		if MatchGlob(pattern, file) {
+			return pattern, true
+		}
+	}
+	return "", false
+}
+
+// MatchGlob reports whether the slash-separated file path matches pattern.
+// Patterns use path.Match syntax plus "**", which matches any number of
+// directories. A pattern without a slash matches the file name in any
+// directory (e.g. "*.pb.go"), and a trailing slash matches everything below
+// a directory (e.g. "vendor/").
+func MatchGlob(pattern, file string) bool {
+	file = path.Clean(strings.TrimPrefix(file, "./"))
+	pattern = strings.TrimPrefix(pattern, "./")
+	if strings.HasSuffix(pattern, "/") {
+		pattern += "**"
+	}
+
+	if !strings.Contains(pattern, "/") {
+		matched, _ := path.Match(pattern, path.Base(file))
+		return matched
+	}
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(file, "/"))
+}
+
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) == 0 {
+		return len(segments) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(pattern[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	matched, _ := path.Match(pattern[0], segments[0])
+	return matched && matchSegments(pattern[1:], segments[1:])
+}
@@ -273,3 +273,73 @@ func GetRemovedLines(hunk string) []string {
 
 	return removed
 }
+
+// String formats the hunk back into unified diff text
+func (dh *DiffHunk) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@", dh.OldStart, dh.OldLines, dh.NewStart, dh.NewLines)
+	for _, line := range dh.Lines {
+		b.WriteString("\n")
+		switch line.Type {
+		case Add:
+			b.WriteString("+" + line.Text)
+		case Delete:
+			b.WriteString("-" + line.Text)
+		case Control:
+			b.WriteString(line.Text)
+		default:
+			b.WriteString(" " + line.Text)
+		}
+	}
+	return b.String()
+}
+
+// TrimBefore returns a copy of the hunk that keeps at most n lines before
+// Lines[idx] and every line from idx on, with the header adjusted to match
+func (dh *DiffHunk) TrimBefore(idx, n int) *DiffHunk {
+	idx = min(max(idx, 0), len(dh.Lines))
+	from := max(idx-n, 0)
+
+	trimmed := &DiffHunk{
+		OldStart: dh.OldStart,
+		OldLines: dh.OldLines,
+		NewStart: dh.NewStart,
+		NewLines: dh.NewLines,
+		Lines:    dh.Lines[from:],
+	}
+	for _, line := range dh.Lines[:from] {
+		if line.Type == Context || line.Type == Delete {
+			trimmed.OldStart++
+			trimmed.OldLines--
+		}
+		if line.Type == Context || line.Type == Add {
+			trimmed.NewStart++
+			trimmed.NewLines--
+		}
+	}
+	return trimmed
+}
+
+// AppendContext adds lines as unchanged context at the end of the hunk
+func (dh *DiffHunk) AppendContext(lines []string) {
+	oldLine := dh.OldStart + dh.OldLines
+	newLine := dh.NewStart + dh.NewLines
+	position := len(dh.Lines)
+	if position > 0 {
+		position = dh.Lines[position-1].PositionInHunk + 1
+	}
+	// Cap the slice so appending copies it instead of writing into the
+	// lines of the hunk it was trimmed from
+	dh.Lines = dh.Lines[:len(dh.Lines):len(dh.Lines)]
+	for i, text := range lines {
+		dh.Lines = append(dh.Lines, &DiffLine{
+			Type:           Context,
+			OldLineNumber:  oldLine + i,
+			NewLineNumber:  newLine + i,
+			Text:           text,
+			PositionInHunk: position + i,
+		})
+	}
+	dh.OldLines += len(lines)
+	dh.NewLines += len(lines)
+}
@@ -489,3 +489,42 @@ func TestGetZeroBased(t *testing.T) {
 		})
 	}
 }
+
+func TestTrimBeforeAndAppendContext(t *testing.T) {
+	hunk := "@@ -10,5 +10,5 @@\n a\n b\n-c\n+C\n d\n e"
+	dh, err := ParseDiffHunk(hunk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := dh.String(); got != hunk {
+		t.Errorf("String() = %q, want %q", got, hunk)
+	}
+
+	tests := []struct {
+		name   string
+		idx, n int
+		after  []string
+		want   string
+	}{
+		{name: "keep everything", idx: 5, n: 10, want: hunk},
+		{name: "one line above the last", idx: 5, n: 1, want: "@@ -13,2 +13,2 @@\n d\n e"},
+		{name: "trim through the change", idx: 3, n: 1, want: "@@ -12,3 +12,3 @@\n-c\n+C\n d\n e"},
+		{name: "only the last line", idx: 5, n: 0, want: "@@ -14,1 +14,1 @@\n e"},
+		{name: "expanded below", idx: 5, n: 0, after: []string{"f", "g"}, want: "@@ -14,3 +14,3 @@\n e\n f\n g"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trimmed := dh.TrimBefore(tt.idx, tt.n)
+			trimmed.AppendContext(tt.after)
+			if got := trimmed.String(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := dh.String(); got != hunk {
+		t.Errorf("original hunk changed to %q", got)
+	}
+}
@@ -3,30 +3,40 @@ package github
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/chmouel/gh-prreview/pkg/diffposition"
+	"github.com/chmouel/gh-prreview/pkg/log"
 	"github.com/chmouel/gh-prreview/pkg/parser"
 	"github.com/cli/go-gh/v2"
+	"github.com/cli/go-gh/v2/pkg/auth"
+	"golang.org/x/sync/errgroup"
 )
 
 type Client struct {
-	repo  string
-	debug bool
+	repo     string
+	host     string
+	login    string
+	progress func(stage string)
 }
 
 type ReviewComment struct {
 	ID                int64
 	ThreadID          string // GraphQL node ID for resolving the thread
+	ReviewID          int64  // Review the comment was submitted with
 	Path              string
 	Line              int
 	Body              string
 	Author            string
 	HasSuggestion     bool
 	SuggestedCode     string
+	IsDeletion        bool // Empty suggestion: the commented lines are to be deleted
 	OriginalLine      int
 	OriginalLines     int
 	StartLine         int
@@ -35,11 +45,15 @@ type ReviewComment struct {
 	OriginalEndLine   int
 	DiffHunk          string
 	DiffSide          diffposition.DiffSide
-	SubjectType       string
+	OriginalCommitID  string // Commit the comment was made against
+	SubjectType       string // "line" or "file" from GitHub, replaced by "resolved" for resolved threads
+	IsFileLevel       bool   // Comment on the whole file, without a line
 	HTMLURL           string
 	CreatedAt         time.Time
+	UpdatedAt         time.Time // Last edit of the comment body
 	IsOutdated        bool
 	ThreadComments    []ThreadComment
+	Reactions         []Reaction
 }
 
 type ThreadComment struct {
@@ -48,6 +62,8 @@ type ThreadComment struct {
 	Author    string
 	HTMLURL   string
 	CreatedAt time.Time
+	UpdatedAt time.Time
+	Reactions []Reaction
 }
 
 // PullRequest represents a GitHub pull request with display-relevant fields
@@ -55,24 +71,59 @@ type PullRequest struct {
 	Number         int
 	Title          string
 	Author         string
-	State          string
+	State          string // OPEN, CLOSED or MERGED
 	IsDraft        bool
 	HeadRefName    string
 	ReviewDecision string // APPROVED, CHANGES_REQUESTED, REVIEW_REQUIRED, etc.
 }
 
+// StateLabel returns "merged" or "closed" for a pull request that is no longer
+// open, and an empty string for an open one
+func (pr *PullRequest) StateLabel() string {
+	if pr.State == "" || pr.State == "OPEN" {
+		return ""
+	}
+	return strings.ToLower(pr.State)
+}
+
+// PR states accepted by ListPRs
+const (
+	PRStateOpen   = "open"
+	PRStateClosed = "closed" // closed or merged
+	PRStateAll    = "all"
+)
+
+// prStatesArgument returns the GraphQL states: argument listing the pull
+// requests in state
+func prStatesArgument(state string) (string, error) {
+	switch state {
+	case PRStateOpen:
+		return "[OPEN]", nil
+	case PRStateClosed:
+		return "[CLOSED, MERGED]", nil
+	case PRStateAll:
+		return "[OPEN, CLOSED, MERGED]", nil
+	default:
+		return "", fmt.Errorf("invalid pull request state %q, use open, closed or all", state)
+	}
+}
+
 // IsResolved returns true if the comment thread has been marked as resolved/done
 func (rc *ReviewComment) IsResolved() bool {
 	return rc.SubjectType == "resolved"
 }
 
+// NewClient creates a client for the default gh host: $GH_HOST when set,
+// otherwise the host gh is authenticated against (github.com by default)
 func NewClient() *Client {
-	return &Client{}
+	host, _ := auth.DefaultHost()
+	return &Client{host: host}
 }
 
-// SetDebug enables or disables debug output
-func (c *Client) SetDebug(debug bool) {
-	c.debug = debug
+// SetProgressFunc registers a callback invoked as FetchReviewComments moves
+// through its stages, so callers can show progress on large pull requests
+func (c *Client) SetProgressFunc(progress func(stage string)) {
+	c.progress = progress
 }
 
 // SetRepo sets the repository to use (format: "owner/repo")
@@ -80,15 +131,55 @@ func (c *Client) SetRepo(repo string) {
 	c.repo = repo
 }
 
+// SetHost sets the GitHub hostname (e.g. "github.example.com" for GitHub Enterprise)
+func (c *Client) SetHost(host string) {
+	c.host = host
+}
+
+// Host returns the GitHub hostname the client talks to
+func (c *Client) Host() string {
+	if c.host == "" {
+		return "github.com"
+	}
+	return c.host
+}
+
+// BaseWebURL returns the web URL of the GitHub host, e.g. "https://github.com"
+func (c *Client) BaseWebURL() string {
+	return "https://" + c.Host()
+}
+
+// ghExec runs a gh command; tests replace it to fake API responses
+var ghExec = gh.Exec
+
+// ghAPI runs `gh api` against the client's host. A rate-limited call fails
+// with a *RateLimitError.
+func (c *Client) ghAPI(args ...string) (stdOut, stdErr bytes.Buffer, err error) {
+	stdOut, stdErr, err = ghExec(append([]string{"api", "--hostname", c.Host()}, args...)...)
+	if err != nil {
+		resource := "core"
+		if slices.Contains(args, "graphql") {
+			resource = "graphql"
+		}
+		err = c.classifyGHError(err, resource, stdOut.String()+stdErr.String())
+	}
+	return stdOut, stdErr, err
+}
+
 // GetRepo returns the current repository (format: "owner/repo")
 func (c *Client) GetRepo() (string, error) {
 	return c.getRepo()
 }
 
-// debugLog prints debug messages if debug mode is enabled
+// debugLog prints debug messages at the debug log level
 func (c *Client) debugLog(format string, args ...any) {
-	if c.debug {
-		fmt.Fprintf(os.Stderr, "[DEBUG] "+format+"\n", args...)
+	log.Debugf(format, args...)
+}
+
+// reportProgress forwards a stage description to the progress callback, if any
+func (c *Client) reportProgress(stage string) {
+	if c.progress != nil {
+		c.progress(stage)
 	}
 }
 
@@ -124,9 +215,18 @@ func (c *Client) getReviewThreads(repo string, prNumber int) (map[int64]*ThreadI
 									body
 									url
 									createdAt
+									updatedAt
 									author {
 										login
 									}
+									reactions(first: 50) {
+										nodes {
+											content
+											user {
+												login
+											}
+										}
+									}
 								}
 							}
 						}
@@ -138,7 +238,7 @@ func (c *Client) getReviewThreads(repo string, prNumber int) (map[int64]*ThreadI
 
 	c.debugLog("GraphQL query: %s", query)
 
-	stdOut, _, err := gh.Exec("api", "graphql", "-f", fmt.Sprintf("query=%s", query))
+	stdOut, _, err := c.ghAPI("graphql", "-f", fmt.Sprintf("query=%s", query))
 	if err != nil {
 		c.debugLog("GraphQL query failed: %v", err)
 		return nil, err
@@ -160,9 +260,18 @@ func (c *Client) getReviewThreads(repo string, prNumber int) (map[int64]*ThreadI
 									Body       string    `json:"body"`
 									URL        string    `json:"url"`
 									CreatedAt  time.Time `json:"createdAt"`
+									UpdatedAt  time.Time `json:"updatedAt"`
 									Author     struct {
 										Login string `json:"login"`
 									} `json:"author"`
+									Reactions struct {
+										Nodes []struct {
+											Content string `json:"content"`
+											User    struct {
+												Login string `json:"login"`
+											} `json:"user"`
+										} `json:"nodes"`
+									} `json:"reactions"`
 								} `json:"nodes"`
 							} `json:"comments"`
 						} `json:"nodes"`
@@ -174,9 +283,7 @@ func (c *Client) getReviewThreads(repo string, prNumber int) (map[int64]*ThreadI
 
 	if err := json.Unmarshal(stdOut.Bytes(), &result); err != nil {
 		c.debugLog("Failed to parse GraphQL response: %v", err)
-		if c.debug {
-			fmt.Fprintf(os.Stderr, "[DEBUG] Raw response: %s\n", stdOut.String())
-		}
+		c.debugLog("Raw response: %s", stdOut.String())
 		return nil, fmt.Errorf("failed to parse GraphQL response: %w", err)
 	}
 
@@ -199,12 +306,21 @@ func (c *Client) getReviewThreads(repo string, prNumber int) (map[int64]*ThreadI
 		for j, comment := range thread.Comments.Nodes {
 			c.debugLog("  Comment %d: ID=%d, author=%s, body_len=%d",
 				j, comment.DatabaseID, comment.Author.Login, len(comment.Body))
+			var reactions []Reaction
+			for _, reaction := range comment.Reactions.Nodes {
+				reactions = append(reactions, Reaction{
+					Content: graphQLReactionContents[reaction.Content],
+					User:    reaction.User.Login,
+				})
+			}
 			threadComments = append(threadComments, ThreadComment{
 				ID:        comment.DatabaseID,
 				Body:      comment.Body,
 				Author:    comment.Author.Login,
 				HTMLURL:   comment.URL,
 				CreatedAt: comment.CreatedAt,
+				UpdatedAt: comment.UpdatedAt,
+				Reactions: reactions,
 			})
 		}
 
@@ -238,9 +354,13 @@ func (c *Client) getRepo() (string, error) {
 		return c.repo, nil
 	}
 
-	stdOut, _, err := gh.Exec("repo", "view", "--json", "nameWithOwner", "--jq", ".nameWithOwner")
+	stdOut, stdErr, err := ghExec("repo", "view", "--json", "nameWithOwner", "--jq", ".nameWithOwner")
 	if err != nil {
-		return "", fmt.Errorf("not in a GitHub repository (or no remote configured)")
+		var rateErr *RateLimitError
+		if errors.As(c.classifyGHError(err, "graphql", stdErr.String()), &rateErr) {
+			return "", rateErr
+		}
+		return "", ErrNotInRepo
 	}
 
 	c.repo = strings.TrimSpace(stdOut.String())
@@ -248,9 +368,16 @@ func (c *Client) getRepo() (string, error) {
 }
 
 func (c *Client) GetCurrentBranchPR() (int, error) {
-	stdOut, _, err := gh.Exec("pr", "view", "--json", "number", "--jq", ".number")
+	stdOut, stdErr, err := ghExec("pr", "view", "--json", "number", "--jq", ".number")
 	if err != nil {
-		return 0, fmt.Errorf("no PR found for current branch (use: gh prreview list <PR_NUMBER>)")
+		var rateErr *RateLimitError
+		if errors.As(c.classifyGHError(err, "graphql", stdErr.String()), &rateErr) {
+			return 0, rateErr
+		}
+		if isNotInRepoOutput(stdOut.String() + stdErr.String()) {
+			return 0, ErrNotInRepo
+		}
+		return 0, fmt.Errorf("%w for the current branch (use: gh prreview list <PR_NUMBER>)", ErrPRNotFound)
 	}
 
 	var prNumber int
@@ -261,8 +388,116 @@ func (c *Client) GetCurrentBranchPR() (int, error) {
 	return prNumber, nil
 }
 
-// ListOpenPRs fetches all open pull requests for the repository
-func (c *Client) ListOpenPRs() ([]*PullRequest, error) {
+// CurrentUser returns the login of the authenticated user. The lookup is
+// cached for the lifetime of the client.
+func (c *Client) CurrentUser() (string, error) {
+	if c.login != "" {
+		return c.login, nil
+	}
+
+	stdOut, _, err := c.ghAPI("user", "--jq", ".login")
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch the current user: %w", err)
+	}
+
+	login := strings.TrimSpace(stdOut.String())
+	if login == "" {
+		return "", fmt.Errorf("could not determine the current user")
+	}
+	c.login = login
+	return login, nil
+}
+
+// GetPRAuthor returns the login of the pull request author
+func (c *Client) GetPRAuthor(prNumber int) (string, error) {
+	repo, err := c.getRepo()
+	if err != nil {
+		return "", err
+	}
+
+	stdOut, _, err := c.ghAPI(fmt.Sprintf("repos/%s/pulls/%d", repo, prNumber), "--jq", ".user.login")
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch PR #%d: %w", prNumber, err)
+	}
+
+	author := strings.TrimSpace(stdOut.String())
+	if author == "" {
+		return "", fmt.Errorf("could not determine the author of PR #%d", prNumber)
+	}
+	return author, nil
+}
+
+// GetPR fetches a single pull request
+func (c *Client) GetPR(prNumber int) (*PullRequest, error) {
+	repo, err := c.getRepo()
+	if err != nil {
+		return nil, err
+	}
+
+	stdOut, _, err := c.ghAPI(fmt.Sprintf("repos/%s/pulls/%d", repo, prNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch PR #%d: %w", prNumber, err)
+	}
+
+	var pr struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+		State  string `json:"state"`
+		Merged bool   `json:"merged"`
+		Draft  bool   `json:"draft"`
+		User   struct {
+			Login string `json:"login"`
+		} `json:"user"`
+		Head struct {
+			Ref string `json:"ref"`
+		} `json:"head"`
+	}
+	if err := json.Unmarshal(stdOut.Bytes(), &pr); err != nil {
+		return nil, fmt.Errorf("failed to parse PR #%d: %w", prNumber, err)
+	}
+
+	// REST reports merged pull requests as closed, unlike GraphQL
+	state := strings.ToUpper(pr.State)
+	if pr.Merged {
+		state = "MERGED"
+	}
+	return &PullRequest{
+		Number:      pr.Number,
+		Title:       pr.Title,
+		Author:      pr.User.Login,
+		State:       state,
+		IsDraft:     pr.Draft,
+		HeadRefName: pr.Head.Ref,
+	}, nil
+}
+
+// GetPRBaseRef returns the name of the branch the pull request targets
+func (c *Client) GetPRBaseRef(prNumber int) (string, error) {
+	repo, err := c.getRepo()
+	if err != nil {
+		return "", err
+	}
+
+	stdOut, _, err := c.ghAPI(fmt.Sprintf("repos/%s/pulls/%d", repo, prNumber), "--jq", ".base.ref")
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch PR #%d: %w", prNumber, err)
+	}
+
+	ref := strings.TrimSpace(stdOut.String())
+	if ref == "" {
+		return "", fmt.Errorf("could not determine the base branch of PR #%d", prNumber)
+	}
+	return ref, nil
+}
+
+// ListPRs fetches up to limit pull requests in state (PRStateOpen,
+// PRStateClosed or PRStateAll) for the repository, newest first. A limit of
+// zero or less fetches the default of 100.
+func (c *Client) ListPRs(limit int, state string) ([]*PullRequest, error) {
+	states, err := prStatesArgument(state)
+	if err != nil {
+		return nil, err
+	}
 	repo, err := c.getRepo()
 	if err != nil {
 		return nil, err
@@ -275,15 +510,30 @@ func (c *Client) ListOpenPRs() ([]*PullRequest, error) {
 	owner := parts[0]
 	name := parts[1]
 
-	c.debugLog("Fetching open PRs for %s", repo)
+	if limit <= 0 {
+		limit = 100
+	}
 
-	query := fmt.Sprintf(`
+	c.debugLog("Fetching up to %d %s PRs for %s", limit, state, repo)
+
+	prs := make([]*PullRequest, 0)
+	cursor := ""
+	for len(prs) < limit {
+		// GraphQL caps page sizes at 100
+		pageSize := min(limit-len(prs), 100)
+		after := ""
+		if cursor != "" {
+			after = fmt.Sprintf(`, after: "%s"`, cursor)
+		}
+
+		query := fmt.Sprintf(`
 		query {
 			repository(owner: "%s", name: "%s") {
-				pullRequests(first: 100, states: OPEN, orderBy: {field: CREATED_AT, direction: DESC}) {
+				pullRequests(first: %d%s, states: %s, orderBy: {field: CREATED_AT, direction: DESC}) {
 					nodes {
 						number
 						title
+						state
 						author {
 							login
 						}
@@ -291,75 +541,90 @@ func (c *Client) ListOpenPRs() ([]*PullRequest, error) {
 						headRefName
 						reviewDecision
 					}
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
 				}
 			}
 		}
-	`, owner, name)
+	`, owner, name, pageSize, after, states)
 
-	c.debugLog("GraphQL query: %s", query)
+		c.debugLog("GraphQL query: %s", query)
 
-	stdOut, _, err := gh.Exec("api", "graphql", "-f", fmt.Sprintf("query=%s", query))
-	if err != nil {
-		c.debugLog("GraphQL query failed: %v", err)
-		return nil, fmt.Errorf("failed to fetch pull requests: %w", err)
-	}
+		stdOut, _, err := c.ghAPI("graphql", "-f", fmt.Sprintf("query=%s", query))
+		if err != nil {
+			c.debugLog("GraphQL query failed: %v", err)
+			return nil, fmt.Errorf("failed to fetch pull requests: %w", err)
+		}
 
-	c.debugLog("GraphQL response length: %d bytes", len(stdOut.Bytes()))
+		c.debugLog("GraphQL response length: %d bytes", len(stdOut.Bytes()))
 
-	var result struct {
-		Data struct {
-			Repository struct {
-				PullRequests struct {
-					Nodes []struct {
-						Number int    `json:"number"`
-						Title  string `json:"title"`
-						Author struct {
-							Login string `json:"login"`
-						} `json:"author"`
-						IsDraft        bool   `json:"isDraft"`
-						HeadRefName    string `json:"headRefName"`
-						ReviewDecision string `json:"reviewDecision"`
-					} `json:"nodes"`
-				} `json:"pullRequests"`
-			} `json:"repository"`
-		} `json:"data"`
-	}
+		var result struct {
+			Data struct {
+				Repository struct {
+					PullRequests struct {
+						Nodes []struct {
+							Number int    `json:"number"`
+							Title  string `json:"title"`
+							State  string `json:"state"`
+							Author struct {
+								Login string `json:"login"`
+							} `json:"author"`
+							IsDraft        bool   `json:"isDraft"`
+							HeadRefName    string `json:"headRefName"`
+							ReviewDecision string `json:"reviewDecision"`
+						} `json:"nodes"`
+						PageInfo struct {
+							HasNextPage bool   `json:"hasNextPage"`
+							EndCursor   string `json:"endCursor"`
+						} `json:"pageInfo"`
+					} `json:"pullRequests"`
+				} `json:"repository"`
+			} `json:"data"`
+		}
 
-	if err := json.Unmarshal(stdOut.Bytes(), &result); err != nil {
-		c.debugLog("Failed to parse GraphQL response: %v", err)
-		if c.debug {
-			fmt.Fprintf(os.Stderr, "[DEBUG] Raw response: %s\n", stdOut.String())
+		if err := json.Unmarshal(stdOut.Bytes(), &result); err != nil {
+			c.debugLog("Failed to parse GraphQL response: %v", err)
+			c.debugLog("Raw response: %s", stdOut.String())
+			return nil, fmt.Errorf("failed to parse GraphQL response: %w", err)
 		}
-		return nil, fmt.Errorf("failed to parse GraphQL response: %w", err)
-	}
 
-	prs := make([]*PullRequest, 0, len(result.Data.Repository.PullRequests.Nodes))
-	for _, node := range result.Data.Repository.PullRequests.Nodes {
-		prs = append(prs, &PullRequest{
-			Number:         node.Number,
-			Title:          node.Title,
-			Author:         node.Author.Login,
-			IsDraft:        node.IsDraft,
-			HeadRefName:    node.HeadRefName,
-			ReviewDecision: node.ReviewDecision,
-		})
+		for _, node := range result.Data.Repository.PullRequests.Nodes {
+			prs = append(prs, &PullRequest{
+				Number:         node.Number,
+				Title:          node.Title,
+				Author:         node.Author.Login,
+				State:          node.State,
+				IsDraft:        node.IsDraft,
+				HeadRefName:    node.HeadRefName,
+				ReviewDecision: node.ReviewDecision,
+			})
+		}
+
+		pageInfo := result.Data.Repository.PullRequests.PageInfo
+		if !pageInfo.HasNextPage {
+			break
+		}
+		cursor = pageInfo.EndCursor
 	}
 
-	c.debugLog("Found %d open pull requests", len(prs))
+	c.debugLog("Found %d %s pull requests", len(prs), state)
 
 	return prs, nil
 }
 
 // DumpCommentsJSON returns raw JSON for the selected comment IDs. When commentIDs is empty, all
-// review comments for the PR are returned.
-func (c *Client) DumpCommentsJSON(prNumber int, commentIDs []int64) (string, error) {
+// review comments for the PR are returned. Comments found in threadIDs, which maps comment IDs
+// to the GraphQL ID of their thread, get it as an extra "threadId" field.
+func (c *Client) DumpCommentsJSON(prNumber int, commentIDs []int64, threadIDs map[int64]string) (string, error) {
 	repo, err := c.getRepo()
 	if err != nil {
 		return "", err
 	}
 
 	query := fmt.Sprintf("repos/%s/pulls/%d/comments", repo, prNumber)
-	stdOut, _, err := gh.Exec("api", query, "--paginate")
+	stdOut, _, err := c.ghAPI(query, "--paginate")
 	if err != nil {
 		return "", fmt.Errorf("failed to fetch review comments: %w", err)
 	}
@@ -377,20 +642,22 @@ func (c *Client) DumpCommentsJSON(prNumber int, commentIDs []int64) (string, err
 
 	selected := make([]json.RawMessage, 0)
 	for _, raw := range rawComments {
-		if includeAll {
-			selected = append(selected, raw)
-			continue
-		}
-
 		var comment struct {
 			ID int64 `json:"id"`
 		}
 		if err := json.Unmarshal(raw, &comment); err != nil {
+			if includeAll {
+				selected = append(selected, raw)
+			}
+			continue
+		}
+		if _, ok := wanted[comment.ID]; !includeAll && !ok {
 			continue
 		}
-		if _, ok := wanted[comment.ID]; ok {
-			selected = append(selected, raw)
+		if threadID, ok := threadIDs[comment.ID]; ok {
+			raw = withThreadID(raw, threadID)
 		}
+		selected = append(selected, raw)
 	}
 
 	if len(selected) == 0 {
@@ -416,150 +683,270 @@ func (c *Client) DumpCommentsJSON(prNumber int, commentIDs []int64) (string, err
 	return pretty.String(), nil
 }
 
-func (c *Client) FetchReviewComments(prNumber int) ([]*ReviewComment, error) {
-	repo, err := c.getRepo()
-	if err != nil {
-		return nil, err
+// withThreadID adds the "threadId" field at the start of the JSON object raw,
+// leaving the REST fields as they are
+func withThreadID(raw json.RawMessage, threadID string) json.RawMessage {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) < 2 || raw[0] != '{' {
+		return raw
 	}
+	quoted, _ := json.Marshal(threadID)
 
-	// First, get review threads with all comments using GraphQL
-	reviewThreads, err := c.getReviewThreads(repo, prNumber)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Warning: Could not fetch review threads: %v\n", err)
-		reviewThreads = make(map[int64]*ThreadInfo)
+	var b bytes.Buffer
+	b.WriteString(`{"threadId":`)
+	b.Write(quoted)
+	if rest := bytes.TrimSpace(raw[1:]); len(rest) > 0 && rest[0] != '}' {
+		b.WriteByte(',')
 	}
+	b.Write(raw[1:])
+	return b.Bytes()
+}
 
-	// Fetch review comments using gh api
-	query := fmt.Sprintf("repos/%s/pulls/%d/comments", repo, prNumber)
-	stdOut, _, err := gh.Exec("api", query, "--paginate")
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch review comments: %w", err)
+// ParseCommentsJSON decodes review comments from the JSON produced by
+// DumpCommentsJSON (the REST API representation), for use without network
+// access. Replies are attached to the comment they answer as ThreadComments.
+// Thread IDs are kept when the dump has them, but not the resolution state,
+// so every thread is considered unresolved.
+func ParseCommentsJSON(r io.Reader) ([]*ReviewComment, error) {
+	var rawComments []restReviewComment
+	if err := json.NewDecoder(r).Decode(&rawComments); err != nil {
+		return nil, fmt.Errorf("failed to parse review comments JSON: %w", err)
 	}
 
-	var rawComments []struct {
-		ID        int64  `json:"id"`
-		Path      string `json:"path"`
-		Line      int    `json:"line"`
-		StartLine int    `json:"start_line"`
-		Body      string `json:"body"`
-		DiffHunk  string `json:"diff_hunk"`
-		HTMLURL   string `json:"html_url"`
-		Side      string `json:"side"`
-		User      struct {
-			Login string `json:"login"`
-		} `json:"user"`
-		OriginalLine      int       `json:"original_line"`
-		OriginalStartLine int       `json:"original_start_line"`
-		SubjectType       string    `json:"subject_type"`
-		CreatedAt         time.Time `json:"created_at"`
+	comments := make([]*ReviewComment, 0, len(rawComments))
+	byID := make(map[int64]*ReviewComment, len(rawComments))
+	for i := range rawComments {
+		raw := &rawComments[i]
+		if raw.InReplyToID != 0 {
+			continue
+		}
+		comment := raw.toReviewComment()
+		comment.ThreadID = raw.ThreadID
+		byID[comment.ID] = comment
+		comments = append(comments, comment)
 	}
 
-	if err := json.Unmarshal(stdOut.Bytes(), &rawComments); err != nil {
-		return nil, fmt.Errorf("failed to parse review comments: %w", err)
+	for i := range rawComments {
+		raw := &rawComments[i]
+		if raw.InReplyToID == 0 {
+			continue
+		}
+		parent, ok := byID[raw.InReplyToID]
+		if !ok {
+			continue
+		}
+		parent.ThreadComments = append(parent.ThreadComments, ThreadComment{
+			ID:        raw.ID,
+			Body:      raw.Body,
+			Author:    raw.User.Login,
+			HTMLURL:   raw.HTMLURL,
+			CreatedAt: raw.CreatedAt,
+			UpdatedAt: raw.UpdatedAt,
+		})
+	}
+
+	return comments, nil
+}
+
+func (c *Client) FetchReviewComments(prNumber int) ([]*ReviewComment, error) {
+	return c.fetchReviewComments(prNumber, "")
+}
+
+// FetchReviewCommentsForPath returns the review comments of a pull request on
+// one file. The API has no server-side path filter, so every comment is still
+// downloaded, but the comments on other files are dropped before they are
+// converted and joined with their threads, which is most of the processing on
+// PRs with thousands of comments.
+func (c *Client) FetchReviewCommentsForPath(prNumber int, path string) ([]*ReviewComment, error) {
+	if path == "" {
+		return nil, fmt.Errorf("path is required")
+	}
+	return c.fetchReviewComments(prNumber, path)
+}
+
+// fetchReviewComments fetches the review comments of a pull request, only
+// those on path when it is not empty
+func (c *Client) fetchReviewComments(prNumber int, path string) ([]*ReviewComment, error) {
+	repo, err := c.getRepo()
+	if err != nil {
+		return nil, err
+	}
+
+	// The GraphQL review threads and the REST review comments are independent
+	// reads, so fetch them at the same time
+	c.reportProgress("Fetching review threads and comments")
+	var reviewThreads map[int64]*ThreadInfo
+	var threadsErr error
+	var rawComments []restReviewComment
+	var group errgroup.Group
+	group.Go(func() error {
+		// A failed threads fetch is not fatal, see below
+		reviewThreads, threadsErr = c.getReviewThreads(repo, prNumber)
+		return nil
+	})
+	group.Go(func() error {
+		query := fmt.Sprintf("repos/%s/pulls/%d/comments", repo, prNumber)
+		stdOut, _, err := c.ghAPI(query, "--paginate")
+		if errors.Is(err, errNotFound) {
+			return fmt.Errorf("%w: #%d in %s", ErrPRNotFound, prNumber, repo)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to fetch review comments: %w", err)
+		}
+		if err := json.Unmarshal(stdOut.Bytes(), &rawComments); err != nil {
+			return fmt.Errorf("failed to parse review comments: %w", err)
+		}
+		return nil
+	})
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+	if threadsErr != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Could not fetch review threads: %v\n", threadsErr)
+		reviewThreads = make(map[int64]*ThreadInfo)
 	}
 
 	c.debugLog("Processing %d review comments from REST API", len(rawComments))
+	c.reportProgress(fmt.Sprintf("Processing %d review comments", len(rawComments)))
 
 	// Get set of reply comment IDs to skip
 	replyIDs := c.getReplyCommentIDs(reviewThreads)
 
 	comments := make([]*ReviewComment, 0, len(rawComments))
 	for _, raw := range rawComments {
+		if path != "" && raw.Path != path {
+			continue
+		}
+
 		// Skip reply comments - they're already in ThreadComments
 		if replyIDs[raw.ID] {
 			c.debugLog("Comment %d: Skipping (it's a reply, not a top-level review comment)", raw.ID)
 			continue
 		}
 
-		// Check if this comment has thread info
-		threadInfo := reviewThreads[raw.ID]
-		subjectType := raw.SubjectType
-		var threadComments []ThreadComment
-		var threadID string
+		comment := raw.toReviewComment()
 
-		if threadInfo != nil {
+		// Check if this comment has thread info
+		if threadInfo := reviewThreads[raw.ID]; threadInfo != nil {
 			c.debugLog("Comment %d: Found thread with %d total comments, resolved=%v",
 				raw.ID, len(threadInfo.Comments), threadInfo.IsResolved)
-			threadID = threadInfo.ID
+			comment.ThreadID = threadInfo.ID
+			// IsFileLevel was taken from subject_type by toReviewComment, so
+			// marking the thread resolved here does not lose it
 			if threadInfo.IsResolved {
-				subjectType = "resolved"
+				comment.SubjectType = "resolved"
+			}
+			if len(threadInfo.Comments) > 0 {
+				comment.Reactions = threadInfo.Comments[0].Reactions
 			}
 			// Skip the first comment (it's the main review comment we're already showing)
 			if len(threadInfo.Comments) > 1 {
-				threadComments = threadInfo.Comments[1:]
-				c.debugLog("Comment %d: Adding %d thread replies", raw.ID, len(threadComments))
+				comment.ThreadComments = threadInfo.Comments[1:]
+				c.debugLog("Comment %d: Adding %d thread replies", raw.ID, len(comment.ThreadComments))
 			}
 		} else {
 			c.debugLog("Comment %d: No thread info found", raw.ID)
 		}
 
-		// Determine diff side
-		diffSide := diffposition.DiffSideRight
-		if raw.Side == "LEFT" {
-			diffSide = diffposition.DiffSideLeft
-		}
+		comments = append(comments, comment)
+	}
 
-		// Calculate position information
-		startLine := raw.Line
-		if raw.StartLine > 0 {
-			startLine = raw.StartLine
-		}
-		endLine := raw.Line
+	return comments, nil
+}
 
-		originalStartLine := raw.OriginalLine
-		if raw.OriginalStartLine > 0 {
-			originalStartLine = raw.OriginalStartLine
-		}
-		originalEndLine := raw.OriginalLine
+// restReviewComment is a review comment as returned by the REST API
+type restReviewComment struct {
+	ID          int64  `json:"id"`
+	InReplyToID int64  `json:"in_reply_to_id"`
+	ReviewID    int64  `json:"pull_request_review_id"`
+	ThreadID    string `json:"threadId"` // Only in DumpCommentsJSON output
+	Path        string `json:"path"`
+	Line        int    `json:"line"`
+	StartLine   int    `json:"start_line"`
+	Body        string `json:"body"`
+	DiffHunk    string `json:"diff_hunk"`
+	HTMLURL     string `json:"html_url"`
+	Side        string `json:"side"`
+	User        struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	OriginalLine      int       `json:"original_line"`
+	OriginalStartLine int       `json:"original_start_line"`
+	OriginalCommitID  string    `json:"original_commit_id"`
+	SubjectType       string    `json:"subject_type"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
 
-		// Calculate if comment is outdated
-		isOutdated := false
-		if raw.DiffHunk != "" {
-			pos, err := diffposition.CalculateCommentPosition(
-				raw.Line,
-				raw.OriginalLine,
-				raw.DiffHunk,
-				diffSide,
-			)
-			if err == nil {
-				isOutdated = pos.IsOutdated
-			}
-		}
+// toReviewComment converts the REST fields, without any thread information
+func (raw *restReviewComment) toReviewComment() *ReviewComment {
+	// Determine diff side
+	diffSide := diffposition.DiffSideRight
+	if raw.Side == "LEFT" {
+		diffSide = diffposition.DiffSideLeft
+	}
+
+	// Calculate position information
+	startLine := raw.Line
+	if raw.StartLine > 0 {
+		startLine = raw.StartLine
+	}
+	endLine := raw.Line
 
-		comment := &ReviewComment{
-			ID:                raw.ID,
-			ThreadID:          threadID,
-			Path:              raw.Path,
-			Line:              raw.Line,
-			StartLine:         startLine,
-			EndLine:           endLine,
-			Body:              raw.Body,
-			Author:            raw.User.Login,
-			DiffHunk:          raw.DiffHunk,
-			DiffSide:          diffSide,
-			OriginalLine:      raw.OriginalLine,
-			OriginalStartLine: originalStartLine,
-			OriginalEndLine:   originalEndLine,
-			SubjectType:       subjectType,
-			HTMLURL:           raw.HTMLURL,
-			CreatedAt:         raw.CreatedAt,
-			IsOutdated:        isOutdated,
-			ThreadComments:    threadComments,
-		}
-
-		// Check if the comment contains a suggestion
-		if suggestion := parser.ParseSuggestion(raw.Body); suggestion != "" {
-			comment.HasSuggestion = true
-			comment.SuggestedCode = suggestion
-
-			// Calculate how many lines the suggestion spans
-			comment.OriginalLines = calculateOriginalLines(raw.DiffHunk)
+	originalStartLine := raw.OriginalLine
+	if raw.OriginalStartLine > 0 {
+		originalStartLine = raw.OriginalStartLine
+	}
+	originalEndLine := raw.OriginalLine
+
+	// Calculate if comment is outdated
+	isOutdated := false
+	if raw.DiffHunk != "" {
+		pos, err := diffposition.CalculateCommentPosition(
+			raw.Line,
+			raw.OriginalLine,
+			raw.DiffHunk,
+			diffSide,
+		)
+		if err == nil {
+			isOutdated = pos.IsOutdated
 		}
+	}
 
-		comments = append(comments, comment)
+	comment := &ReviewComment{
+		ID:                raw.ID,
+		ReviewID:          raw.ReviewID,
+		Path:              raw.Path,
+		Line:              raw.Line,
+		StartLine:         startLine,
+		EndLine:           endLine,
+		Body:              raw.Body,
+		Author:            raw.User.Login,
+		DiffHunk:          raw.DiffHunk,
+		DiffSide:          diffSide,
+		OriginalLine:      raw.OriginalLine,
+		OriginalStartLine: originalStartLine,
+		OriginalEndLine:   originalEndLine,
+		OriginalCommitID:  raw.OriginalCommitID,
+		SubjectType:       raw.SubjectType,
+		IsFileLevel:       raw.SubjectType == "file",
+		HTMLURL:           raw.HTMLURL,
+		CreatedAt:         raw.CreatedAt,
+		UpdatedAt:         raw.UpdatedAt,
+		IsOutdated:        isOutdated,
 	}
 
-	return comments, nil
+	// Check if the comment contains a suggestion
+	if suggestion, ok := parser.FindSuggestion(raw.Body); ok {
+		comment.HasSuggestion = true
+		comment.SuggestedCode = suggestion
+		comment.IsDeletion = suggestion == ""
+
+		// Calculate how many lines the suggestion spans
+		comment.OriginalLines = calculateOriginalLines(raw.DiffHunk)
+	}
+
+	return comment
 }
 
 // calculateOriginalLines determines how many lines from the original file
@@ -602,7 +989,7 @@ func (c *Client) ResolveThread(threadID string) error {
 
 	c.debugLog("GraphQL mutation: %s (threadId=%s)", mutation, threadID)
 
-	stdOut, stdErr, err := gh.Exec("api", "graphql",
+	stdOut, stdErr, err := c.ghAPI("graphql",
 		"-f", fmt.Sprintf("query=%s", mutation),
 		"-F", fmt.Sprintf("threadId=%s", threadID))
 	if err != nil {
@@ -610,6 +997,9 @@ func (c *Client) ResolveThread(threadID string) error {
 		if stdErr.Len() > 0 {
 			c.debugLog("Stderr: %s", stdErr.String())
 		}
+		if errors.Is(err, errNotFound) {
+			return fmt.Errorf("%w: thread %s", ErrCommentNotFound, threadID)
+		}
 		return fmt.Errorf("failed to resolve thread: %w", err)
 	}
 
@@ -632,9 +1022,7 @@ func (c *Client) ResolveThread(threadID string) error {
 
 	if err := json.Unmarshal(stdOut.Bytes(), &result); err != nil {
 		c.debugLog("Failed to parse GraphQL response: %v", err)
-		if c.debug {
-			fmt.Fprintf(os.Stderr, "[DEBUG] Raw GraphQL response for ResolveThread: %s\n", stdOut.String())
-		}
+		c.debugLog("Raw GraphQL response for ResolveThread: %s", stdOut.String())
 		return fmt.Errorf("failed to parse response: %w", err)
 	}
 
@@ -650,6 +1038,78 @@ func (c *Client) ResolveThread(threadID string) error {
 	return nil
 }
 
+// UnsubscribeFromPR stops notifications for a pull request using GraphQL.
+// GitHub has no subscription per review thread, so this mutes the whole PR.
+func (c *Client) UnsubscribeFromPR(prNumber int) error {
+	repo, err := c.getRepo()
+	if err != nil {
+		return err
+	}
+
+	stdOut, _, err := c.ghAPI(fmt.Sprintf("repos/%s/pulls/%d", repo, prNumber), "--jq", ".node_id")
+	if err != nil {
+		return fmt.Errorf("failed to fetch PR #%d: %w", prNumber, err)
+	}
+	prID := strings.TrimSpace(stdOut.String())
+	if prID == "" {
+		return fmt.Errorf("could not determine the node ID of PR #%d", prNumber)
+	}
+
+	c.debugLog("Unsubscribing from PR #%d (%s)", prNumber, prID)
+
+	mutation := `mutation Unsubscribe($subscribableId: ID!) {
+		updateSubscription(input: {subscribableId: $subscribableId, state: UNSUBSCRIBED}) {
+			subscribable {
+				viewerSubscription
+			}
+		}
+	}`
+
+	stdOut, stdErr, err := c.ghAPI("graphql",
+		"-f", fmt.Sprintf("query=%s", mutation),
+		"-F", fmt.Sprintf("subscribableId=%s", prID))
+	if err != nil {
+		if stdErr.Len() > 0 {
+			c.debugLog("Stderr: %s", stdErr.String())
+		}
+		return fmt.Errorf("failed to unsubscribe from PR #%d: %w", prNumber, err)
+	}
+
+	var result struct {
+		Data struct {
+			UpdateSubscription struct {
+				Subscribable struct {
+					ViewerSubscription string `json:"viewerSubscription"`
+				} `json:"subscribable"`
+			} `json:"updateSubscription"`
+		} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(stdOut.Bytes(), &result); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	if len(result.Errors) > 0 {
+		return fmt.Errorf("GraphQL error: %s", result.Errors[0].Message)
+	}
+	if state := result.Data.UpdateSubscription.Subscribable.ViewerSubscription; state != "UNSUBSCRIBED" {
+		return fmt.Errorf("subscription to PR #%d is still %s", prNumber, strings.ToLower(state))
+	}
+
+	c.debugLog("Unsubscribed from PR #%d", prNumber)
+	return nil
+}
+
+// ResolveThreadAndUnsubscribe resolves a review thread, then stops the
+// notifications of its pull request. Nothing is muted if resolving fails.
+func (c *Client) ResolveThreadAndUnsubscribe(threadID string, prNumber int) error {
+	if err := c.ResolveThread(threadID); err != nil {
+		return err
+	}
+	return c.UnsubscribeFromPR(prNumber)
+}
+
 // UnresolveThread marks a review thread as unresolved using GraphQL
 func (c *Client) UnresolveThread(threadID string) error {
 	if threadID == "" {
@@ -671,12 +1131,15 @@ func (c *Client) UnresolveThread(threadID string) error {
 
 	c.debugLog("GraphQL mutation: %s", mutation)
 
-	stdOut, stdErr, err := gh.Exec("api", "graphql", "-f", fmt.Sprintf("query=%s", mutation))
+	stdOut, stdErr, err := c.ghAPI("graphql", "-f", fmt.Sprintf("query=%s", mutation))
 	if err != nil {
 		c.debugLog("GraphQL mutation failed: %v", err)
 		if stdErr.Len() > 0 {
 			c.debugLog("Stderr: %s", stdErr.String())
 		}
+		if errors.Is(err, errNotFound) {
+			return fmt.Errorf("%w: thread %s", ErrCommentNotFound, threadID)
+		}
 		return fmt.Errorf("failed to unresolve thread: %w", err)
 	}
 
@@ -747,41 +1210,84 @@ func (c *Client) ReplyToReviewComment(prNumber int, commentID int64, body string
 		return nil, fmt.Errorf("failed to close temporary file: %w", err)
 	}
 
-	stdOut, stdErr, err := gh.Exec("api", endpoint, "-X", "POST", "-F", fmt.Sprintf("body=@%s", tmpFile.Name()))
+	stdOut, stdErr, err := c.ghAPI(endpoint, "-X", "POST", "-F", fmt.Sprintf("body=@%s", tmpFile.Name()))
 	if err != nil {
 		c.debugLog("Failed to post review comment reply: %v", err)
 		if stdErr.Len() > 0 {
 			c.debugLog("Stderr: %s", stdErr.String())
 		}
+		if errors.Is(err, errNotFound) {
+			return nil, fmt.Errorf("%w: %d on PR #%d", ErrCommentNotFound, commentID, prNumber)
+		}
 		return nil, fmt.Errorf("failed to post review comment reply: %w", err)
 	}
 
-	var response struct {
-		ID        int64     `json:"id"`
-		Body      string    `json:"body"`
-		HTMLURL   string    `json:"html_url"`
-		CreatedAt time.Time `json:"created_at"`
-		User      struct {
-			Login string `json:"login"`
-		} `json:"user"`
+	var response replyResponse
+	if err := json.Unmarshal(stdOut.Bytes(), &response); err != nil {
+		c.debugLog("Raw response for ReplyToReviewComment: %s", stdOut.String())
+		return nil, fmt.Errorf("failed to parse API response: %w", err)
 	}
 
-	if err := json.Unmarshal(stdOut.Bytes(), &response); err != nil {
-		if c.debug {
-			fmt.Fprintf(os.Stderr, "[DEBUG] Raw response for ReplyToReviewComment: %s\n", stdOut.String())
+	c.debugLog("Reply created with ID %d", response.ID)
+
+	return response.toThreadComment(), nil
+}
+
+// ReplyToThreadComment posts a reply with in_reply_to set to commentID,
+// which can be any comment of a review thread, including a reply. Unlike
+// ReplyToReviewComment, it does not need the first comment of the thread.
+func (c *Client) ReplyToThreadComment(prNumber int, commentID int64, body string) (*ThreadComment, error) {
+	if commentID == 0 {
+		return nil, fmt.Errorf("comment ID is required")
+	}
+	if strings.TrimSpace(body) == "" {
+		return nil, fmt.Errorf("comment body cannot be empty")
+	}
+
+	repo, err := c.getRepo()
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("repos/%s/pulls/%d/comments", repo, prNumber)
+	c.debugLog("Posting reply in reply to comment %d on %s PR #%d", commentID, repo, prNumber)
+
+	payload := struct {
+		Body      string `json:"body"`
+		InReplyTo int64  `json:"in_reply_to"`
+	}{body, commentID}
+	var response replyResponse
+	if err := c.postJSON(endpoint, payload, &response); err != nil {
+		if errors.Is(err, errNotFound) {
+			return nil, fmt.Errorf("%w: %d on PR #%d", ErrCommentNotFound, commentID, prNumber)
 		}
-		return nil, fmt.Errorf("failed to parse API response: %w", err)
+		return nil, fmt.Errorf("failed to post review comment reply: %w", err)
 	}
 
 	c.debugLog("Reply created with ID %d", response.ID)
 
+	return response.toThreadComment(), nil
+}
+
+// replyResponse is the review comment GitHub returns for a new reply
+type replyResponse struct {
+	ID        int64     `json:"id"`
+	Body      string    `json:"body"`
+	HTMLURL   string    `json:"html_url"`
+	CreatedAt time.Time `json:"created_at"`
+	User      struct {
+		Login string `json:"login"`
+	} `json:"user"`
+}
+
+func (r replyResponse) toThreadComment() *ThreadComment {
 	return &ThreadComment{
-		ID:        response.ID,
-		Body:      response.Body,
-		Author:    response.User.Login,
-		HTMLURL:   response.HTMLURL,
-		CreatedAt: response.CreatedAt,
-	}, nil
+		ID:        r.ID,
+		Body:      r.Body,
+		Author:    r.User.Login,
+		HTMLURL:   r.HTMLURL,
+		CreatedAt: r.CreatedAt,
+	}
 }
 
 // AddReactionToComment adds an emoji reaction to a review comment.
@@ -813,7 +1319,7 @@ func (c *Client) AddReactionToComment(prNumber int, commentID int64, emoji strin
 	}
 
 	endpoint := fmt.Sprintf("repos/%s/pulls/comments/%d/reactions", repo, commentID)
-	stdOut, stdErr, err := gh.Exec("api", endpoint,
+	stdOut, stdErr, err := c.ghAPI(endpoint,
 		"-X", "POST",
 		"--header", "Accept: application/vnd.github.squirrel-girl-preview+json",
 		"--input", tmpFile.Name())
@@ -0,0 +1,75 @@
+package github
+
+import (
+	"errors"
+	"strings"
+)
+
+// Errors for the failures callers may want to tell apart, to be checked with
+// errors.Is. The errors returned wrap them with more context.
+var (
+	ErrNotInRepo       = errors.New("not in a GitHub repository (or no remote configured)")
+	ErrPRNotFound      = errors.New("pull request not found")
+	ErrCommentNotFound = errors.New("review comment not found")
+	ErrRateLimited     = errors.New("GitHub API rate limit exceeded")
+)
+
+// errNotFound matches the failure of a gh api call asking for something that
+// does not exist; the methods turn it into the error of what they looked for
+var errNotFound = errors.New("not found")
+
+// notFoundSignatures are what gh prints when the resource of a call does not
+// exist: the HTTP status of REST calls, and the NOT_FOUND errors of GraphQL
+var notFoundSignatures = []string{
+	"(HTTP 404)",
+	"Could not resolve to a",
+}
+
+// notFoundError marks err, the failure of a gh api call, as errNotFound while
+// keeping its message
+type notFoundError struct {
+	err error
+}
+
+func (e *notFoundError) Error() string {
+	return e.err.Error()
+}
+
+func (e *notFoundError) Unwrap() error {
+	return e.err
+}
+
+func (e *notFoundError) Is(target error) bool {
+	return target == errNotFound
+}
+
+// isNotFoundOutput reports whether output, of a failed gh call, says the
+// requested resource does not exist
+func isNotFoundOutput(output string) bool {
+	for _, signature := range notFoundSignatures {
+		if strings.Contains(output, signature) {
+			return true
+		}
+	}
+	return false
+}
+
+// notInRepoSignatures are what gh prints when it cannot tell the repository
+// from the working directory
+var notInRepoSignatures = []string{
+	"not a git repository",
+	"no git remotes found",
+	"none of the git remotes",
+}
+
+// isNotInRepoOutput reports whether output, of a failed gh call, says the
+// working directory is not a clone of a GitHub repository
+func isNotInRepoOutput(output string) bool {
+	output = strings.ToLower(output)
+	for _, signature := range notInRepoSignatures {
+		if strings.Contains(output, signature) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package github
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestGetRepoNotInRepo(t *testing.T) {
+	fakeGH(t, func(args []string) (string, error) {
+		return "fatal: not a git repository (or any of the parent directories): .git", errors.New("exit status 1")
+	})
+
+	if _, err := (&Client{}).GetRepo(); !errors.Is(err, ErrNotInRepo) {
+		t.Errorf("GetRepo() error = %v, want ErrNotInRepo", err)
+	}
+}
+
+func TestGetCurrentBranchPRErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   error
+	}{
+		{"no PR for the branch", `no pull requests found for branch "fix-it"`, ErrPRNotFound},
+		{"outside a clone", "fatal: not a git repository (or any of the parent directories): .git", ErrNotInRepo},
+		{"no remote", "no git remotes found", ErrNotInRepo},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeGH(t, func(args []string) (string, error) {
+				return tt.output, errors.New("exit status 1")
+			})
+
+			_, err := (&Client{}).GetCurrentBranchPR()
+			if !errors.Is(err, tt.want) {
+				t.Errorf("GetCurrentBranchPR() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFetchReviewCommentsPRNotFound(t *testing.T) {
+	fakeGH(t, func(args []string) (string, error) {
+		return "gh: Not Found (HTTP 404)", errors.New("exit status 1")
+	})
+	client := &Client{repo: "owner/repo"}
+
+	_, err := client.FetchReviewComments(999)
+	if !errors.Is(err, ErrPRNotFound) {
+		t.Fatalf("FetchReviewComments() error = %v, want ErrPRNotFound", err)
+	}
+	if !strings.Contains(err.Error(), "#999 in owner/repo") {
+		t.Errorf("error = %q, want it to name the PR", err)
+	}
+}
+
+func TestReplyAndResolveCommentNotFound(t *testing.T) {
+	client := &Client{repo: "owner/repo"}
+
+	fakeGH(t, func(args []string) (string, error) {
+		return "gh: Not Found (HTTP 404)", errors.New("exit status 1")
+	})
+	if _, err := client.ReplyToReviewComment(7, 456, "Done"); !errors.Is(err, ErrCommentNotFound) {
+		t.Errorf("ReplyToReviewComment() error = %v, want ErrCommentNotFound", err)
+	}
+	if _, err := client.ReplyToThreadComment(7, 456, "Done"); !errors.Is(err, ErrCommentNotFound) {
+		t.Errorf("ReplyToThreadComment() error = %v, want ErrCommentNotFound", err)
+	}
+
+	fakeGH(t, func(args []string) (string, error) {
+		return `{"errors":[{"type":"NOT_FOUND","message":"Could not resolve to a node with the global id of 'PRRT_x'"}]}`, errors.New("exit status 1")
+	})
+	if err := client.ResolveThread("PRRT_x"); !errors.Is(err, ErrCommentNotFound) {
+		t.Errorf("ResolveThread() error = %v, want ErrCommentNotFound", err)
+	}
+	if err := client.UnresolveThread("PRRT_x"); !errors.Is(err, ErrCommentNotFound) {
+		t.Errorf("UnresolveThread() error = %v, want ErrCommentNotFound", err)
+	}
+}
+
+func TestOtherErrorsAreNotClassified(t *testing.T) {
+	fakeGH(t, func(args []string) (string, error) {
+		return "gh: Server Error (HTTP 500)", errors.New("exit status 1")
+	})
+	client := &Client{repo: "owner/repo"}
+
+	_, err := client.FetchReviewComments(7)
+	for _, target := range []error{ErrPRNotFound, ErrCommentNotFound, ErrNotInRepo, ErrRateLimited} {
+		if errors.Is(err, target) {
+			t.Errorf("FetchReviewComments() error = %v, should not match %v", err, target)
+		}
+	}
+}
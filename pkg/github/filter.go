@@ -0,0 +1,106 @@
+package github
+
+import (
+	"strings"
+	"time"
+)
+
+// IsAuthoredBy reports whether the comment was written by login. Logins are
+// compared case-insensitively and without the "[bot]" suffix, which the REST
+// API adds to app accounts but GraphQL does not, so "Copilot" and
+// "Copilot[bot]" both match.
+func (rc *ReviewComment) IsAuthoredBy(login string) bool {
+	return strings.EqualFold(trimBotSuffix(rc.Author), trimBotSuffix(login))
+}
+
+func trimBotSuffix(login string) string {
+	return strings.TrimSuffix(login, "[bot]")
+}
+
+// ExcludeAuthor returns the comments not authored by login (case-insensitive).
+// Replies are kept: only the top-level comment decides whether a thread is
+// someone else's feedback.
+func ExcludeAuthor(comments []*ReviewComment, login string) []*ReviewComment {
+	if login == "" {
+		return comments
+	}
+
+	filtered := make([]*ReviewComment, 0, len(comments))
+	for _, comment := range comments {
+		if !comment.IsAuthoredBy(login) {
+			filtered = append(filtered, comment)
+		}
+	}
+	return filtered
+}
+
+// FilterByReview returns the comments submitted with the review reviewID.
+// Replies belong to their own reviews, so threads are kept or dropped whole
+// based on their first comment.
+func FilterByReview(comments []*ReviewComment, reviewID int64) []*ReviewComment {
+	filtered := make([]*ReviewComment, 0, len(comments))
+	for _, comment := range comments {
+		if comment.ReviewID == reviewID {
+			filtered = append(filtered, comment)
+		}
+	}
+	return filtered
+}
+
+// FilterByAuthor returns the threads started by login, compared as
+// IsAuthoredBy does
+func FilterByAuthor(comments []*ReviewComment, login string) []*ReviewComment {
+	filtered := make([]*ReviewComment, 0, len(comments))
+	for _, comment := range comments {
+		if comment.IsAuthoredBy(login) {
+			filtered = append(filtered, comment)
+		}
+	}
+	return filtered
+}
+
+// FilterActiveSince returns the threads with activity at or after since: the
+// comment or one of its replies was created or edited then
+func FilterActiveSince(comments []*ReviewComment, since time.Time) []*ReviewComment {
+	filtered := make([]*ReviewComment, 0, len(comments))
+	for _, comment := range comments {
+		if activeSince(comment, since) {
+			filtered = append(filtered, comment)
+		}
+	}
+	return filtered
+}
+
+func activeSince(comment *ReviewComment, since time.Time) bool {
+	if !comment.CreatedAt.Before(since) || !comment.UpdatedAt.Before(since) {
+		return true
+	}
+	for _, reply := range comment.ThreadComments {
+		if !reply.CreatedAt.Before(since) || !reply.UpdatedAt.Before(since) {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterNeedsReply returns the unresolved threads waiting on login: the
+// latest comment, the last reply or the comment itself when there is none, was
+// written by someone else
+func FilterNeedsReply(comments []*ReviewComment, login string) []*ReviewComment {
+	filtered := make([]*ReviewComment, 0, len(comments))
+	for _, comment := range comments {
+		if !comment.IsResolved() && !strings.EqualFold(trimBotSuffix(comment.LastAuthor()), trimBotSuffix(login)) {
+			filtered = append(filtered, comment)
+		}
+	}
+	return filtered
+}
+
+// LastAuthor returns the author of the latest comment of the thread. Replies
+// are kept in the order they were posted.
+func (rc *ReviewComment) LastAuthor() string {
+	if len(rc.ThreadComments) == 0 {
+		return rc.Author
+	}
+	return rc.ThreadComments[len(rc.ThreadComments)-1].Author
+}
@@ -0,0 +1,134 @@
+package github
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExcludeAuthor(t *testing.T) {
+	comments := []*ReviewComment{
+		{ID: 1, Author: "me"},
+		{ID: 2, Author: "reviewer", ThreadComments: []ThreadComment{{ID: 20, Author: "me"}}},
+		{ID: 3, Author: "Me"},
+		{ID: 4, Author: "copilot-pull-request-reviewer[bot]"},
+	}
+
+	tests := []struct {
+		name  string
+		login string
+		want  []int64
+	}{
+		{"excludes own comments case-insensitively", "me", []int64{2, 4}},
+		{"keeps everything for an unknown login", "someone-else", []int64{1, 2, 3, 4}},
+		{"keeps everything for an empty login", "", []int64{1, 2, 3, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExcludeAuthor(comments, tt.login)
+			if len(got) != len(tt.want) {
+				t.Fatalf("ExcludeAuthor(%q) returned %d comments, want %d", tt.login, len(got), len(tt.want))
+			}
+			for i, comment := range got {
+				if comment.ID != tt.want[i] {
+					t.Errorf("ExcludeAuthor(%q)[%d].ID = %d, want %d", tt.login, i, comment.ID, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestIsAuthoredBy(t *testing.T) {
+	tests := []struct {
+		author string
+		login  string
+		want   bool
+	}{
+		{"Copilot", "Copilot", true},
+		{"Copilot", "copilot", true},
+		{"Copilot", "Copilot[bot]", true},
+		{"github-actions[bot]", "github-actions", true},
+		{"Copilot", "copilot-pull-request-reviewer", false},
+		{"alice", "bob", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.author+"/"+tt.login, func(t *testing.T) {
+			comment := &ReviewComment{Author: tt.author}
+			if got := comment.IsAuthoredBy(tt.login); got != tt.want {
+				t.Errorf("IsAuthoredBy(%q) on %q = %v, want %v", tt.login, tt.author, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterByReview(t *testing.T) {
+	comments := []*ReviewComment{
+		{ID: 1, ReviewID: 100},
+		{ID: 2, ReviewID: 200},
+		{ID: 3, ReviewID: 100},
+	}
+
+	got := FilterByReview(comments, 100)
+	if len(got) != 2 || got[0].ID != 1 || got[1].ID != 3 {
+		t.Errorf("FilterByReview(100) = %v, want comments 1 and 3", got)
+	}
+	if got := FilterByReview(comments, 300); len(got) != 0 {
+		t.Errorf("FilterByReview(300) returned %d comments, want none", len(got))
+	}
+}
+
+func TestFilterByAuthor(t *testing.T) {
+	comments := []*ReviewComment{
+		{ID: 1, Author: "Copilot[bot]"},
+		{ID: 2, Author: "reviewer", ThreadComments: []ThreadComment{{ID: 20, Author: "copilot"}}},
+		{ID: 3, Author: "copilot"},
+	}
+
+	got := FilterByAuthor(comments, "Copilot")
+	if len(got) != 2 || got[0].ID != 1 || got[1].ID != 3 {
+		t.Errorf("FilterByAuthor(Copilot) = %v, want the threads 1 and 3 started by Copilot", got)
+	}
+}
+
+func TestFilterActiveSince(t *testing.T) {
+	since := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	before := since.Add(-time.Hour)
+	after := since.Add(time.Hour)
+	comments := []*ReviewComment{
+		{ID: 1, CreatedAt: before, UpdatedAt: before},
+		{ID: 2, CreatedAt: after, UpdatedAt: after},
+		{ID: 3, CreatedAt: before, UpdatedAt: after},
+		{ID: 4, CreatedAt: before, UpdatedAt: before, ThreadComments: []ThreadComment{{ID: 40, CreatedAt: after, UpdatedAt: after}}},
+		{ID: 5, CreatedAt: since},
+	}
+
+	got := FilterActiveSince(comments, since)
+	var ids []int64
+	for _, comment := range got {
+		ids = append(ids, comment.ID)
+	}
+	if len(ids) != 4 || ids[0] != 2 || ids[1] != 3 || ids[2] != 4 || ids[3] != 5 {
+		t.Errorf("FilterActiveSince() kept %v, want [2 3 4 5]", ids)
+	}
+}
+
+func TestFilterNeedsReply(t *testing.T) {
+	comments := []*ReviewComment{
+		{ID: 1, Author: "reviewer"},
+		{ID: 2, Author: "reviewer", ThreadComments: []ThreadComment{{ID: 20, Author: "Me"}}},
+		{ID: 3, Author: "reviewer", ThreadComments: []ThreadComment{{ID: 30, Author: "me"}, {ID: 31, Author: "reviewer"}}},
+		{ID: 4, Author: "me"},
+		{ID: 5, Author: "reviewer", SubjectType: "resolved"},
+		{ID: 6, Author: "me", ThreadComments: []ThreadComment{{ID: 60, Author: "Copilot[bot]"}}},
+	}
+
+	got := FilterNeedsReply(comments, "me")
+	var ids []int64
+	for _, comment := range got {
+		ids = append(ids, comment.ID)
+	}
+	if len(ids) != 3 || ids[0] != 1 || ids[1] != 3 || ids[2] != 6 {
+		t.Errorf("FilterNeedsReply() kept %v, want [1 3 6]", ids)
+	}
+}
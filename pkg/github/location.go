@@ -0,0 +1,44 @@
+package github
+
+import "fmt"
+
+// LinesLabel returns the line the comment is on, or "start-end" for a comment
+// spanning several lines. Outdated comments that no longer map to a line of
+// the current diff fall back to their original lines. It is empty for
+// file-level comments.
+func (rc *ReviewComment) LinesLabel() string {
+	start, end := rc.StartLine, rc.EndLine
+	if end == 0 {
+		start, end = rc.OriginalStartLine, rc.OriginalEndLine
+	}
+	if end == 0 {
+		end = rc.Line
+	}
+	switch {
+	case end == 0:
+		return ""
+	case start > 0 && start < end:
+		return fmt.Sprintf("%d-%d", start, end)
+	default:
+		return fmt.Sprintf("%d", end)
+	}
+}
+
+// LocationLabel returns where the comment is, as "path:line" or
+// "path:start-end" for multi-line comments
+func (rc *ReviewComment) LocationLabel() string {
+	lines := rc.LinesLabel()
+	if lines == "" {
+		return rc.Path
+	}
+	return rc.Path + ":" + lines
+}
+
+// LocationDescription returns LocationLabel, or "file-level comment on
+// <path>" for comments on a whole file rather than on lines of it
+func (rc *ReviewComment) LocationDescription() string {
+	if rc.IsFileLevel {
+		return "file-level comment on " + rc.Path
+	}
+	return rc.LocationLabel()
+}
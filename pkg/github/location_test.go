@@ -0,0 +1,69 @@
+package github
+
+import "testing"
+
+func TestLocationLabel(t *testing.T) {
+	tests := []struct {
+		name    string
+		comment ReviewComment
+		want    string
+	}{
+		{
+			name:    "single line",
+			comment: ReviewComment{Path: "main.go", Line: 12, StartLine: 12, EndLine: 12},
+			want:    "main.go:12",
+		},
+		{
+			name:    "multi-line",
+			comment: ReviewComment{Path: "main.go", Line: 14, StartLine: 10, EndLine: 14},
+			want:    "main.go:10-14",
+		},
+		{
+			name: "outdated multi-line falls back to the original lines",
+			comment: ReviewComment{
+				Path: "main.go", IsOutdated: true,
+				OriginalLine: 8, OriginalStartLine: 6, OriginalEndLine: 8,
+			},
+			want: "main.go:6-8",
+		},
+		{
+			name: "outdated comment still mapped to a line",
+			comment: ReviewComment{
+				Path: "main.go", IsOutdated: true, Line: 20, StartLine: 20, EndLine: 20,
+				OriginalLine: 15, OriginalStartLine: 15, OriginalEndLine: 15,
+			},
+			want: "main.go:20",
+		},
+		{
+			name:    "only Line set",
+			comment: ReviewComment{Path: "main.go", Line: 3},
+			want:    "main.go:3",
+		},
+		{
+			name:    "file-level comment",
+			comment: ReviewComment{Path: "main.go", SubjectType: "file"},
+			want:    "main.go",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.comment.LocationLabel(); got != tt.want {
+				t.Errorf("LocationLabel() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocationDescription(t *testing.T) {
+	lineComment := ReviewComment{Path: "main.go", Line: 3}
+	if got := lineComment.LocationDescription(); got != "main.go:3" {
+		t.Errorf("LocationDescription() = %q, want %q", got, "main.go:3")
+	}
+
+	// Resolving the thread replaces SubjectType, the file-level flag stays
+	fileComment := ReviewComment{Path: "main.go", SubjectType: "resolved", IsFileLevel: true}
+	if got, want := fileComment.LocationDescription(), "file-level comment on main.go"; got != want {
+		t.Errorf("LocationDescription() = %q, want %q", got, want)
+	}
+}
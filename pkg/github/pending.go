@@ -0,0 +1,131 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// draftComment is a review comment in the comments array of a new review
+type draftComment struct {
+	Path string `json:"path"`
+	Line int    `json:"line"`
+	Side string `json:"side"`
+	Body string `json:"body"`
+}
+
+// PendingReview returns the pending review of the authenticated user on a
+// pull request, or nil when there is none. GitHub only lists a pending review
+// to its author, and allows one per user and pull request.
+func (c *Client) PendingReview(prNumber int) (*Review, error) {
+	reviews, err := c.ListReviews(prNumber)
+	if err != nil {
+		return nil, err
+	}
+	for i := range reviews {
+		if reviews[i].State == "PENDING" {
+			return &reviews[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// AddPendingReviewComment adds a comment on line of path (in the new version
+// of the file) to the user's pending review, starting one when there is none,
+// and returns the ID of the pending review. Nothing is visible to others until
+// the review is submitted with SubmitPendingReview.
+func (c *Client) AddPendingReviewComment(prNumber int, path string, line int, body string) (int64, error) {
+	repo, err := c.getRepo()
+	if err != nil {
+		return 0, err
+	}
+
+	pending, err := c.PendingReview(prNumber)
+	if err != nil {
+		return 0, err
+	}
+
+	if pending == nil {
+		// A review created without an event stays pending
+		c.debugLog("Starting a pending review on %s PR #%d", repo, prNumber)
+		payload := struct {
+			Comments []draftComment `json:"comments"`
+		}{
+			Comments: []draftComment{{Path: path, Line: line, Side: "RIGHT", Body: body}},
+		}
+		var response reviewResponse
+		if err := c.postJSON(fmt.Sprintf("repos/%s/pulls/%d/reviews", repo, prNumber), payload, &response); err != nil {
+			return 0, fmt.Errorf("failed to start pending review: %w", err)
+		}
+		return response.ID, nil
+	}
+
+	// The REST API cannot add comments to an existing review, GraphQL can
+	c.debugLog("Adding a comment to pending review %d", pending.ID)
+	mutation := `mutation AddPendingComment($reviewId: ID!, $path: String!, $line: Int!, $body: String!) {
+		addPullRequestReviewThread(input: {pullRequestReviewId: $reviewId, path: $path, line: $line, side: RIGHT, body: $body}) {
+			thread {
+				id
+			}
+		}
+	}`
+
+	stdOut, stdErr, err := c.ghAPI("graphql",
+		"-f", fmt.Sprintf("query=%s", mutation),
+		"-f", fmt.Sprintf("reviewId=%s", pending.NodeID),
+		"-f", fmt.Sprintf("path=%s", path),
+		"-F", fmt.Sprintf("line=%d", line),
+		"-f", fmt.Sprintf("body=%s", body))
+	if err != nil {
+		if stdErr.Len() > 0 {
+			c.debugLog("Stderr: %s", stdErr.String())
+		}
+		return 0, fmt.Errorf("failed to add comment to pending review: %w", err)
+	}
+
+	var result struct {
+		Data struct {
+			AddPullRequestReviewThread struct {
+				Thread struct {
+					ID string `json:"id"`
+				} `json:"thread"`
+			} `json:"addPullRequestReviewThread"`
+		} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(stdOut.Bytes(), &result); err != nil {
+		c.debugLog("Raw GraphQL response for AddPendingReviewComment: %s", stdOut.String())
+		return 0, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if len(result.Errors) > 0 {
+		return 0, fmt.Errorf("GraphQL error: %s", result.Errors[0].Message)
+	}
+	if result.Data.AddPullRequestReviewThread.Thread.ID == "" {
+		return 0, fmt.Errorf("comment was not added to pending review %d", pending.ID)
+	}
+	return pending.ID, nil
+}
+
+// SubmitPendingReview submits a pending review with event (APPROVE,
+// REQUEST_CHANGES or COMMENT) and an optional body
+func (c *Client) SubmitPendingReview(prNumber int, reviewID int64, event, body string) (*Review, error) {
+	repo, err := c.getRepo()
+	if err != nil {
+		return nil, err
+	}
+
+	c.debugLog("Submitting pending review %d on %s PR #%d as %s", reviewID, repo, prNumber, event)
+	payload := map[string]string{"event": event}
+	if body != "" {
+		payload["body"] = body
+	}
+
+	var response reviewResponse
+	endpoint := fmt.Sprintf("repos/%s/pulls/%d/reviews/%d/events", repo, prNumber, reviewID)
+	if err := c.postJSON(endpoint, payload, &response); err != nil {
+		return nil, fmt.Errorf("failed to submit pending review: %w", err)
+	}
+	review := response.toReview()
+	return &review, nil
+}
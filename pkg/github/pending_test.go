@@ -0,0 +1,125 @@
+package github
+
+import (
+	"encoding/json"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// readInput returns the request body a fake gh call was given with --input
+func readInput(t *testing.T, args []string) []byte {
+	t.Helper()
+	i := slices.Index(args, "--input")
+	if i < 0 || i+1 >= len(args) {
+		t.Fatalf("gh args = %v, want --input FILE", args)
+	}
+	data, err := os.ReadFile(args[i+1])
+	if err != nil {
+		t.Fatalf("reading request body: %v", err)
+	}
+	return data
+}
+
+func TestAddPendingReviewCommentStartsReview(t *testing.T) {
+	var payload struct {
+		Event    string         `json:"event"`
+		Comments []draftComment `json:"comments"`
+	}
+	calls := fakeGH(t, func(args []string) (string, error) {
+		if !slices.Contains(args, "POST") {
+			return `[{"id":100,"user":{"login":"alice"},"state":"APPROVED"}]`, nil
+		}
+		if err := json.Unmarshal(readInput(t, args), &payload); err != nil {
+			t.Fatalf("request body: %v", err)
+		}
+		return `{"id":500,"node_id":"PRR_500","state":"PENDING"}`, nil
+	})
+	client := &Client{repo: "owner/repo"}
+
+	reviewID, err := client.AddPendingReviewComment(7, "main.go", 12, "Typo")
+	if err != nil {
+		t.Fatalf("AddPendingReviewComment() error = %v", err)
+	}
+	if reviewID != 500 {
+		t.Errorf("AddPendingReviewComment() = %d, want the new review 500", reviewID)
+	}
+	if len(*calls) != 2 || !slices.Contains((*calls)[1], "repos/owner/repo/pulls/7/reviews") {
+		t.Errorf("gh calls = %v, want the reviews listing then a new review", *calls)
+	}
+	if payload.Event != "" {
+		t.Errorf("event = %q, want none so the review stays pending", payload.Event)
+	}
+	want := []draftComment{{Path: "main.go", Line: 12, Side: "RIGHT", Body: "Typo"}}
+	if !slices.Equal(payload.Comments, want) {
+		t.Errorf("comments = %+v, want %+v", payload.Comments, want)
+	}
+}
+
+func TestAddPendingReviewCommentExtendsReview(t *testing.T) {
+	calls := fakeGH(t, func(args []string) (string, error) {
+		if slices.Contains(args, "graphql") {
+			return `{"data":{"addPullRequestReviewThread":{"thread":{"id":"PRRT_1"}}}}`, nil
+		}
+		return `[{"id":100,"state":"COMMENTED"},{"id":500,"node_id":"PRR_500","state":"PENDING"}]`, nil
+	})
+	client := &Client{repo: "owner/repo"}
+
+	reviewID, err := client.AddPendingReviewComment(7, "main.go", 12, "Typo")
+	if err != nil {
+		t.Fatalf("AddPendingReviewComment() error = %v", err)
+	}
+	if reviewID != 500 {
+		t.Errorf("AddPendingReviewComment() = %d, want the pending review 500", reviewID)
+	}
+	if len(*calls) != 2 {
+		t.Fatalf("gh calls = %v, want the reviews listing then the mutation", *calls)
+	}
+	mutation := strings.Join((*calls)[1], " ")
+	for _, want := range []string{"addPullRequestReviewThread", "reviewId=PRR_500", "path=main.go", "line=12", "body=Typo"} {
+		if !strings.Contains(mutation, want) {
+			t.Errorf("mutation call %q does not contain %q", mutation, want)
+		}
+	}
+}
+
+func TestAddPendingReviewCommentGraphQLError(t *testing.T) {
+	fakeGH(t, func(args []string) (string, error) {
+		if slices.Contains(args, "graphql") {
+			return `{"errors":[{"message":"line must be part of the diff"}]}`, nil
+		}
+		return `[{"id":500,"node_id":"PRR_500","state":"PENDING"}]`, nil
+	})
+	client := &Client{repo: "owner/repo"}
+
+	_, err := client.AddPendingReviewComment(7, "main.go", 999, "Typo")
+	if err == nil || !strings.Contains(err.Error(), "line must be part of the diff") {
+		t.Errorf("AddPendingReviewComment() error = %v, want the GraphQL error", err)
+	}
+}
+
+func TestSubmitPendingReview(t *testing.T) {
+	var payload map[string]string
+	calls := fakeGH(t, func(args []string) (string, error) {
+		if err := json.Unmarshal(readInput(t, args), &payload); err != nil {
+			t.Fatalf("request body: %v", err)
+		}
+		return `{"id":500,"user":{"login":"alice"},"state":"COMMENTED","submitted_at":"2026-01-04T09:00:00Z"}`, nil
+	})
+	client := &Client{repo: "owner/repo"}
+
+	review, err := client.SubmitPendingReview(7, 500, "COMMENT", "A few nits")
+	if err != nil {
+		t.Fatalf("SubmitPendingReview() error = %v", err)
+	}
+	if len(*calls) != 1 || !slices.Contains((*calls)[0], "repos/owner/repo/pulls/7/reviews/500/events") {
+		t.Errorf("gh calls = %v, want a POST to the review events endpoint", *calls)
+	}
+	if payload["event"] != "COMMENT" || payload["body"] != "A few nits" {
+		t.Errorf("request body = %v, want event COMMENT and the body", payload)
+	}
+	if review.State != "COMMENTED" {
+		t.Errorf("SubmitPendingReview() state = %q, want COMMENTED", review.State)
+	}
+}
@@ -0,0 +1,106 @@
+package github
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimitError reports a gh call refused because the GitHub API rate limit
+// was exceeded
+type RateLimitError struct {
+	Secondary bool      // abuse/secondary limit, which has no fixed reset time
+	Reset     time.Time // zero when unknown
+	Err       error
+}
+
+func (e *RateLimitError) Error() string {
+	const hint = "check that gh is authenticated ('gh auth status') and that --repo points at the intended host"
+	switch {
+	case e.Secondary:
+		return "GitHub API secondary rate limit exceeded, wait a few minutes before retrying"
+	case e.Reset.IsZero():
+		return "GitHub API rate limit exceeded; " + hint
+	default:
+		return fmt.Sprintf("GitHub API rate limit exceeded, resets at %s; %s", e.Reset.Local().Format("15:04"), hint)
+	}
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return e.Err
+}
+
+// Is makes every rate limit match ErrRateLimited
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// rateLimitSignatures are the messages gh prints, on stderr for REST calls
+// and in the GraphQL errors on stdout, when a call is rate-limited
+var rateLimitSignatures = []string{
+	"api rate limit exceeded",
+	"rate_limited",
+}
+
+// secondaryRateLimitSignatures identify the secondary limits GitHub applies
+// to bursts of requests
+var secondaryRateLimitSignatures = []string{
+	"secondary rate limit",
+	"abuse detection",
+}
+
+// rateLimitKind reports whether the output of a failed gh call is a rate
+// limit, and whether it is a secondary one
+func rateLimitKind(output string) (limited, secondary bool) {
+	output = strings.ToLower(output)
+	for _, signature := range secondaryRateLimitSignatures {
+		if strings.Contains(output, signature) {
+			return true, true
+		}
+	}
+	for _, signature := range rateLimitSignatures {
+		if strings.Contains(output, signature) {
+			return true, false
+		}
+	}
+	return false, false
+}
+
+// classifyGHError turns err, the failure of a gh call with the given output,
+// into a *RateLimitError when the output shows a rate limit. The reset time of
+// resource (core for REST calls, graphql) is looked up on the rate_limit
+// endpoint, which does not count against the limit. A call for something that
+// does not exist is marked to match errNotFound; other errors are returned
+// unchanged.
+func (c *Client) classifyGHError(err error, resource, output string) error {
+	limited, secondary := rateLimitKind(output)
+	if !limited {
+		if isNotFoundOutput(output) {
+			return &notFoundError{err: err}
+		}
+		return err
+	}
+
+	rateErr := &RateLimitError{Secondary: secondary, Err: err}
+	if !secondary {
+		rateErr.Reset = c.rateLimitReset(resource)
+	}
+	return rateErr
+}
+
+// rateLimitReset returns when the limit of resource resets, or the zero time
+// when it cannot be found
+func (c *Client) rateLimitReset(resource string) time.Time {
+	stdOut, _, err := ghExec("api", "--hostname", c.Host(), "rate_limit",
+		"--jq", fmt.Sprintf(".resources.%s.reset", resource))
+	if err != nil {
+		c.debugLog("failed to fetch the rate limit reset time: %v", err)
+		return time.Time{}
+	}
+	reset, err := strconv.ParseInt(strings.TrimSpace(stdOut.String()), 10, 64)
+	if err != nil || reset <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(reset, 0)
+}
@@ -0,0 +1,81 @@
+package github
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRateLimitKind(t *testing.T) {
+	tests := []struct {
+		name          string
+		output        string
+		wantLimited   bool
+		wantSecondary bool
+	}{
+		{"REST", "gh: API rate limit exceeded for user ID 1234. (HTTP 403)", true, false},
+		{"GraphQL", `{"errors":[{"type":"RATE_LIMITED","message":"API rate limit exceeded"}]}`, true, false},
+		{"secondary", "gh: You have exceeded a secondary rate limit. Please wait a few minutes before you try again. (HTTP 403)", true, true},
+		{"abuse detection", "You have triggered an abuse detection mechanism.", true, true},
+		{"not found", "gh: Not Found (HTTP 404)", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limited, secondary := rateLimitKind(tt.output)
+			if limited != tt.wantLimited || secondary != tt.wantSecondary {
+				t.Errorf("rateLimitKind() = %v, %v, want %v, %v", limited, secondary, tt.wantLimited, tt.wantSecondary)
+			}
+		})
+	}
+}
+
+func TestGHAPIRateLimit(t *testing.T) {
+	reset := time.Date(2026, 3, 4, 15, 30, 0, 0, time.UTC)
+	calls := fakeGH(t, func(args []string) (string, error) {
+		if slices.Contains(args, "rate_limit") {
+			return "1772638200\n", nil
+		}
+		return `{"message":"API rate limit exceeded for user ID 1234."}`, errors.New("exit status 1")
+	})
+	client := &Client{repo: "owner/repo"}
+
+	_, err := client.ListReviews(7)
+	var rateErr *RateLimitError
+	if !errors.As(err, &rateErr) {
+		t.Fatalf("error = %v, want a *RateLimitError", err)
+	}
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("error = %v, want it to match ErrRateLimited", err)
+	}
+	if !rateErr.Reset.Equal(reset) {
+		t.Errorf("Reset = %v, want %v", rateErr.Reset, reset)
+	}
+	want := "resets at " + reset.Local().Format("15:04")
+	if !strings.Contains(err.Error(), want) {
+		t.Errorf("error = %q, want it to contain %q", err, want)
+	}
+
+	last := (*calls)[len(*calls)-1]
+	if !slices.Contains(last, ".resources.core.reset") {
+		t.Errorf("rate_limit call = %v, want the core resource", last)
+	}
+}
+
+func TestGHAPIOtherErrorsUnchanged(t *testing.T) {
+	calls := fakeGH(t, func(args []string) (string, error) {
+		return "", errors.New("exit status 1")
+	})
+	client := &Client{repo: "owner/repo"}
+
+	_, err := client.ListReviews(7)
+	var rateErr *RateLimitError
+	if err == nil || errors.As(err, &rateErr) {
+		t.Fatalf("error = %v, want a plain failure", err)
+	}
+	if len(*calls) != 1 {
+		t.Errorf("gh calls = %v, want no rate_limit lookup", *calls)
+	}
+}
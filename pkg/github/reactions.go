@@ -0,0 +1,103 @@
+package github
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Reaction is an emoji reaction left on a review comment
+type Reaction struct {
+	Content string // REST API name: +1, -1, laugh, confused, heart, hooray, rocket, eyes
+	User    string
+}
+
+// graphQLReactionContents maps GraphQL ReactionContent values to REST API names
+var graphQLReactionContents = map[string]string{
+	"THUMBS_UP":   "+1",
+	"THUMBS_DOWN": "-1",
+	"LAUGH":       "laugh",
+	"CONFUSED":    "confused",
+	"HEART":       "heart",
+	"HOORAY":      "hooray",
+	"ROCKET":      "rocket",
+	"EYES":        "eyes",
+}
+
+// reactionEmojis maps the emoji characters GitHub renders to REST API names
+var reactionEmojis = map[string]string{
+	"👍":  "+1",
+	"👎":  "-1",
+	"😄":  "laugh",
+	"😕":  "confused",
+	"❤️": "heart",
+	"❤":  "heart",
+	"🎉":  "hooray",
+	"🚀":  "rocket",
+	"👀":  "eyes",
+}
+
+// NormalizeReaction converts an emoji (🚀), a REST name (rocket, :rocket:) or a
+// GraphQL name (ROCKET) to the REST API reaction name.
+func NormalizeReaction(reaction string) (string, error) {
+	reaction = strings.TrimSpace(reaction)
+	if name, ok := reactionEmojis[reaction]; ok {
+		return name, nil
+	}
+
+	name := strings.Trim(reaction, ":")
+	if rest, ok := graphQLReactionContents[strings.ToUpper(name)]; ok {
+		return rest, nil
+	}
+	name = strings.ToLower(name)
+	for _, rest := range graphQLReactionContents {
+		if name == rest {
+			return rest, nil
+		}
+	}
+	if name == "thumbsup" {
+		return "+1", nil
+	}
+	if name == "thumbsdown" {
+		return "-1", nil
+	}
+
+	return "", fmt.Errorf("unsupported reaction %q (use one of 👍 👎 😄 😕 ❤️ 🎉 🚀 👀)", reaction)
+}
+
+// HasReactionFrom reports whether user reacted with content (a REST API name)
+// on the review comment or on any reply in its thread
+func (rc *ReviewComment) HasReactionFrom(user, content string) bool {
+	if hasReaction(rc.Reactions, user, content) {
+		return true
+	}
+	for _, reply := range rc.ThreadComments {
+		if hasReaction(reply.Reactions, user, content) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasReaction(reactions []Reaction, user, content string) bool {
+	for _, reaction := range reactions {
+		if reaction.Content == content && strings.EqualFold(reaction.User, user) {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterResolvableByReaction returns the unresolved comments on which user
+// reacted with content, i.e. the threads a "react to resolve" convention marks as done
+func FilterResolvableByReaction(comments []*ReviewComment, user, content string) []*ReviewComment {
+	var matches []*ReviewComment
+	for _, comment := range comments {
+		if comment.IsResolved() || comment.ThreadID == "" {
+			continue
+		}
+		if comment.HasReactionFrom(user, content) {
+			matches = append(matches, comment)
+		}
+	}
+	return matches
+}
@@ -0,0 +1,89 @@
+package github
+
+import "testing"
+
+func TestNormalizeReaction(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"🚀", "rocket", false},
+		{"rocket", "rocket", false},
+		{":rocket:", "rocket", false},
+		{"ROCKET", "rocket", false},
+		{"👍", "+1", false},
+		{"+1", "+1", false},
+		{"THUMBS_UP", "+1", false},
+		{"thumbsup", "+1", false},
+		{"❤️", "heart", false},
+		{" 🎉 ", "hooray", false},
+		{"🦄", "", true},
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := NormalizeReaction(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormalizeReaction(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeReaction(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterResolvableByReaction(t *testing.T) {
+	comments := []*ReviewComment{
+		{
+			ID:        1,
+			ThreadID:  "T1",
+			Reactions: []Reaction{{Content: "rocket", User: "prauthor"}},
+		},
+		{
+			ID:       2,
+			ThreadID: "T2",
+			ThreadComments: []ThreadComment{
+				{ID: 20, Author: "prauthor", Reactions: []Reaction{{Content: "rocket", User: "PRAuthor"}}},
+			},
+		},
+		{
+			ID:        3,
+			ThreadID:  "T3",
+			Reactions: []Reaction{{Content: "rocket", User: "reviewer"}},
+		},
+		{
+			ID:        4,
+			ThreadID:  "T4",
+			Reactions: []Reaction{{Content: "+1", User: "prauthor"}},
+		},
+		{
+			ID:          5,
+			ThreadID:    "T5",
+			SubjectType: "resolved",
+			Reactions:   []Reaction{{Content: "rocket", User: "prauthor"}},
+		},
+		{
+			ID:        6,
+			Reactions: []Reaction{{Content: "rocket", User: "prauthor"}},
+		},
+	}
+
+	got := FilterResolvableByReaction(comments, "prauthor", "rocket")
+
+	var ids []int64
+	for _, comment := range got {
+		ids = append(ids, comment.ID)
+	}
+	want := []int64{1, 2}
+	if len(ids) != len(want) {
+		t.Fatalf("FilterResolvableByReaction() = %v, want %v", ids, want)
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Errorf("FilterResolvableByReaction() = %v, want %v", ids, want)
+		}
+	}
+}
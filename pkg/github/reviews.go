@@ -0,0 +1,123 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Review is a review submitted on a pull request, grouping the review
+// comments posted with it
+type Review struct {
+	ID          int64
+	Author      string
+	State       string    // APPROVED, CHANGES_REQUESTED, COMMENTED, DISMISSED or PENDING
+	SubmittedAt time.Time // Zero for a pending review
+	NodeID      string    // GraphQL ID, needed to add comments to a pending review
+}
+
+// ListReviews returns the reviews of a pull request, oldest first
+func (c *Client) ListReviews(prNumber int) ([]Review, error) {
+	repo, err := c.getRepo()
+	if err != nil {
+		return nil, err
+	}
+
+	stdOut, _, err := c.ghAPI(fmt.Sprintf("repos/%s/pulls/%d/reviews", repo, prNumber), "--paginate")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch reviews: %w", err)
+	}
+
+	var raw []reviewResponse
+	if err := json.Unmarshal(stdOut.Bytes(), &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse reviews: %w", err)
+	}
+
+	reviews := make([]Review, 0, len(raw))
+	for i := range raw {
+		reviews = append(reviews, raw[i].toReview())
+	}
+	return reviews, nil
+}
+
+// reviewResponse is the REST representation of a review
+type reviewResponse struct {
+	ID     int64  `json:"id"`
+	NodeID string `json:"node_id"`
+	User   struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	State       string    `json:"state"`
+	SubmittedAt time.Time `json:"submitted_at"`
+}
+
+func (r *reviewResponse) toReview() Review {
+	return Review{
+		ID:          r.ID,
+		Author:      r.User.Login,
+		State:       r.State,
+		SubmittedAt: r.SubmittedAt,
+		NodeID:      r.NodeID,
+	}
+}
+
+// SubmitReview submits a review of a pull request. event is APPROVE,
+// REQUEST_CHANGES or COMMENT; body is optional for APPROVE.
+func (c *Client) SubmitReview(prNumber int, event, body string) (*Review, error) {
+	repo, err := c.getRepo()
+	if err != nil {
+		return nil, err
+	}
+
+	c.debugLog("Submitting %s review on %s PR #%d", event, repo, prNumber)
+	payload := map[string]string{"event": event}
+	if body != "" {
+		payload["body"] = body
+	}
+
+	var response reviewResponse
+	if err := c.postJSON(fmt.Sprintf("repos/%s/pulls/%d/reviews", repo, prNumber), payload, &response); err != nil {
+		return nil, fmt.Errorf("failed to submit review: %w", err)
+	}
+	review := response.toReview()
+	return &review, nil
+}
+
+// postJSON POSTs payload as the JSON request body to endpoint and decodes
+// the response into out. The payload goes through a file since gh api takes
+// nested fields only with --input.
+func (c *Client) postJSON(endpoint string, payload, out any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	tmpFile, err := os.CreateTemp("", "gh-prreview-request-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer func() {
+		_ = os.Remove(tmpFile.Name())
+	}()
+	if _, err := tmpFile.Write(data); err != nil {
+		_ = tmpFile.Close()
+		return fmt.Errorf("failed to write request: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary file: %w", err)
+	}
+
+	stdOut, stdErr, err := c.ghAPI(endpoint, "-X", "POST", "--input", tmpFile.Name())
+	if err != nil {
+		if stdErr.Len() > 0 {
+			c.debugLog("Stderr: %s", stdErr.String())
+		}
+		return err
+	}
+	if err := json.Unmarshal(stdOut.Bytes(), out); err != nil {
+		c.debugLog("Raw response for %s: %s", endpoint, stdOut.String())
+		return fmt.Errorf("failed to parse API response: %w", err)
+	}
+	return nil
+}
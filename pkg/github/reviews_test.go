@@ -0,0 +1,93 @@
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestListReviews(t *testing.T) {
+	calls := fakeGH(t, func(args []string) (string, error) {
+		return `[
+			{"id":100,"user":{"login":"alice"},"state":"CHANGES_REQUESTED","submitted_at":"2026-01-02T10:00:00Z"},
+			{"id":200,"user":{"login":"Copilot"},"state":"COMMENTED","submitted_at":"2026-01-03T12:30:00Z"}]`, nil
+	})
+	client := &Client{repo: "owner/repo"}
+
+	reviews, err := client.ListReviews(7)
+	if err != nil {
+		t.Fatalf("ListReviews() error = %v", err)
+	}
+	if len(*calls) != 1 || !slices.Contains((*calls)[0], "repos/owner/repo/pulls/7/reviews") {
+		t.Errorf("gh calls = %v, want the reviews listing", *calls)
+	}
+
+	want := []Review{
+		{ID: 100, Author: "alice", State: "CHANGES_REQUESTED", SubmittedAt: time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)},
+		{ID: 200, Author: "Copilot", State: "COMMENTED", SubmittedAt: time.Date(2026, 1, 3, 12, 30, 0, 0, time.UTC)},
+	}
+	if len(reviews) != len(want) {
+		t.Fatalf("ListReviews() returned %d reviews, want %d", len(reviews), len(want))
+	}
+	for i := range want {
+		if reviews[i].ID != want[i].ID || reviews[i].Author != want[i].Author ||
+			reviews[i].State != want[i].State || !reviews[i].SubmittedAt.Equal(want[i].SubmittedAt) {
+			t.Errorf("ListReviews()[%d] = %+v, want %+v", i, reviews[i], want[i])
+		}
+	}
+}
+
+func TestListReviewsFailure(t *testing.T) {
+	fakeGH(t, func(args []string) (string, error) {
+		return "", errors.New("HTTP 404")
+	})
+	client := &Client{repo: "owner/repo"}
+
+	if _, err := client.ListReviews(7); err == nil {
+		t.Fatal("ListReviews() error = nil, want the gh failure")
+	}
+}
+
+func TestSubmitReview(t *testing.T) {
+	var payload map[string]string
+	calls := fakeGH(t, func(args []string) (string, error) {
+		if err := json.Unmarshal(readInput(t, args), &payload); err != nil {
+			t.Fatalf("request body: %v", err)
+		}
+		return `{"id":300,"user":{"login":"bob"},"state":"APPROVED","submitted_at":"2026-01-04T09:00:00Z"}`, nil
+	})
+	client := &Client{repo: "owner/repo"}
+
+	review, err := client.SubmitReview(7, "APPROVE", "LGTM")
+	if err != nil {
+		t.Fatalf("SubmitReview() error = %v", err)
+	}
+	if len(*calls) != 1 || !slices.Contains((*calls)[0], "repos/owner/repo/pulls/7/reviews") ||
+		!slices.Contains((*calls)[0], "POST") {
+		t.Errorf("gh calls = %v, want a POST to the reviews endpoint", *calls)
+	}
+	if payload["event"] != "APPROVE" || payload["body"] != "LGTM" {
+		t.Errorf("request body = %v, want event APPROVE and body LGTM", payload)
+	}
+	if review.ID != 300 || review.Author != "bob" || review.State != "APPROVED" {
+		t.Errorf("SubmitReview() = %+v, want review 300 APPROVED by bob", review)
+	}
+}
+
+func TestSubmitReviewOmitsEmptyBody(t *testing.T) {
+	var body []byte
+	fakeGH(t, func(args []string) (string, error) {
+		body = readInput(t, args)
+		return `{"id":1,"state":"APPROVED"}`, nil
+	})
+	client := &Client{repo: "owner/repo"}
+
+	if _, err := client.SubmitReview(7, "APPROVE", ""); err != nil {
+		t.Fatalf("SubmitReview() error = %v", err)
+	}
+	if string(body) != `{"event":"APPROVE"}` {
+		t.Errorf("request body = %s, want only the event", body)
+	}
+}
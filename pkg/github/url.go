@@ -0,0 +1,42 @@
+package github
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ParseCommentURL extracts the host, the repository ("owner/repo"), PR number
+// and review comment ID from a comment URL as copied from the browser, e.g.
+// https://github.com/owner/repo/pull/123#discussion_r456789. The files view
+// form (.../pull/123/files#r456789) is accepted too.
+func ParseCommentURL(rawURL string) (host, repo string, pr int, commentID int64, err error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return "", "", 0, 0, fmt.Errorf("invalid comment URL: %s", rawURL)
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 4 || parts[2] != "pull" {
+		return "", "", 0, 0, fmt.Errorf("not a pull request URL: %s", rawURL)
+	}
+	pr, err = strconv.Atoi(parts[3])
+	if err != nil || pr <= 0 {
+		return "", "", 0, 0, fmt.Errorf("invalid PR number in URL: %s", rawURL)
+	}
+
+	id, ok := strings.CutPrefix(u.Fragment, "discussion_r")
+	if !ok {
+		id, ok = strings.CutPrefix(u.Fragment, "r")
+	}
+	if !ok {
+		return "", "", 0, 0, fmt.Errorf("URL does not point to a review comment (no #discussion_r<ID>): %s", rawURL)
+	}
+	commentID, err = strconv.ParseInt(id, 10, 64)
+	if err != nil || commentID <= 0 {
+		return "", "", 0, 0, fmt.Errorf("invalid comment ID in URL: %s", rawURL)
+	}
+
+	return u.Host, parts[0] + "/" + parts[1], pr, commentID, nil
+}
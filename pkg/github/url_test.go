@@ -0,0 +1,44 @@
+package github
+
+import "testing"
+
+func TestParseCommentURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		host    string
+		repo    string
+		pr      int
+		id      int64
+		wantErr bool
+	}{
+		{name: "conversation link", url: "https://github.com/owner/repo/pull/123#discussion_r456789", host: "github.com", repo: "owner/repo", pr: 123, id: 456789},
+		{name: "files view link", url: "https://github.com/owner/repo/pull/123/files#r456789", host: "github.com", repo: "owner/repo", pr: 123, id: 456789},
+		{name: "enterprise host", url: "https://github.example.com/org/app/pull/7#discussion_r1", host: "github.example.com", repo: "org/app", pr: 7, id: 1},
+		{name: "no fragment", url: "https://github.com/owner/repo/pull/123", wantErr: true},
+		{name: "issue comment", url: "https://github.com/owner/repo/pull/123#issuecomment-42", wantErr: true},
+		{name: "not a pull request", url: "https://github.com/owner/repo/issues/123#discussion_r1", wantErr: true},
+		{name: "bad PR number", url: "https://github.com/owner/repo/pull/abc#discussion_r1", wantErr: true},
+		{name: "bad comment ID", url: "https://github.com/owner/repo/pull/1#discussion_rx", wantErr: true},
+		{name: "plain ID", url: "456789", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			host, repo, pr, id, err := ParseCommentURL(tt.url)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseCommentURL(%q) = %s, %d, %d, want an error", tt.url, repo, pr, id)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseCommentURL(%q) returned error: %v", tt.url, err)
+			}
+			if host != tt.host || repo != tt.repo || pr != tt.pr || id != tt.id {
+				t.Errorf("ParseCommentURL(%q) = %s, %s, %d, %d, want %s, %s, %d, %d",
+					tt.url, host, repo, pr, id, tt.host, tt.repo, tt.pr, tt.id)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// Package log is the leveled logger behind the global -v/--verbose and
+// --debug flags. Messages go to stderr so they never mix with command output.
+package log
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"sync/atomic"
+)
+
+// Level is how much diagnostic output is printed
+type Level int32
+
+const (
+	// LevelQuiet prints nothing but what commands print themselves
+	LevelQuiet Level = iota
+	// LevelInfo adds notes on what was skipped or degraded (-v)
+	LevelInfo
+	// LevelDebug adds API traces and internal decisions (-vv or --debug)
+	LevelDebug
+)
+
+var (
+	level  atomic.Int32
+	mu     sync.Mutex
+	output io.Writer = os.Stderr
+)
+
+// LevelFromVerbosity maps the number of -v flags to a level; debug forces
+// LevelDebug
+func LevelFromVerbosity(verbosity int, debug bool) Level {
+	switch {
+	case debug || verbosity >= 2:
+		return LevelDebug
+	case verbosity == 1:
+		return LevelInfo
+	default:
+		return LevelQuiet
+	}
+}
+
+// SetLevel sets the level of the messages printed from now on
+func SetLevel(l Level) {
+	level.Store(int32(l))
+}
+
+// Enabled reports whether messages of level l are printed
+func Enabled(l Level) bool {
+	return Level(level.Load()) >= l
+}
+
+// SetOutput redirects the messages to w, returning the previous writer. Tests
+// use it to capture output.
+func SetOutput(w io.Writer) io.Writer {
+	mu.Lock()
+	defer mu.Unlock()
+	previous := output
+	output = w
+	return previous
+}
+
+// Infof prints a note at LevelInfo
+func Infof(format string, args ...any) {
+	logf(LevelInfo, "", format, args...)
+}
+
+// Debugf prints a [DEBUG] line at LevelDebug
+func Debugf(format string, args ...any) {
+	logf(LevelDebug, "[DEBUG] ", format, args...)
+}
+
+func logf(l Level, prefix, format string, args ...any) {
+	if !Enabled(l) {
+		return
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	fmt.Fprintf(output, prefix+format+"\n", args...)
+}
@@ -0,0 +1,57 @@
+package log
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestLevelFromVerbosity(t *testing.T) {
+	tests := []struct {
+		verbosity int
+		debug     bool
+		want      Level
+	}{
+		{0, false, LevelQuiet},
+		{1, false, LevelInfo},
+		{2, false, LevelDebug},
+		{3, false, LevelDebug},
+		{0, true, LevelDebug},
+	}
+
+	for _, tt := range tests {
+		if got := LevelFromVerbosity(tt.verbosity, tt.debug); got != tt.want {
+			t.Errorf("LevelFromVerbosity(%d, %v) = %d, want %d", tt.verbosity, tt.debug, got, tt.want)
+		}
+	}
+}
+
+func TestLevels(t *testing.T) {
+	var buf bytes.Buffer
+	previous := SetOutput(&buf)
+	t.Cleanup(func() {
+		SetOutput(previous)
+		SetLevel(LevelQuiet)
+	})
+
+	tests := []struct {
+		name  string
+		level Level
+		want  string
+	}{
+		{"quiet", LevelQuiet, ""},
+		{"info", LevelInfo, "Note: skipped\n"},
+		{"debug", LevelDebug, "Note: skipped\n[DEBUG] fetched 3\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			SetLevel(tt.level)
+			Infof("Note: %s", "skipped")
+			Debugf("fetched %d", 3)
+			if got := buf.String(); got != tt.want {
+				t.Errorf("output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,8 +5,15 @@ import (
 	"strings"
 )
 
-// Pre-compiled regex for suggestion parsing (avoids recompilation on each call)
-var suggestionRe = regexp.MustCompile("(?s)```suggestion\\s*\\n(.*?)```")
+// Pre-compiled regexes for suggestion parsing (avoids recompilation on each
+// call). The opening fence may be indented by up to three spaces, as in
+// Markdown, and carry trailing spaces or an info string after "suggestion"
+// (```suggestion go), as some tools emit. Fences can be longer than three
+// backticks: GitHub picks a longer one when the code itself has ``` lines.
+var (
+	openingFenceRe = regexp.MustCompile("^ {0,3}(`{3,})suggestion(?:[ \\t][^`]*)?$")
+	closingFenceRe = regexp.MustCompile("^(`{3,})[ \\t]*$")
+)
 
 // ParseSuggestion extracts the suggested code from a GitHub review comment body
 // GitHub suggestions are in the format:
@@ -14,25 +21,102 @@ var suggestionRe = regexp.MustCompile("(?s)```suggestion\\s*\\n(.*?)```")
 // suggested code here
 // ```
 func ParseSuggestion(body string) string {
-	matches := suggestionRe.FindStringSubmatch(body)
+	suggestion, _ := FindSuggestion(body)
+	return suggestion
+}
 
-	if len(matches) < 2 {
-		return ""
+// FindSuggestion is like ParseSuggestion, but also reports whether the body
+// has a suggestion block at all. An empty block is a suggestion to delete the
+// commented lines, which ParseSuggestion cannot tell from no suggestion.
+func FindSuggestion(body string) (string, bool) {
+	suggestions := findSuggestions(body, 1)
+	if len(suggestions) == 0 {
+		return "", false
 	}
-
-	return strings.TrimRight(matches[1], "\n")
+	return suggestions[0], true
 }
 
 // ParseMultipleSuggestions extracts all suggestions from a comment body
 func ParseMultipleSuggestions(body string) []string {
-	matches := suggestionRe.FindAllStringSubmatch(body, -1)
+	return findSuggestions(body, -1)
+}
 
-	suggestions := make([]string, 0, len(matches))
-	for _, match := range matches {
-		if len(match) >= 2 {
-			suggestions = append(suggestions, strings.TrimRight(match[1], "\n"))
-		}
+// RemoveSuggestions returns body with each suggestion block, fences
+// included, replaced by an empty line
+func RemoveSuggestions(body string) string {
+	lines := strings.Split(body, "\n")
+	blocks := suggestionBlocks(lines, -1)
+	if len(blocks) == 0 {
+		return body
+	}
+
+	kept := make([]string, 0, len(lines))
+	next := 0
+	for _, b := range blocks {
+		kept = append(kept, lines[next:b.open]...)
+		kept = append(kept, "")
+		next = b.close + 1
 	}
+	kept = append(kept, lines[next:]...)
+	return strings.Join(kept, "\n")
+}
+
+// findSuggestions returns the code of the first limit suggestion blocks of
+// body, or of all of them when limit is negative
+func findSuggestions(body string, limit int) []string {
+	lines := strings.Split(body, "\n")
+	blocks := suggestionBlocks(lines, limit)
 
+	suggestions := make([]string, 0, len(blocks))
+	for _, b := range blocks {
+		suggestions = append(suggestions, suggestionCode(lines[b.open+1:b.close]))
+	}
 	return suggestions
 }
+
+// block is a suggestion block, by the index of its opening and closing fence
+// lines
+type block struct {
+	open, close int
+}
+
+// suggestionBlocks finds the first limit suggestion blocks of lines, or all
+// of them when limit is negative. A block ends at the first line holding only
+// a fence at least as long as the opening one, at column 0, so shorter fences
+// and indented ones are part of the code. A block that is never closed is not
+// a suggestion.
+func suggestionBlocks(lines []string, limit int) []block {
+	var blocks []block
+	for i := 0; i < len(lines) && (limit < 0 || len(blocks) < limit); i++ {
+		opening := openingFenceRe.FindStringSubmatch(strings.TrimSuffix(lines[i], "\r"))
+		if opening == nil {
+			continue
+		}
+		end := closingFence(lines, i+1, len(opening[1]))
+		if end < 0 {
+			break
+		}
+		blocks = append(blocks, block{open: i, close: end})
+		i = end
+	}
+	return blocks
+}
+
+// closingFence returns the index of the first line from start that closes a
+// fence of n backticks, or -1
+func closingFence(lines []string, start, n int) int {
+	for i := start; i < len(lines); i++ {
+		closing := closingFenceRe.FindStringSubmatch(strings.TrimSuffix(lines[i], "\r"))
+		if closing != nil && len(closing[1]) >= n {
+			return i
+		}
+	}
+	return -1
+}
+
+// suggestionCode joins the lines of a suggestion block: CRLF line endings
+// become LF, and trailing empty lines are dropped
+func suggestionCode(lines []string) string {
+	code := strings.ReplaceAll(strings.Join(lines, "\n"), "\r\n", "\n")
+	return strings.TrimRight(code, "\r\n")
+}
@@ -47,6 +47,46 @@ func TestParseSuggestion(t *testing.T) {
 	}
 }
 
+func TestFindSuggestion(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		wantCode  string
+		wantFound bool
+	}{
+		{"suggestion", "```suggestion\nconst x = 1\n```", "const x = 1", true},
+		{"empty suggestion deletes lines", "Remove this:\n```suggestion\n```", "", true},
+		{"no suggestion", "Looks good", "", false},
+		{"language hint on the fence", "```suggestion go\nconst x = 1\n```", "const x = 1", true},
+		{"CRLF line endings", "Try:\r\n```suggestion\r\nconst x = 1\r\nconst y = 2\r\n```\r\n", "const x = 1\nconst y = 2", true},
+		{"trailing spaces on the fence", "```suggestion   \nconst x = 1\n```", "const x = 1", true},
+		{"leading blank line is kept", "```suggestion\n\nconst x = 1\n```", "\nconst x = 1", true},
+		{"other fence starting with suggestion", "```suggestions\nconst x = 1\n```", "", false},
+		{"unclosed block", "```suggestion\nconst x = 1\n", "", false},
+		{"indented opening fence", "  ```suggestion\nconst x = 1\n```", "const x = 1", true},
+		{"fence in the middle of a line", "See ```suggestion\nconst x = 1\n```", "", false},
+		{
+			"four-backtick fence around a markdown code block",
+			"````suggestion\nRun:\n\n```bash\nmake test\n```\n````",
+			"Run:\n\n```bash\nmake test\n```",
+			true,
+		},
+		{"indented fence does not close", "```suggestion\n- item\n  ```\n```", "- item\n  ```", true},
+		{"fence followed by text does not close", "```suggestion\nx := \"```go\"\n```", "x := \"```go\"", true},
+		{"longer closing fence closes", "```suggestion\nconst x = 1\n`````\n", "const x = 1", true},
+		{"shorter fence does not close", "`````suggestion\na\n````\nb\n`````", "a\n````\nb", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, found := FindSuggestion(tt.body)
+			if code != tt.wantCode || found != tt.wantFound {
+				t.Errorf("FindSuggestion() = %q, %v, want %q, %v", code, found, tt.wantCode, tt.wantFound)
+			}
+		})
+	}
+}
+
 func TestParseMultipleSuggestions(t *testing.T) {
 	body := `You have two options:
 
@@ -70,3 +110,13 @@ Option 2:
 		t.Errorf("Second suggestion = %q, want %q", suggestions[1], "const timeout = 60")
 	}
 }
+
+func TestParseMultipleSuggestionsNestedFences(t *testing.T) {
+	body := "````suggestion\n```go\nx := 1\n```\n````\nor\n```suggestion\ny := 2\n```"
+
+	got := ParseMultipleSuggestions(body)
+	want := []string{"```go\nx := 1\n```", "y := 2"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("ParseMultipleSuggestions() = %q, want %q", got, want)
+	}
+}
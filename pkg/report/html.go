@@ -0,0 +1,149 @@
+package report
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/alecthomas/chroma"
+	chromahtml "github.com/alecthomas/chroma/formatters/html"
+	"github.com/alecthomas/chroma/lexers"
+	"github.com/alecthomas/chroma/styles"
+	"github.com/chmouel/gh-prreview/pkg/github"
+	"github.com/chmouel/gh-prreview/pkg/ui"
+	"github.com/yuin/goldmark"
+)
+
+//go:embed templates/*.tmpl
+var embeddedTemplates embed.FS
+
+// Options holds the metadata shown in the report header
+type Options struct {
+	Repo        string
+	PRNumber    int
+	PRURL       string
+	GeneratedAt time.Time
+}
+
+// fileSection groups the comments of a single file
+type fileSection struct {
+	Path     string
+	Comments []*github.ReviewComment
+}
+
+// WriteHTML renders a self-contained HTML report of the review comments to w.
+// Comments are grouped per file (sorted by path, then line) into collapsible sections.
+func WriteHTML(w io.Writer, comments []*github.ReviewComment, opts Options) error {
+	tmplContent, err := embeddedTemplates.ReadFile("templates/report.html.tmpl")
+	if err != nil {
+		return fmt.Errorf("failed to load report template: %w", err)
+	}
+
+	tmpl, err := template.New("report").Funcs(template.FuncMap{
+		"markdown":        renderMarkdownHTML,
+		"highlight":       highlightHTML,
+		"stripSuggestion": ui.StripSuggestionBlock,
+		"formatTime": func(t time.Time) string {
+			if t.IsZero() {
+				return ""
+			}
+			return t.UTC().Format("2006-01-02 15:04 MST")
+		},
+	}).Parse(string(tmplContent))
+	if err != nil {
+		return fmt.Errorf("failed to parse report template: %w", err)
+	}
+
+	unresolved := 0
+	for _, comment := range comments {
+		if !comment.IsResolved() {
+			unresolved++
+		}
+	}
+
+	data := map[string]any{
+		"Repo":        opts.Repo,
+		"PRNumber":    opts.PRNumber,
+		"PRURL":       opts.PRURL,
+		"GeneratedAt": opts.GeneratedAt,
+		"Total":       len(comments),
+		"Unresolved":  unresolved,
+		"Files":       groupByFile(comments),
+	}
+
+	if err := tmpl.Execute(w, data); err != nil {
+		return fmt.Errorf("failed to render report: %w", err)
+	}
+	return nil
+}
+
+// groupByFile groups comments by path, sorting files by name and comments by line
+func groupByFile(comments []*github.ReviewComment) []fileSection {
+	byPath := make(map[string][]*github.ReviewComment)
+	var paths []string
+	for _, comment := range comments {
+		if _, ok := byPath[comment.Path]; !ok {
+			paths = append(paths, comment.Path)
+		}
+		byPath[comment.Path] = append(byPath[comment.Path], comment)
+	}
+	sort.Strings(paths)
+
+	sections := make([]fileSection, 0, len(paths))
+	for _, path := range paths {
+		fileComments := byPath[path]
+		sort.SliceStable(fileComments, func(i, j int) bool {
+			return fileComments[i].Line < fileComments[j].Line
+		})
+		sections = append(sections, fileSection{Path: path, Comments: fileComments})
+	}
+	return sections
+}
+
+// renderMarkdownHTML converts a comment body to HTML. Raw HTML in the body is
+// not passed through, so the output is safe to embed.
+func renderMarkdownHTML(text string) template.HTML {
+	var buf bytes.Buffer
+	if err := goldmark.Convert([]byte(text), &buf); err != nil {
+		return plainPre(text)
+	}
+	return template.HTML(buf.String())
+}
+
+// highlightHTML syntax highlights code using chroma with inline styles. The
+// lexer is picked from the file name, or from the language name when the
+// file name is empty (e.g. "diff").
+func highlightHTML(code, filename, language string) template.HTML {
+	var lexer chroma.Lexer
+	if filename != "" {
+		lexer = lexers.Match(filename)
+	}
+	if lexer == nil && language != "" {
+		lexer = lexers.Get(language)
+	}
+	if lexer == nil {
+		lexer = lexers.Fallback
+	}
+	lexer = chroma.Coalesce(lexer)
+
+	iterator, err := lexer.Tokenise(nil, code)
+	if err != nil {
+		return plainPre(code)
+	}
+
+	var buf bytes.Buffer
+	formatter := chromahtml.New(chromahtml.WithClasses(false), chromahtml.TabWidth(4))
+	if err := formatter.Format(&buf, styles.Get("github"), iterator); err != nil {
+		return plainPre(code)
+	}
+	return template.HTML(buf.String())
+}
+
+// plainPre wraps escaped text in a <pre> block, used when rendering fails
+func plainPre(text string) template.HTML {
+	return template.HTML("<pre>" + template.HTMLEscapeString(text) + "</pre>")
+}
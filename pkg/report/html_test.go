@@ -0,0 +1,122 @@
+package report
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chmouel/gh-prreview/pkg/github"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func sampleComments() []*github.ReviewComment {
+	created := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+	return []*github.ReviewComment{
+		{
+			ID:            2,
+			Path:          "pkg/main.go",
+			Line:          12,
+			Author:        "reviewer",
+			Body:          "Use a constant here.\n```suggestion\nconst maxRetries = 3\n```",
+			HasSuggestion: true,
+			SuggestedCode: "const maxRetries = 3",
+			DiffHunk:      "@@ -10,2 +10,3 @@\n func main() {\n+\tretries := 3",
+			HTMLURL:       "https://github.com/owner/repo/pull/42#discussion_r2",
+			CreatedAt:     created,
+			ThreadComments: []github.ThreadComment{
+				{ID: 3, Author: "author", Body: "Done, thanks!", HTMLURL: "https://github.com/owner/repo/pull/42#discussion_r3", CreatedAt: created.Add(time.Hour)},
+			},
+		},
+		{
+			ID:          1,
+			Path:        "README.md",
+			Line:        4,
+			Author:      "Copilot",
+			Body:        "Typo: <b>recieve</b>",
+			SubjectType: "resolved",
+			IsOutdated:  true,
+			HTMLURL:     "https://github.com/owner/repo/pull/42#discussion_r1",
+			CreatedAt:   created,
+		},
+	}
+}
+
+func TestWriteHTMLGolden(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteHTML(&buf, sampleComments(), Options{
+		Repo:        "owner/repo",
+		PRNumber:    42,
+		PRURL:       "https://github.com/owner/repo/pull/42",
+		GeneratedAt: time.Date(2024, 5, 2, 8, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("WriteHTML returned error: %v", err)
+	}
+
+	golden := filepath.Join("testdata", "report.golden.html")
+	if *update {
+		if err := os.WriteFile(golden, buf.Bytes(), 0o644); err != nil {
+			t.Fatalf("failed to update golden file: %v", err)
+		}
+	}
+
+	expected, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("failed to read golden file: %v", err)
+	}
+	if buf.String() != string(expected) {
+		t.Errorf("WriteHTML output does not match %s (run with -update to refresh)\n got:\n%s", golden, buf.String())
+	}
+}
+
+func TestWriteHTMLStructure(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteHTML(&buf, sampleComments(), Options{Repo: "owner/repo", PRNumber: 42}); err != nil {
+		t.Fatalf("WriteHTML returned error: %v", err)
+	}
+	out := buf.String()
+
+	tests := []struct {
+		name     string
+		contains string
+	}{
+		{"one section per file", `<summary>README.md (1)</summary>`},
+		{"second file section", `<summary>pkg/main.go (1)</summary>`},
+		{"comment permalink", `href="https://github.com/owner/repo/pull/42#discussion_r2"`},
+		{"raw html is not passed through", "<!-- raw HTML omitted -->"},
+		{"resolved status", `<span class="status-resolved">resolved</span>`},
+		{"outdated flag", `<span class="outdated">outdated</span>`},
+		{"reply rendered", "Done, thanks!"},
+		{"summary counts", "2 comment(s), 1 unresolved"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !strings.Contains(out, tt.contains) {
+				t.Errorf("WriteHTML output missing %q", tt.contains)
+			}
+		})
+	}
+
+	if strings.Index(out, "README.md") > strings.Index(out, "pkg/main.go") {
+		t.Errorf("WriteHTML should sort file sections by path")
+	}
+	if strings.Contains(out, "```suggestion") {
+		t.Errorf("WriteHTML should strip suggestion blocks from comment bodies")
+	}
+}
+
+func TestWriteHTMLEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteHTML(&buf, nil, Options{Repo: "owner/repo", PRNumber: 1}); err != nil {
+		t.Fatalf("WriteHTML returned error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "No review comments found.") {
+		t.Errorf("WriteHTML with no comments should say so, got:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,114 @@
+// Package reviewdiff places review comments onto the lines of a local git diff.
+package reviewdiff
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/chmouel/gh-prreview/pkg/diffhunk"
+	"github.com/chmouel/gh-prreview/pkg/diffposition"
+	"github.com/chmouel/gh-prreview/pkg/github"
+)
+
+// lineKey identifies a line of a file on one side of the diff
+type lineKey struct {
+	path string
+	side diffposition.DiffSide
+	line int
+}
+
+// PlaceComments maps each comment onto the line of diff (the output of
+// `git diff`) it was made on. It returns the comments to show after each diff
+// line, keyed by the 0-based index of that line in diff, and the comments
+// whose line is not part of the diff (outdated, or outside the changed hunks).
+//
+// Comments on the RIGHT side match added and context lines by their new line
+// number, comments on the LEFT side match removed and context lines by their
+// old line number. Comments on the same line keep their creation order.
+func PlaceComments(diff string, comments []*github.ReviewComment) (map[int][]*github.ReviewComment, []*github.ReviewComment) {
+	index := indexDiffLines(strings.Split(diff, "\n"))
+
+	placed := make(map[int][]*github.ReviewComment)
+	var unplaced []*github.ReviewComment
+	for _, comment := range comments {
+		side := comment.DiffSide
+		if side == "" {
+			side = diffposition.DiffSideRight
+		}
+		at, ok := index[lineKey{comment.Path, side, comment.Line}]
+		if comment.Line <= 0 || !ok {
+			unplaced = append(unplaced, comment)
+			continue
+		}
+		placed[at] = append(placed[at], comment)
+	}
+
+	for _, lineComments := range placed {
+		sort.SliceStable(lineComments, func(i, j int) bool {
+			return lineComments[i].CreatedAt.Before(lineComments[j].CreatedAt)
+		})
+	}
+	return placed, unplaced
+}
+
+// indexDiffLines records, for every file line shown in a multi-file diff, the
+// index of the diff line displaying it
+func indexDiffLines(lines []string) map[lineKey]int {
+	index := make(map[lineKey]int)
+
+	var oldPath, newPath string
+	var oldLine, newLine, oldLeft, newLeft int
+	for i, line := range lines {
+		inHunk := oldLeft > 0 || newLeft > 0
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			hunk, err := diffhunk.ParseDiffHunk(line)
+			if err != nil {
+				oldLeft, newLeft = 0, 0
+				continue
+			}
+			oldLine, newLine = hunk.OldStart, hunk.NewStart
+			oldLeft, newLeft = hunk.OldLines, hunk.NewLines
+		case !inHunk:
+			// File headers between hunks
+			switch {
+			case strings.HasPrefix(line, "diff --git "):
+				oldPath, newPath = "", ""
+			case strings.HasPrefix(line, "--- "):
+				oldPath = headerPath(line, "--- ", "a/")
+			case strings.HasPrefix(line, "+++ "):
+				newPath = headerPath(line, "+++ ", "b/")
+			}
+		case strings.HasPrefix(line, "+"):
+			index[lineKey{newPath, diffposition.DiffSideRight, newLine}] = i
+			newLine++
+			newLeft--
+		case strings.HasPrefix(line, "-"):
+			index[lineKey{oldPath, diffposition.DiffSideLeft, oldLine}] = i
+			oldLine++
+			oldLeft--
+		case strings.HasPrefix(line, `\`):
+			// "\ No newline at end of file"
+		default:
+			index[lineKey{oldPath, diffposition.DiffSideLeft, oldLine}] = i
+			index[lineKey{newPath, diffposition.DiffSideRight, newLine}] = i
+			oldLine++
+			newLine++
+			oldLeft--
+			newLeft--
+		}
+	}
+	return index
+}
+
+// headerPath extracts the file path from a ---/+++ header line
+func headerPath(line, header, prefix string) string {
+	path := strings.TrimPrefix(line, header)
+	if tab := strings.IndexByte(path, '\t'); tab >= 0 {
+		path = path[:tab]
+	}
+	if path == "/dev/null" {
+		return ""
+	}
+	return strings.TrimPrefix(path, prefix)
+}
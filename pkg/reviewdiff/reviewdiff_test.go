@@ -0,0 +1,103 @@
+package reviewdiff
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chmouel/gh-prreview/pkg/diffposition"
+	"github.com/chmouel/gh-prreview/pkg/github"
+)
+
+const sampleDiff = `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -3,6 +3,7 @@ import "fmt"
+ 
+ func main() {
+-	retries := 3
++	const retries = 3
++	const delay = 1
+ 	fmt.Println(retries)
+ }
+ 
+diff --git a/docs/new.md b/docs/new.md
+new file mode 100644
+index 0000000..3333333
+--- /dev/null
++++ b/docs/new.md
+@@ -0,0 +1,2 @@
++# New
++--- not a header
+`
+
+// diffLineIndex returns the index of the first diff line equal to text
+func diffLineIndex(t *testing.T, text string) int {
+	t.Helper()
+	for i, line := range strings.Split(sampleDiff, "\n") {
+		if line == text {
+			return i
+		}
+	}
+	t.Fatalf("line %q not in sample diff", text)
+	return -1
+}
+
+func TestPlaceComments(t *testing.T) {
+	created := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	comments := []*github.ReviewComment{
+		{ID: 1, Path: "main.go", Line: 5, DiffSide: diffposition.DiffSideRight},
+		{ID: 2, Path: "main.go", Line: 6, DiffSide: diffposition.DiffSideRight, CreatedAt: created.Add(time.Hour)},
+		{ID: 3, Path: "main.go", Line: 6, CreatedAt: created},
+		{ID: 4, Path: "main.go", Line: 5, DiffSide: diffposition.DiffSideLeft},
+		{ID: 5, Path: "main.go", Line: 7, DiffSide: diffposition.DiffSideRight},
+		{ID: 6, Path: "docs/new.md", Line: 2},
+		{ID: 7, Path: "main.go", Line: 40},
+		{ID: 8, Path: "other.go", Line: 5},
+		{ID: 9, Path: "main.go", Line: 0, IsOutdated: true},
+	}
+
+	placed, unplaced := PlaceComments(sampleDiff, comments)
+
+	tests := []struct {
+		name string
+		line string
+		want []int64
+	}{
+		{"added line on the right side", "+\tconst retries = 3", []int64{1}},
+		{"several comments in creation order, empty side is right", "+\tconst delay = 1", []int64{3, 2}},
+		{"removed line on the left side", "-\tretries := 3", []int64{4}},
+		{"context line", " \tfmt.Println(retries)", []int64{5}},
+		{"new file, hunk content looking like a header", "+--- not a header", []int64{6}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := placed[diffLineIndex(t, tt.line)]
+			if len(got) != len(tt.want) {
+				t.Fatalf("comments after %q = %d, want %d", tt.line, len(got), len(tt.want))
+			}
+			for i, id := range tt.want {
+				if got[i].ID != id {
+					t.Errorf("comments after %q [%d].ID = %d, want %d", tt.line, i, got[i].ID, id)
+				}
+			}
+		})
+	}
+
+	var unplacedIDs []int64
+	for _, comment := range unplaced {
+		unplacedIDs = append(unplacedIDs, comment.ID)
+	}
+	if len(unplacedIDs) != 3 || unplacedIDs[0] != 7 || unplacedIDs[1] != 8 || unplacedIDs[2] != 9 {
+		t.Errorf("unplaced comments = %v, want [7 8 9]", unplacedIDs)
+	}
+}
+
+func TestPlaceCommentsEmptyDiff(t *testing.T) {
+	comments := []*github.ReviewComment{{ID: 1, Path: "main.go", Line: 3}}
+	placed, unplaced := PlaceComments("", comments)
+	if len(placed) != 0 || len(unplaced) != 1 {
+		t.Errorf("PlaceComments() on an empty diff = %v, %v; want every comment unplaced", placed, unplaced)
+	}
+}
@@ -0,0 +1,155 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const appliedFile = "applied.json"
+
+// AppliedSuggestion records where an applied suggestion ended up in the local
+// file, so a later resolve can check the change is still there
+type AppliedSuggestion struct {
+	Repo      string    `json:"repo"`
+	CommentID int64     `json:"comment_id"`
+	ThreadID  string    `json:"thread_id"`
+	Path      string    `json:"path"`
+	StartLine int       `json:"start_line"` // 1-based, inclusive
+	EndLine   int       `json:"end_line"`   // 1-based, inclusive
+	Lines     []string  `json:"lines"`
+	AppliedAt time.Time `json:"applied_at"`
+}
+
+// AppliedStore persists AppliedSuggestion records as JSON in the state directory
+type AppliedStore struct {
+	path string
+}
+
+// NewAppliedStore returns a store backed by the applied suggestions file in dir
+func NewAppliedStore(dir string) *AppliedStore {
+	return &AppliedStore{path: filepath.Join(dir, appliedFile)}
+}
+
+// DefaultAppliedStore returns a store in the default state directory
+func DefaultAppliedStore() (*AppliedStore, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	return NewAppliedStore(dir), nil
+}
+
+func (s *AppliedStore) load() ([]AppliedSuggestion, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", s.path, err)
+	}
+
+	var records []AppliedSuggestion
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", s.path, err)
+	}
+	return records, nil
+}
+
+func (s *AppliedStore) save(records []AppliedSuggestion) error {
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode applied suggestions: %w", err)
+	}
+	if err := os.WriteFile(s.path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", s.path, err)
+	}
+	return nil
+}
+
+// Record stores a record, replacing any previous one for the same comment
+func (s *AppliedStore) Record(record AppliedSuggestion) error {
+	records, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	replaced := false
+	for i := range records {
+		if records[i].Repo == record.Repo && records[i].CommentID == record.CommentID {
+			records[i] = record
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		records = append(records, record)
+	}
+	return s.save(records)
+}
+
+// Get returns the record for a comment, or nil if there is none
+func (s *AppliedStore) Get(repo string, commentID int64) (*AppliedSuggestion, error) {
+	records, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	for i := range records {
+		if records[i].Repo == repo && records[i].CommentID == commentID {
+			return &records[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// Remove deletes the record for a comment, if any
+func (s *AppliedStore) Remove(repo string, commentID int64) error {
+	records, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	kept := records[:0]
+	for _, record := range records {
+		if record.Repo != repo || record.CommentID != commentID {
+			kept = append(kept, record)
+		}
+	}
+	if len(kept) == len(records) {
+		return nil
+	}
+	return s.save(kept)
+}
+
+// StillPresent reports whether the recorded lines are still found at the
+// recorded range of the file. Apply records absolute paths; relative ones,
+// from older records, are read from the current directory.
+func (a *AppliedSuggestion) StillPresent() (bool, error) {
+	content, err := os.ReadFile(a.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", a.Path, err)
+	}
+	return a.matches(strings.Split(string(content), "\n")), nil
+}
+
+func (a *AppliedSuggestion) matches(fileLines []string) bool {
+	if a.StartLine < 1 || a.EndLine < a.StartLine || a.EndLine > len(fileLines) {
+		return false
+	}
+	if a.EndLine-a.StartLine+1 != len(a.Lines) {
+		return false
+	}
+	for i, line := range a.Lines {
+		if fileLines[a.StartLine-1+i] != line {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,131 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAppliedStoreRecordAndGet(t *testing.T) {
+	store := NewAppliedStore(t.TempDir())
+
+	got, err := store.Get("owner/repo", 1)
+	if err != nil {
+		t.Fatalf("Get() on empty store returned error: %v", err)
+	}
+	if got != nil {
+		t.Fatalf("Get() on empty store = %+v, want nil", got)
+	}
+
+	record := AppliedSuggestion{
+		Repo:      "owner/repo",
+		CommentID: 1,
+		ThreadID:  "T1",
+		Path:      "main.go",
+		StartLine: 3,
+		EndLine:   4,
+		Lines:     []string{"a", "b"},
+		AppliedAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+	}
+	if err := store.Record(record); err != nil {
+		t.Fatalf("Record() returned error: %v", err)
+	}
+	if err := store.Record(AppliedSuggestion{Repo: "other/repo", CommentID: 1, Path: "x.go"}); err != nil {
+		t.Fatalf("Record() returned error: %v", err)
+	}
+
+	got, err = store.Get("owner/repo", 1)
+	if err != nil {
+		t.Fatalf("Get() returned error: %v", err)
+	}
+	if got == nil || got.Path != "main.go" || got.StartLine != 3 || got.EndLine != 4 || got.ThreadID != "T1" {
+		t.Errorf("Get() = %+v, want %+v", got, record)
+	}
+	if !got.AppliedAt.Equal(record.AppliedAt) {
+		t.Errorf("Get().AppliedAt = %v, want %v", got.AppliedAt, record.AppliedAt)
+	}
+
+	// Recording the same comment again replaces the previous entry
+	record.StartLine = 10
+	record.EndLine = 11
+	if err := store.Record(record); err != nil {
+		t.Fatalf("Record() returned error: %v", err)
+	}
+	got, _ = store.Get("owner/repo", 1)
+	if got == nil || got.StartLine != 10 {
+		t.Errorf("Get() after re-record = %+v, want StartLine 10", got)
+	}
+	other, _ := store.Get("other/repo", 1)
+	if other == nil || other.Path != "x.go" {
+		t.Errorf("Get() for another repo = %+v, want x.go record", other)
+	}
+}
+
+func TestAppliedStoreRemove(t *testing.T) {
+	store := NewAppliedStore(t.TempDir())
+	for _, id := range []int64{1, 2} {
+		if err := store.Record(AppliedSuggestion{Repo: "owner/repo", CommentID: id}); err != nil {
+			t.Fatalf("Record() returned error: %v", err)
+		}
+	}
+
+	if err := store.Remove("owner/repo", 1); err != nil {
+		t.Fatalf("Remove() returned error: %v", err)
+	}
+	if got, _ := store.Get("owner/repo", 1); got != nil {
+		t.Errorf("Get() after Remove() = %+v, want nil", got)
+	}
+	if got, _ := store.Get("owner/repo", 2); got == nil {
+		t.Errorf("Remove() dropped an unrelated record")
+	}
+	if err := store.Remove("owner/repo", 42); err != nil {
+		t.Errorf("Remove() of unknown record returned error: %v", err)
+	}
+}
+
+func TestAppliedSuggestionStillPresent(t *testing.T) {
+	t.Chdir(t.TempDir())
+	if err := os.WriteFile("main.go", []byte("package main\n\nconst a = 1\nconst b = 2\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		record AppliedSuggestion
+		want   bool
+	}{
+		{"lines unchanged", AppliedSuggestion{Path: "main.go", StartLine: 3, EndLine: 4, Lines: []string{"const a = 1", "const b = 2"}}, true},
+		{"lines edited", AppliedSuggestion{Path: "main.go", StartLine: 3, EndLine: 3, Lines: []string{"const a = 2"}}, false},
+		{"range past end of file", AppliedSuggestion{Path: "main.go", StartLine: 9, EndLine: 9, Lines: []string{"x"}}, false},
+		{"file removed", AppliedSuggestion{Path: filepath.Join("gone", "main.go"), StartLine: 1, EndLine: 1, Lines: []string{"package main"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.record.StillPresent()
+			if err != nil {
+				t.Fatalf("StillPresent() returned error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("StillPresent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDirHonorsXDGStateHome(t *testing.T) {
+	base := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", base)
+
+	dir, err := Dir()
+	if err != nil {
+		t.Fatalf("Dir() returned error: %v", err)
+	}
+	if want := filepath.Join(base, "gh-prreview"); dir != want {
+		t.Errorf("Dir() = %q, want %q", dir, want)
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		t.Errorf("Dir() did not create %s", dir)
+	}
+}
@@ -0,0 +1,92 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+const browseFile = "browse.json"
+
+// BrowseState is the part of the browse TUI that is restored on the next
+// launch for the same pull request
+type BrowseState struct {
+	CollapsedFiles []string `json:"collapsed_files,omitempty"`
+	// SelectedPath and SelectedCommentID identify the last highlighted item.
+	// A file header has a path and no comment ID.
+	SelectedPath      string `json:"selected_path,omitempty"`
+	SelectedCommentID int64  `json:"selected_comment_id,omitempty"`
+}
+
+// BrowseStore persists BrowseState per repository and pull request as JSON
+// in the state directory
+type BrowseStore struct {
+	path string
+}
+
+// NewBrowseStore returns a store backed by the browse state file in dir
+func NewBrowseStore(dir string) *BrowseStore {
+	return &BrowseStore{path: filepath.Join(dir, browseFile)}
+}
+
+// DefaultBrowseStore returns a store in the default state directory
+func DefaultBrowseStore() (*BrowseStore, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	return NewBrowseStore(dir), nil
+}
+
+func browseKey(repo string, prNumber int) string {
+	return fmt.Sprintf("%s#%d", repo, prNumber)
+}
+
+func (s *BrowseStore) load() (map[string]BrowseState, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]BrowseState{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", s.path, err)
+	}
+
+	states := map[string]BrowseState{}
+	if err := json.Unmarshal(data, &states); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", s.path, err)
+	}
+	return states, nil
+}
+
+// Load returns the saved state for a pull request, or a zero state if
+// nothing was saved
+func (s *BrowseStore) Load(repo string, prNumber int) (BrowseState, error) {
+	states, err := s.load()
+	if err != nil {
+		return BrowseState{}, err
+	}
+	return states[browseKey(repo, prNumber)], nil
+}
+
+// Save stores the state for a pull request, replacing any previous one
+func (s *BrowseStore) Save(repo string, prNumber int, browse BrowseState) error {
+	states, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	sort.Strings(browse.CollapsedFiles)
+	states[browseKey(repo, prNumber)] = browse
+
+	data, err := json.MarshalIndent(states, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode browse state: %w", err)
+	}
+	if err := os.WriteFile(s.path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", s.path, err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package state
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBrowseStoreSaveAndLoad(t *testing.T) {
+	dir := t.TempDir()
+	store := NewBrowseStore(dir)
+
+	got, err := store.Load("owner/repo", 42)
+	if err != nil {
+		t.Fatalf("Load() on empty store returned error: %v", err)
+	}
+	if len(got.CollapsedFiles) != 0 || got.SelectedPath != "" || got.SelectedCommentID != 0 {
+		t.Fatalf("Load() on empty store = %+v, want zero state", got)
+	}
+
+	saved := BrowseState{
+		CollapsedFiles:    []string{"pkg/b.go", "cmd/a.go"},
+		SelectedPath:      "pkg/b.go",
+		SelectedCommentID: 7,
+	}
+	if err := store.Save("owner/repo", 42, saved); err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+	if err := store.Save("owner/repo", 43, BrowseState{SelectedPath: "README.md"}); err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+
+	// A fresh store on the same directory sees what the previous session saved
+	got, err = NewBrowseStore(dir).Load("owner/repo", 42)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	want := BrowseState{
+		CollapsedFiles:    []string{"cmd/a.go", "pkg/b.go"},
+		SelectedPath:      "pkg/b.go",
+		SelectedCommentID: 7,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Load() = %+v, want %+v", got, want)
+	}
+
+	other, err := store.Load("owner/repo", 43)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if other.SelectedPath != "README.md" || other.CollapsedFiles != nil {
+		t.Errorf("Load() for other PR = %+v", other)
+	}
+
+	// Saving again replaces the previous state for the PR
+	if err := store.Save("owner/repo", 42, BrowseState{}); err != nil {
+		t.Fatalf("Save() returned error: %v", err)
+	}
+	got, err = store.Load("owner/repo", 42)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if !reflect.DeepEqual(got, BrowseState{}) {
+		t.Errorf("Load() after reset = %+v, want zero state", got)
+	}
+}
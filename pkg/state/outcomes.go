@@ -0,0 +1,99 @@
+package state
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const outcomesFile = "outcomes.jsonl"
+
+// Apply outcome results
+const (
+	OutcomeApplied = "applied"
+	OutcomeSkipped = "skipped"
+	OutcomeFailed  = "failed"
+)
+
+// Outcome records what happened to a suggestion when apply processed it
+type Outcome struct {
+	Repo      string    `json:"repo"`
+	CommentID int64     `json:"comment_id"`
+	Author    string    `json:"author"`
+	Result    string    `json:"result"`
+	At        time.Time `json:"at"`
+}
+
+// OutcomeStore appends apply outcomes to a JSON Lines file in the state
+// directory, building a history across runs
+type OutcomeStore struct {
+	path string
+}
+
+// NewOutcomeStore returns a store backed by the outcomes file in dir
+func NewOutcomeStore(dir string) *OutcomeStore {
+	return &OutcomeStore{path: filepath.Join(dir, outcomesFile)}
+}
+
+// DefaultOutcomeStore returns a store in the default state directory
+func DefaultOutcomeStore() (*OutcomeStore, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	return NewOutcomeStore(dir), nil
+}
+
+// Record appends an outcome to the history
+func (s *OutcomeStore) Record(outcome Outcome) error {
+	line, err := json.Marshal(outcome)
+	if err != nil {
+		return fmt.Errorf("failed to encode outcome: %w", err)
+	}
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", s.path, err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write %s: %w", s.path, err)
+	}
+	return nil
+}
+
+// Load returns the recorded outcomes in the order they were recorded.
+// Malformed lines are skipped.
+func (s *OutcomeStore) Load() ([]Outcome, error) {
+	f, err := os.Open(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", s.path, err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	var outcomes []Outcome
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var outcome Outcome
+		if err := json.Unmarshal(scanner.Bytes(), &outcome); err != nil {
+			continue
+		}
+		outcomes = append(outcomes, outcome)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", s.path, err)
+	}
+	return outcomes, nil
+}
@@ -0,0 +1,51 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestOutcomeStoreRecordAndLoad(t *testing.T) {
+	dir := t.TempDir()
+	store := NewOutcomeStore(dir)
+
+	outcomes, err := store.Load()
+	if err != nil || outcomes != nil {
+		t.Fatalf("Load() on empty store = %v, %v; want nil, nil", outcomes, err)
+	}
+
+	at := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	recorded := []Outcome{
+		{Repo: "owner/repo", CommentID: 1, Author: "Copilot", Result: OutcomeApplied, At: at},
+		{Repo: "owner/repo", CommentID: 2, Author: "alice", Result: OutcomeSkipped, At: at},
+	}
+	for _, outcome := range recorded {
+		if err := store.Record(outcome); err != nil {
+			t.Fatalf("Record() returned error: %v", err)
+		}
+	}
+
+	// A corrupted line must not hide the rest of the history
+	f, err := os.OpenFile(filepath.Join(dir, outcomesFile), os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString("not json\n")
+	_ = f.Close()
+	if err := store.Record(Outcome{Repo: "owner/repo", CommentID: 3, Result: OutcomeFailed, At: at}); err != nil {
+		t.Fatalf("Record() returned error: %v", err)
+	}
+
+	outcomes, err = store.Load()
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+	if len(outcomes) != 3 {
+		t.Fatalf("Load() returned %d outcomes, want 3", len(outcomes))
+	}
+	if outcomes[0] != recorded[0] || outcomes[1] != recorded[1] || outcomes[2].CommentID != 3 {
+		t.Errorf("Load() = %+v", outcomes)
+	}
+}
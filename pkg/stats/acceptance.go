@@ -0,0 +1,69 @@
+package stats
+
+import (
+	"sort"
+
+	"github.com/chmouel/gh-prreview/pkg/state"
+)
+
+// AuthorAcceptance is how often an author's suggestions got applied
+type AuthorAcceptance struct {
+	Author  string
+	Applied int
+	Total   int
+}
+
+// Rate returns the fraction of suggestions applied, between 0 and 1
+func (a AuthorAcceptance) Rate() float64 {
+	if a.Total == 0 {
+		return 0
+	}
+	return float64(a.Applied) / float64(a.Total)
+}
+
+// AcceptanceRates computes per-author acceptance from an apply history. Only
+// outcomes for repo are counted (all repos when repo is empty), and a
+// suggestion processed several times counts once, with its latest outcome.
+// Authors are sorted by number of suggestions, most first.
+func AcceptanceRates(outcomes []state.Outcome, repo string) []AuthorAcceptance {
+	type key struct {
+		repo      string
+		commentID int64
+	}
+	latest := make(map[key]state.Outcome)
+	for _, outcome := range outcomes {
+		if repo != "" && outcome.Repo != repo {
+			continue
+		}
+		k := key{outcome.Repo, outcome.CommentID}
+		if previous, ok := latest[k]; ok && previous.At.After(outcome.At) {
+			continue
+		}
+		latest[k] = outcome
+	}
+
+	byAuthor := make(map[string]*AuthorAcceptance)
+	for _, outcome := range latest {
+		acceptance, ok := byAuthor[outcome.Author]
+		if !ok {
+			acceptance = &AuthorAcceptance{Author: outcome.Author}
+			byAuthor[outcome.Author] = acceptance
+		}
+		acceptance.Total++
+		if outcome.Result == state.OutcomeApplied {
+			acceptance.Applied++
+		}
+	}
+
+	rates := make([]AuthorAcceptance, 0, len(byAuthor))
+	for _, acceptance := range byAuthor {
+		rates = append(rates, *acceptance)
+	}
+	sort.Slice(rates, func(i, j int) bool {
+		if rates[i].Total != rates[j].Total {
+			return rates[i].Total > rates[j].Total
+		}
+		return rates[i].Author < rates[j].Author
+	})
+	return rates
+}
@@ -0,0 +1,50 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chmouel/gh-prreview/pkg/state"
+)
+
+func TestAcceptanceRates(t *testing.T) {
+	at := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	history := []state.Outcome{
+		{Repo: "owner/repo", CommentID: 1, Author: "Copilot", Result: state.OutcomeApplied, At: at},
+		{Repo: "owner/repo", CommentID: 2, Author: "Copilot", Result: state.OutcomeSkipped, At: at},
+		{Repo: "owner/repo", CommentID: 3, Author: "Copilot", Result: state.OutcomeFailed, At: at},
+		// Skipped first, applied on a later run: counts once, as applied
+		{Repo: "owner/repo", CommentID: 4, Author: "Copilot", Result: state.OutcomeSkipped, At: at},
+		{Repo: "owner/repo", CommentID: 4, Author: "Copilot", Result: state.OutcomeApplied, At: at.Add(time.Hour)},
+		{Repo: "owner/repo", CommentID: 5, Author: "Copilot", Result: state.OutcomeSkipped, At: at},
+		{Repo: "owner/repo", CommentID: 6, Author: "alice", Result: state.OutcomeApplied, At: at},
+		{Repo: "other/repo", CommentID: 1, Author: "bob", Result: state.OutcomeApplied, At: at},
+	}
+
+	got := AcceptanceRates(history, "owner/repo")
+	want := []AuthorAcceptance{
+		{Author: "Copilot", Applied: 2, Total: 5},
+		{Author: "alice", Applied: 1, Total: 1},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("AcceptanceRates() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("AcceptanceRates()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+	if rate := got[0].Rate(); rate != 0.4 {
+		t.Errorf("Copilot Rate() = %v, want 0.4", rate)
+	}
+
+	if all := AcceptanceRates(history, ""); len(all) != 3 {
+		t.Errorf("AcceptanceRates() across repos returned %d authors, want 3", len(all))
+	}
+}
+
+func TestAuthorAcceptanceRateEmpty(t *testing.T) {
+	if rate := (AuthorAcceptance{}).Rate(); rate != 0 {
+		t.Errorf("Rate() with no suggestions = %v, want 0", rate)
+	}
+}
@@ -0,0 +1,140 @@
+// Package stats computes review metrics from pull request review comments.
+package stats
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/chmouel/gh-prreview/pkg/github"
+)
+
+// ReviewerResponse summarizes how quickly a reviewer's threads got a first reply
+type ReviewerResponse struct {
+	Reviewer  string
+	Threads   int           // Threads started by the reviewer
+	Responded int           // Threads that received a reply from someone else
+	Average   time.Duration // Average time to first response over responded threads
+	Longest   time.Duration // Longest time to first response
+}
+
+// Turnaround holds the overall review timing of a pull request
+type Turnaround struct {
+	FirstComment time.Time
+	LastActivity time.Time
+	Total        time.Duration // From the first review comment to the latest reply
+	Reviewers    []ReviewerResponse
+}
+
+// ComputeTurnaround measures the time from the first review comment to the
+// latest activity (comment or reply), and the time to first response for each
+// reviewer. A response is the first reply in a thread by someone other than
+// the reviewer who started it.
+func ComputeTurnaround(comments []*github.ReviewComment) Turnaround {
+	var result Turnaround
+	byReviewer := make(map[string]*ReviewerResponse)
+	totals := make(map[string]time.Duration)
+
+	observe := func(t time.Time) {
+		if t.IsZero() {
+			return
+		}
+		if result.FirstComment.IsZero() || t.Before(result.FirstComment) {
+			result.FirstComment = t
+		}
+		if t.After(result.LastActivity) {
+			result.LastActivity = t
+		}
+	}
+
+	for _, comment := range comments {
+		observe(comment.CreatedAt)
+		for _, reply := range comment.ThreadComments {
+			observe(reply.CreatedAt)
+		}
+
+		reviewer := comment.Author
+		stats, ok := byReviewer[reviewer]
+		if !ok {
+			stats = &ReviewerResponse{Reviewer: reviewer}
+			byReviewer[reviewer] = stats
+		}
+		stats.Threads++
+
+		response, ok := firstResponse(comment)
+		if !ok {
+			continue
+		}
+		stats.Responded++
+		totals[reviewer] += response
+		if response > stats.Longest {
+			stats.Longest = response
+		}
+	}
+
+	if !result.FirstComment.IsZero() {
+		result.Total = result.LastActivity.Sub(result.FirstComment)
+	}
+
+	for reviewer, stats := range byReviewer {
+		if stats.Responded > 0 {
+			stats.Average = totals[reviewer] / time.Duration(stats.Responded)
+		}
+		result.Reviewers = append(result.Reviewers, *stats)
+	}
+	sort.Slice(result.Reviewers, func(i, j int) bool {
+		if result.Reviewers[i].Threads != result.Reviewers[j].Threads {
+			return result.Reviewers[i].Threads > result.Reviewers[j].Threads
+		}
+		return result.Reviewers[i].Reviewer < result.Reviewers[j].Reviewer
+	})
+
+	return result
+}
+
+// firstResponse returns the delay before the first reply by someone other
+// than the comment author
+func firstResponse(comment *github.ReviewComment) (time.Duration, bool) {
+	if comment.CreatedAt.IsZero() {
+		return 0, false
+	}
+
+	var first time.Time
+	for _, reply := range comment.ThreadComments {
+		if strings.EqualFold(reply.Author, comment.Author) || reply.CreatedAt.IsZero() {
+			continue
+		}
+		if first.IsZero() || reply.CreatedAt.Before(first) {
+			first = reply.CreatedAt
+		}
+	}
+	if first.IsZero() || first.Before(comment.CreatedAt) {
+		return 0, false
+	}
+	return first.Sub(comment.CreatedAt), true
+}
+
+// FormatDuration renders a duration compactly, e.g. "2d 3h", "4h 10m" or "45m"
+func FormatDuration(d time.Duration) string {
+	if d < time.Minute {
+		return "<1m"
+	}
+
+	days := int(d / (24 * time.Hour))
+	hours := int(d % (24 * time.Hour) / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+
+	switch {
+	case days > 0 && hours > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case days > 0:
+		return fmt.Sprintf("%dd", days)
+	case hours > 0 && minutes > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	case hours > 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
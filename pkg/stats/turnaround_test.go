@@ -0,0 +1,92 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chmouel/gh-prreview/pkg/github"
+)
+
+func TestComputeTurnaround(t *testing.T) {
+	start := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	comments := []*github.ReviewComment{
+		{
+			ID:        1,
+			Author:    "alice",
+			CreatedAt: start,
+			ThreadComments: []github.ThreadComment{
+				// The reviewer following up does not count as a response
+				{Author: "alice", CreatedAt: start.Add(30 * time.Minute)},
+				{Author: "author", CreatedAt: start.Add(2 * time.Hour)},
+				{Author: "bob", CreatedAt: start.Add(3 * time.Hour)},
+			},
+		},
+		{
+			ID:        2,
+			Author:    "alice",
+			CreatedAt: start.Add(time.Hour),
+			ThreadComments: []github.ThreadComment{
+				{Author: "author", CreatedAt: start.Add(5 * time.Hour)},
+			},
+		},
+		{
+			ID:        3,
+			Author:    "bob",
+			CreatedAt: start.Add(24 * time.Hour),
+		},
+	}
+
+	got := ComputeTurnaround(comments)
+
+	if !got.FirstComment.Equal(start) {
+		t.Errorf("FirstComment = %v, want %v", got.FirstComment, start)
+	}
+	if want := start.Add(24 * time.Hour); !got.LastActivity.Equal(want) {
+		t.Errorf("LastActivity = %v, want %v", got.LastActivity, want)
+	}
+	if got.Total != 24*time.Hour {
+		t.Errorf("Total = %v, want %v", got.Total, 24*time.Hour)
+	}
+
+	want := []ReviewerResponse{
+		{Reviewer: "alice", Threads: 2, Responded: 2, Average: 3 * time.Hour, Longest: 4 * time.Hour},
+		{Reviewer: "bob", Threads: 1, Responded: 0},
+	}
+	if len(got.Reviewers) != len(want) {
+		t.Fatalf("Reviewers = %+v, want %+v", got.Reviewers, want)
+	}
+	for i := range want {
+		if got.Reviewers[i] != want[i] {
+			t.Errorf("Reviewers[%d] = %+v, want %+v", i, got.Reviewers[i], want[i])
+		}
+	}
+}
+
+func TestComputeTurnaroundEmpty(t *testing.T) {
+	got := ComputeTurnaround(nil)
+	if !got.FirstComment.IsZero() || got.Total != 0 || len(got.Reviewers) != 0 {
+		t.Errorf("ComputeTurnaround(nil) = %+v, want zero value", got)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		input time.Duration
+		want  string
+	}{
+		{30 * time.Second, "<1m"},
+		{45 * time.Minute, "45m"},
+		{2 * time.Hour, "2h"},
+		{4*time.Hour + 10*time.Minute, "4h 10m"},
+		{48 * time.Hour, "2d"},
+		{51*time.Hour + 20*time.Minute, "2d 3h"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := FormatDuration(tt.input); got != tt.want {
+				t.Errorf("FormatDuration(%v) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package ui
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+)
+
+// OpenURL opens url in the system's default browser, without waiting for it
+func OpenURL(url string) error {
+	args := openURLCommand(runtime.GOOS, url)
+	if err := exec.Command(args[0], args[1:]...).Start(); err != nil {
+		return fmt.Errorf("failed to open browser: %w", err)
+	}
+	return nil
+}
+
+// openURLCommand returns the command opening url on the given OS
+func openURLCommand(goos, url string) []string {
+	switch goos {
+	case "darwin":
+		return []string{"open", url}
+	case "windows":
+		return []string{"cmd", "/c", "start", url}
+	default:
+		return []string{"xdg-open", url}
+	}
+}
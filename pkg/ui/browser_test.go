@@ -0,0 +1,27 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestOpenURLCommand(t *testing.T) {
+	url := "https://github.com/owner/repo/pull/1"
+	tests := []struct {
+		goos string
+		want []string
+	}{
+		{"darwin", []string{"open", url}},
+		{"linux", []string{"xdg-open", url}},
+		{"windows", []string{"cmd", "/c", "start", url}},
+		{"freebsd", []string{"xdg-open", url}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.goos, func(t *testing.T) {
+			if got := openURLCommand(tt.goos, url); !slices.Equal(got, tt.want) {
+				t.Errorf("openURLCommand(%q) = %v, want %v", tt.goos, got, tt.want)
+			}
+		})
+	}
+}
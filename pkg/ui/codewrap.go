@@ -0,0 +1,125 @@
+package ui
+
+import (
+	"os"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+	"golang.org/x/term"
+)
+
+// CodeWrapMarker ends every code line segment that continues on the next line
+const CodeWrapMarker = "↩"
+
+// codeTabWidth is the number of columns a tab is counted as when wrapping code
+const codeTabWidth = 4
+
+// WrapCode soft-wraps code lines longer than width columns. Unlike WrapText it
+// never reflows words: a long line is cut at the column limit and each segment
+// but the last ends with CodeWrapMarker, so it is clear the line continues and
+// was not broken in the source. ANSI color sequences (e.g. from HighlightCode)
+// take no room. A width too small to hold code and marker leaves the code
+// unchanged.
+func WrapCode(code string, width int) string {
+	if width <= runewidth.StringWidth(CodeWrapMarker) {
+		return code
+	}
+
+	lines := strings.Split(code, "\n")
+	wrapped := make([]string, 0, len(lines))
+	for _, line := range lines {
+		wrapped = append(wrapped, wrapCodeLine(line, width)...)
+	}
+	return strings.Join(wrapped, "\n")
+}
+
+// wrapCodeLine splits a single line into segments of at most width columns,
+// including the continuation marker
+func wrapCodeLine(line string, width int) []string {
+	if codeWidth(line) <= width {
+		return []string{line}
+	}
+
+	limit := width - runewidth.StringWidth(CodeWrapMarker)
+	var segments []string
+	var current strings.Builder
+	currentWidth := 0
+	runes := []rune(line)
+	for i := 0; i < len(runes); i++ {
+		if n := escapeLength(runes[i:]); n > 0 {
+			current.WriteString(string(runes[i : i+n]))
+			i += n - 1
+			continue
+		}
+		w := runeColumns(runes[i])
+		if currentWidth+w > limit && currentWidth > 0 {
+			segments = append(segments, current.String()+CodeWrapMarker)
+			current.Reset()
+			currentWidth = 0
+		}
+		current.WriteRune(runes[i])
+		currentWidth += w
+	}
+	return append(segments, current.String())
+}
+
+// codeWidth returns the display width of a line of code
+func codeWidth(line string) int {
+	width := 0
+	runes := []rune(line)
+	for i := 0; i < len(runes); i++ {
+		if n := escapeLength(runes[i:]); n > 0 {
+			i += n - 1
+			continue
+		}
+		width += runeColumns(runes[i])
+	}
+	return width
+}
+
+// escapeLength returns the length of the ANSI CSI sequence (e.g. a color)
+// starting runes, or 0 if runes does not start with one
+func escapeLength(runes []rune) int {
+	if len(runes) < 2 || runes[0] != '\033' || runes[1] != '[' {
+		return 0
+	}
+	for i := 2; i < len(runes); i++ {
+		if runes[i] >= 0x40 && runes[i] <= 0x7e {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func runeColumns(r rune) int {
+	if r == '\t' {
+		return codeTabWidth
+	}
+	return runewidth.RuneWidth(r)
+}
+
+// terminalWidth, when set, replaces the width detected on stdout
+var terminalWidth int
+
+// SetTerminalWidth makes TerminalWidth return width, for output that reaches
+// the terminal indirectly (e.g. through a pager). Zero restores detection.
+func SetTerminalWidth(width int) {
+	terminalWidth = width
+}
+
+// TerminalWidth returns the width of the terminal attached to stdout, or 0
+// when stdout is not a terminal
+func TerminalWidth() int {
+	if terminalWidth > 0 {
+		return terminalWidth
+	}
+	fd := int(os.Stdout.Fd())
+	if !term.IsTerminal(fd) {
+		return 0
+	}
+	width, _, err := term.GetSize(fd)
+	if err != nil {
+		return 0
+	}
+	return width
+}
@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWrapCode(t *testing.T) {
+	tests := []struct {
+		name  string
+		code  string
+		width int
+		want  string
+	}{
+		{
+			name:  "short lines unchanged",
+			code:  "a := 1\nb := 2",
+			width: 10,
+			want:  "a := 1\nb := 2",
+		},
+		{
+			name:  "line exactly at width unchanged",
+			code:  "0123456789",
+			width: 10,
+			want:  "0123456789",
+		},
+		{
+			name:  "long line wrapped with marker",
+			code:  "return fmt.Errorf(x)",
+			width: 10,
+			want:  "return fm↩\nt.Errorf(↩\nx)",
+		},
+		{
+			name:  "very long line wrapped several times",
+			code:  "abcdefghijklmnopqrstuvwxyz",
+			width: 10,
+			want:  "abcdefghi↩\njklmnopqr↩\nstuvwxyz",
+		},
+		{
+			name:  "whitespace kept, words not reflowed",
+			code:  "if a && b || c {",
+			width: 8,
+			want:  "if a &&↩\n b || c↩\n {",
+		},
+		{
+			name:  "only long lines wrapped",
+			code:  "x\nabcdefghijkl\ny",
+			width: 8,
+			want:  "x\nabcdefg↩\nhijkl\ny",
+		},
+		{
+			name:  "tabs count as four columns",
+			code:  "\t\tabcdef",
+			width: 8,
+			want:  "\t↩\n\tabc↩\ndef",
+		},
+		{
+			name:  "wide runes not split",
+			code:  "日本語日本語",
+			width: 6,
+			want:  "日本↩\n語日↩\n本語",
+		},
+		{
+			name:  "no width leaves code unchanged",
+			code:  "abcdefghijklmnopqrstuvwxyz",
+			width: 0,
+			want:  "abcdefghijklmnopqrstuvwxyz",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := WrapCode(tt.code, tt.width)
+			if got != tt.want {
+				t.Errorf("WrapCode(%q, %d) = %q, want %q", tt.code, tt.width, got, tt.want)
+			}
+			for _, line := range strings.Split(got, "\n") {
+				if tt.width > 0 && codeWidth(line) > tt.width {
+					t.Errorf("WrapCode(%q, %d) produced line %q wider than %d", tt.code, tt.width, line, tt.width)
+				}
+			}
+			if strings.ReplaceAll(got, CodeWrapMarker+"\n", "") != tt.code {
+				t.Errorf("WrapCode(%q, %d) = %q, removing markers should give back the code", tt.code, tt.width, got)
+			}
+		})
+	}
+}
+
+func TestColorizeCodeWidth(t *testing.T) {
+	originalEnabled := colorEnabled
+	colorEnabled = false
+	defer func() { colorEnabled = originalEnabled }()
+
+	if got, want := ColorizeCodeWidth("abcdefghijkl", 8), "abcdefg↩\nhijkl"; got != want {
+		t.Errorf("ColorizeCodeWidth() = %q, want %q", got, want)
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/charmbracelet/glamour"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/chmouel/gh-prreview/pkg/parser"
 	"github.com/muesli/reflow/wordwrap"
 	"github.com/muesli/termenv"
 )
@@ -35,17 +36,15 @@ func SetUIDebug(enabled bool) {
 	uiDebug.Store(enabled)
 }
 
-// Cached glamour renderer for markdown rendering (created once, reused)
+// Cached glamour renderers for markdown rendering, one per wrap width
+// (created once, reused)
 var (
-	cachedMarkdownRenderer *glamour.TermRenderer
-	rendererInitOnce       sync.Once
+	markdownRenderers   = make(map[int]*glamour.TermRenderer)
+	markdownRenderersMu sync.Mutex
 )
 
-// Pre-compiled regexes for StripSuggestionBlock (avoids recompilation on each call)
-var (
-	suggestionBlockRe = regexp.MustCompile("(?s)```suggestion\\s*\\n.*?```")
-	imageMarkdownRe   = regexp.MustCompile(`!\[.*?\]\(.*?\)`)
-)
+// Pre-compiled regex for StripSuggestionBlock (avoids recompilation on each call)
+var imageMarkdownRe = regexp.MustCompile(`!\[.*?\]\(.*?\)`)
 
 // WarmupMarkdownRenderer initializes the markdown renderer and warms up the
 // syntax highlighting system in the background. Call this early in the app
@@ -54,7 +53,7 @@ func WarmupMarkdownRenderer() {
 	go func() {
 		start := time.Now()
 		// Initialize the renderer (this creates glamour's TermRenderer)
-		r := getMarkdownRenderer()
+		r := getMarkdownRenderer(TextWidth())
 		if r != nil {
 			// Warm up chroma's lexers by rendering some code blocks
 			// This triggers lazy initialization of syntax highlighters
@@ -67,13 +66,16 @@ func WarmupMarkdownRenderer() {
 	}()
 }
 
-// SetColorEnabled toggles ANSI color output across the UI helpers.
+// SetColorEnabled toggles ANSI color output across the UI helpers, and loads
+// the user's color theme when enabling it.
 // Must be called before any rendering occurs (typically at startup).
 func SetColorEnabled(enabled bool) {
 	colorEnabled = enabled
 	if !enabled {
 		lipgloss.SetColorProfile(termenv.Ascii)
+		return
 	}
+	loadUserTheme()
 }
 
 // ColorsEnabled reports whether ANSI colors are enabled.
@@ -132,22 +134,29 @@ func ColorizeDiff(diff string) string {
 
 		switch line[0] {
 		case '+':
-			coloredLines = append(coloredLines, Colorize(ColorGreen, line))
+			coloredLines = append(coloredLines, Colorize(theme.DiffAdd, line))
 		case '-':
-			coloredLines = append(coloredLines, Colorize(ColorRed, line))
+			coloredLines = append(coloredLines, Colorize(theme.DiffRemove, line))
 		case '@':
-			coloredLines = append(coloredLines, Colorize(ColorCyan, line))
+			coloredLines = append(coloredLines, Colorize(theme.Header, line))
 		default:
-			coloredLines = append(coloredLines, Colorize(ColorGray, line))
+			coloredLines = append(coloredLines, Colorize(theme.Muted, line))
 		}
 	}
 
 	return strings.Join(coloredLines, "\n")
 }
 
-// ColorizeCode applies syntax highlighting to suggested code
+// ColorizeCode applies syntax highlighting to suggested code, soft-wrapping
+// lines wider than the terminal
 func ColorizeCode(code string) string {
-	return Colorize(ColorGreen, code)
+	return ColorizeCodeWidth(code, TerminalWidth())
+}
+
+// ColorizeCodeWidth applies syntax highlighting to suggested code, soft-wrapping
+// lines wider than width with a continuation marker (see WrapCode)
+func ColorizeCodeWidth(code string, width int) string {
+	return Colorize(theme.DiffAdd, WrapCode(code, width))
 }
 
 // CreateHyperlink creates an OSC8 hyperlink
@@ -166,7 +175,7 @@ func StripSuggestionBlock(body string) string {
 	result := strings.TrimSpace(body)
 
 	// Remove ```suggestion...``` blocks
-	result = suggestionBlockRe.ReplaceAllString(result, "")
+	result = parser.RemoveSuggestions(result)
 
 	// Remove markdown image links like ![alt](url)
 	result = imageMarkdownRe.ReplaceAllString(result, "")
@@ -179,33 +188,44 @@ func WrapText(text string, width int) string {
 	return wordwrap.String(text, width)
 }
 
-// getMarkdownRenderer returns a cached glamour renderer, creating it once if needed
-func getMarkdownRenderer() *glamour.TermRenderer {
-	rendererInitOnce.Do(func() {
-		var start time.Time
-		if uiDebug.Load() {
-			start = time.Now()
-			fmt.Fprintf(os.Stderr, "[DEBUG] Creating glamour renderer...\n")
-		}
-		// Create renderer once and cache it
-		// Use dark style directly instead of WithAutoStyle() which can be slow
-		// due to terminal capability detection
-		r, err := glamour.NewTermRenderer(
-			glamour.WithStandardStyle("dark"),
-			glamour.WithWordWrap(80),
-		)
-		if err == nil {
-			cachedMarkdownRenderer = r
-		}
-		if uiDebug.Load() {
-			fmt.Fprintf(os.Stderr, "[DEBUG] Glamour renderer created in %v\n", time.Since(start))
-		}
-	})
-	return cachedMarkdownRenderer
+// getMarkdownRenderer returns the cached glamour renderer wrapping at width,
+// creating it on first use
+func getMarkdownRenderer(width int) *glamour.TermRenderer {
+	markdownRenderersMu.Lock()
+	defer markdownRenderersMu.Unlock()
+	if r, ok := markdownRenderers[width]; ok {
+		return r
+	}
+
+	var start time.Time
+	if uiDebug.Load() {
+		start = time.Now()
+		fmt.Fprintf(os.Stderr, "[DEBUG] Creating glamour renderer (width %d)...\n", width)
+	}
+	// Use dark style directly instead of WithAutoStyle() which can be slow
+	// due to terminal capability detection
+	r, err := glamour.NewTermRenderer(
+		glamour.WithStandardStyle("dark"),
+		glamour.WithWordWrap(width),
+	)
+	if err != nil {
+		r = nil
+	}
+	markdownRenderers[width] = r
+	if uiDebug.Load() {
+		fmt.Fprintf(os.Stderr, "[DEBUG] Glamour renderer created in %v\n", time.Since(start))
+	}
+	return r
 }
 
-// RenderMarkdown renders markdown text with glamour
+// RenderMarkdown renders markdown text with glamour, wrapped to TextWidth
 func RenderMarkdown(text string) (string, error) {
+	return RenderMarkdownWidth(text, TextWidth())
+}
+
+// RenderMarkdownWidth renders markdown text with glamour, wrapped to width,
+// e.g. for text printed indented
+func RenderMarkdownWidth(text string, width int) (string, error) {
 	if text == "" {
 		return "", nil
 	}
@@ -214,7 +234,7 @@ func RenderMarkdown(text string) (string, error) {
 		return strings.TrimSpace(text), nil
 	}
 
-	r := getMarkdownRenderer()
+	r := getMarkdownRenderer(width)
 	if r == nil {
 		// Fallback to plain text if renderer creation failed
 		return text, nil
@@ -247,11 +267,11 @@ func RenderMarkdown(text string) (string, error) {
 type AuthorStyle struct {
 	Name  string // Author name (without @ symbol)
 	IsBot bool   // True if author name ends with [bot]
-	Color string // ANSI color code (cyan for users, yellow for bots)
+	Color string // ANSI color code (theme author or bot color)
 }
 
 // NewAuthorStyle creates a new author style based on the author name.
-// Bots (ending with [bot]) are colored yellow, regular users in cyan.
+// Bots (ending with [bot]) get the theme's bot color, regular users its author color.
 func NewAuthorStyle(author string) *AuthorStyle {
 	isBot := strings.HasSuffix(author, "[bot]") || strings.EqualFold(author, "Copilot")
 	name := author
@@ -265,9 +285,9 @@ func NewAuthorStyle(author string) *AuthorStyle {
 	}
 
 	if style.IsBot {
-		style.Color = ColorYellow
+		style.Color = theme.Bot
 	} else {
-		style.Color = ColorCyan
+		style.Color = theme.Author
 	}
 
 	return style
@@ -295,7 +315,7 @@ func (as *AuthorStyle) Format(includeIcon bool) string {
 type StatusStyle struct {
 	IsResolved bool   // True if resolved, false if unresolved
 	Label      string // "resolved" or "unresolved"
-	Color      string // ANSI color code (green for resolved, yellow for unresolved)
+	Color      string // ANSI color code (theme resolved or unresolved color)
 	Emoji      string // Visual indicator (✅ or ⚠️)
 }
 
@@ -307,11 +327,11 @@ func NewStatusStyle(isResolved bool) *StatusStyle {
 
 	if isResolved {
 		style.Label = "resolved"
-		style.Color = ColorGreen
+		style.Color = theme.Resolved
 		style.Emoji = "✅"
 	} else {
 		style.Label = "unresolved"
-		style.Color = ColorYellow
+		style.Color = theme.Unresolved
 		style.Emoji = "⚠️ " // Extra space after ⚠️ for better visual spacing
 	}
 
@@ -372,17 +392,18 @@ func (rls *ReviewListStyle) FormatCommentTitle(commentID int64) string {
 }
 
 // FormatCommentDescription returns a formatted description for comment list: "file:line [emoji status]".
-func (rls *ReviewListStyle) FormatCommentDescription(filePath string, lineNumber int) string {
-	return fmt.Sprintf("%s:%d %s", filePath, lineNumber, rls.Status.Format(true))
+func (rls *ReviewListStyle) FormatCommentDescription(location string) string {
+	return fmt.Sprintf("%s %s", location, rls.Status.Format(true))
 }
 
 // FormatSuggestionTitle returns a formatted title for suggestion list: "@author • file:line".
-func (rls *ReviewListStyle) FormatSuggestionTitle(filePath string, lineNumber int) string {
-	return fmt.Sprintf("%s • %s:%d", rls.Author.Format(false), filePath, lineNumber)
+func (rls *ReviewListStyle) FormatSuggestionTitle(location string) string {
+	return fmt.Sprintf("%s • %s", rls.Author.Format(false), location)
 }
 
 // FormatSuggestionDescription returns a formatted description with status and tags for suggestion list.
-func (rls *ReviewListStyle) FormatSuggestionDescription(hasSuggestion bool, isOutdated bool) string {
+// drifted flags a suggestion whose target lines no longer match the local file.
+func (rls *ReviewListStyle) FormatSuggestionDescription(hasSuggestion bool, isOutdated bool, drifted bool) string {
 	var parts []string
 
 	if hasSuggestion {
@@ -393,6 +414,10 @@ func (rls *ReviewListStyle) FormatSuggestionDescription(hasSuggestion bool, isOu
 		parts = append(parts, Colorize(ColorYellow, EmojiText("⚠️ OUTDATED", "OUTDATED")))
 	}
 
+	if drifted {
+		parts = append(parts, Colorize(ColorYellow, EmojiText("⚠ drifted", "drifted")))
+	}
+
 	parts = append(parts, rls.Status.Format(true))
 
 	return strings.Join(parts, " ")
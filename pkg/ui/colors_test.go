@@ -66,6 +66,11 @@ func TestStripSuggestionBlock(t *testing.T) {
 			body:     "```suggestion\nconst x = 1\n```",
 			expected: "",
 		},
+		{
+			name:     "suggestion block with a language hint",
+			body:     "Here's a fix:\r\n```suggestion go\r\nconst x = 1\r\n```",
+			expected: "Here's a fix:",
+		},
 		{
 			name:     "text before suggestion",
 			body:     "Here's a fix:\n```suggestion\nconst x = 1\n```",
@@ -81,6 +86,11 @@ func TestStripSuggestionBlock(t *testing.T) {
 			body:     "Try this:\n```suggestion\nconst x = 1\n```\nLet me know!",
 			expected: "Try this:\n\nLet me know!",
 		},
+		{
+			name:     "markdown suggestion with a code block",
+			body:     "Document it:\n````suggestion\n```bash\nmake\n```\n````\nThanks",
+			expected: "Document it:\n\nThanks",
+		},
 		{
 			name:     "multiple suggestions",
 			body:     "Option 1:\n```suggestion\na\n```\nOption 2:\n```suggestion\nb\n```",
@@ -313,41 +323,56 @@ func TestCreateHyperlink(t *testing.T) {
 func TestRenderMarkdownCaching(t *testing.T) {
 	// Save original state and restore after test
 	originalEnabled := colorEnabled
-	defer func() {
-		colorEnabled = originalEnabled
-		// Note: We do NOT restore cachedMarkdownRenderer because sync.Once
-		// has already run. Restoring it to nil would break subsequent tests.
-	}()
+	defer func() { colorEnabled = originalEnabled }()
 
 	colorEnabled = true
 
 	// First call should create or reuse the renderer
-	result1, err := RenderMarkdown("**bold**")
+	result1, err := RenderMarkdownWidth("**bold**", 80)
 	if err != nil {
-		t.Fatalf("RenderMarkdown returned error: %v", err)
+		t.Fatalf("RenderMarkdownWidth returned error: %v", err)
 	}
 	if result1 == "" {
-		t.Error("RenderMarkdown returned empty result")
+		t.Error("RenderMarkdownWidth returned empty result")
 	}
 
 	// Capture the cached renderer
-	firstRenderer := cachedMarkdownRenderer
+	firstRenderer := getMarkdownRenderer(80)
 	if firstRenderer == nil {
-		t.Fatal("cachedMarkdownRenderer should be set after first call")
+		t.Fatal("the width 80 renderer should be cached after first call")
 	}
 
 	// Second call should reuse the same renderer
-	result2, err := RenderMarkdown("_italic_")
+	result2, err := RenderMarkdownWidth("_italic_", 80)
 	if err != nil {
-		t.Fatalf("RenderMarkdown returned error: %v", err)
+		t.Fatalf("RenderMarkdownWidth returned error: %v", err)
 	}
 	if result2 == "" {
-		t.Error("RenderMarkdown returned empty result")
+		t.Error("RenderMarkdownWidth returned empty result")
 	}
 
 	// Verify the same renderer is used (not recreated)
-	if cachedMarkdownRenderer != firstRenderer {
-		t.Error("cachedMarkdownRenderer should be reused, not recreated")
+	if getMarkdownRenderer(80) != firstRenderer {
+		t.Error("the width 80 renderer should be reused, not recreated")
+	}
+}
+
+func TestRenderMarkdownWidth(t *testing.T) {
+	originalEnabled := colorEnabled
+	defer func() { colorEnabled = originalEnabled }()
+	colorEnabled = true
+
+	text := strings.Repeat("word ", 40)
+	narrow, err := RenderMarkdownWidth(text, 40)
+	if err != nil {
+		t.Fatalf("RenderMarkdownWidth returned error: %v", err)
+	}
+	wide, err := RenderMarkdownWidth(text, 120)
+	if err != nil {
+		t.Fatalf("RenderMarkdownWidth returned error: %v", err)
+	}
+	if n, w := strings.Count(narrow, "\n"), strings.Count(wide, "\n"); n <= w {
+		t.Errorf("rendering at width 40 gave %d line breaks, want more than the %d at width 120", n, w)
 	}
 }
 
@@ -415,11 +440,13 @@ func TestWarmupMarkdownRenderer(t *testing.T) {
 	// Give the goroutine time to complete
 	time.Sleep(100 * time.Millisecond)
 
-	// After warmup (or if already initialized), the renderer should be cached
-	// We can't reset sync.Once, so we just verify it doesn't panic and the
-	// renderer is non-nil after the call
-	if cachedMarkdownRenderer == nil {
-		t.Error("cachedMarkdownRenderer should be initialized after WarmupMarkdownRenderer")
+	// After warmup (or if already initialized), the renderer for the text
+	// width should be cached
+	markdownRenderersMu.Lock()
+	r := markdownRenderers[TextWidth()]
+	markdownRenderersMu.Unlock()
+	if r == nil {
+		t.Error("the renderer should be initialized after WarmupMarkdownRenderer")
 	}
 }
 
@@ -435,18 +462,21 @@ func TestGetMarkdownRenderer(t *testing.T) {
 	colorEnabled = true
 	uiDebug.Store(false) // Disable debug output
 
-	// Note: sync.Once means the renderer may already be initialized from other tests
+	// The renderer may already be initialized from other tests
 	// We test that getMarkdownRenderer returns a consistent non-nil value
-	r := getMarkdownRenderer()
+	r := getMarkdownRenderer(80)
 	if r == nil {
 		t.Error("getMarkdownRenderer should return a non-nil renderer when colors are enabled")
 	}
 
-	// Calling again should return the same instance (cached)
-	r2 := getMarkdownRenderer()
-	if r2 != r {
+	// Calling again should return the same instance (cached), another
+	// width gets its own
+	if r2 := getMarkdownRenderer(80); r2 != r {
 		t.Error("getMarkdownRenderer should return the same cached instance")
 	}
+	if r3 := getMarkdownRenderer(100); r3 == r {
+		t.Error("getMarkdownRenderer should create a separate renderer for another width")
+	}
 }
 
 func TestFormatRelativeTime(t *testing.T) {
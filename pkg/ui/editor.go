@@ -0,0 +1,134 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// editorTemplateEnv names the variable holding a full editor command line,
+// with {file} and {line} placeholders, e.g. "code --wait --goto {file}:{line}"
+const editorTemplateEnv = "GH_PRREVIEW_EDITOR"
+
+// defaultEditor is used when neither GH_PRREVIEW_EDITOR nor EDITOR is set
+const defaultEditor = "vi"
+
+// gotoEditors open file:line with --goto and need --wait to block until the
+// file is closed
+var gotoEditors = []string{"code", "code-insiders", "codium", "cursor", "windsurf"}
+
+// colonEditors open file:line given as is; the ones in waitEditors also need
+// --wait to block
+var (
+	colonEditors = []string{"subl", "sublime_text", "zed", "hx", "helix"}
+	waitEditors  = []string{"subl", "sublime_text", "zed"}
+)
+
+// editorArgs returns the command line opening path at line with editor, the
+// value of $EDITOR. Known editors get their own line syntax, others the
+// +line argument most terminal editors accept. A line below 1 opens the file
+// without moving to a line.
+func editorArgs(editor, path string, line int) ([]string, error) {
+	parts := strings.Fields(editor)
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("invalid EDITOR value: %q", editor)
+	}
+
+	name := strings.TrimSuffix(filepath.Base(parts[0]), ".exe")
+	args := parts
+	switch {
+	case slices.Contains(gotoEditors, name):
+		args = withFlag(args, "--wait")
+		if line > 0 {
+			return append(args, "--goto", fmt.Sprintf("%s:%d", path, line)), nil
+		}
+	case slices.Contains(colonEditors, name):
+		if slices.Contains(waitEditors, name) {
+			args = withFlag(args, "--wait")
+		}
+		if line > 0 {
+			return append(args, fmt.Sprintf("%s:%d", path, line)), nil
+		}
+	default:
+		if line > 0 {
+			return append(args, "+"+strconv.Itoa(line), path), nil
+		}
+	}
+	return append(args, path), nil
+}
+
+// withFlag appends flag to args unless it is already there
+func withFlag(args []string, flag string) []string {
+	if slices.Contains(args[1:], flag) {
+		return args
+	}
+	return append(args, flag)
+}
+
+// expandEditorTemplate fills the {file} and {line} placeholders of a
+// GH_PRREVIEW_EDITOR command line. Without a {file} placeholder the file is
+// appended; a line below 1 becomes 1.
+func expandEditorTemplate(template, path string, line int) ([]string, error) {
+	parts := strings.Fields(template)
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("invalid %s value: %q", editorTemplateEnv, template)
+	}
+
+	line = max(line, 1)
+	hasFile := false
+	args := make([]string, 0, len(parts)+1)
+	for _, part := range parts {
+		if strings.Contains(part, "{file}") {
+			hasFile = true
+		}
+		part = strings.ReplaceAll(part, "{file}", path)
+		args = append(args, strings.ReplaceAll(part, "{line}", strconv.Itoa(line)))
+	}
+	if !hasFile {
+		args = append(args, path)
+	}
+	return args, nil
+}
+
+// EditorCommand returns the command opening path at line in the user's
+// editor: the GH_PRREVIEW_EDITOR template when set, else $EDITOR (vi by
+// default). Its standard streams are those of the process.
+func EditorCommand(path string, line int) (*exec.Cmd, error) {
+	var args []string
+	var err error
+	if template := os.Getenv(editorTemplateEnv); template != "" {
+		args, err = expandEditorTemplate(template, path, line)
+	} else {
+		editor := os.Getenv("EDITOR")
+		if editor == "" {
+			editor = defaultEditor
+		}
+		args, err = editorArgs(editor, path, line)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd, nil
+}
+
+// LaunchEditor opens path at line in the user's editor and waits for it to
+// exit. A line below 1 opens the file without moving to a line.
+func LaunchEditor(path string, line int) error {
+	cmd, err := EditorCommand(path, line)
+	if err != nil {
+		return err
+	}
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("editor exited with error: %w", err)
+	}
+	return nil
+}
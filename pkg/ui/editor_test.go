@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEditorArgs(t *testing.T) {
+	tests := []struct {
+		name   string
+		editor string
+		line   int
+		want   []string
+	}{
+		{"terminal editor", "nvim", 12, []string{"nvim", "+12", "main.go"}},
+		{"terminal editor without line", "vi", 0, []string{"vi", "main.go"}},
+		{"editor with flags", "emacs -nw", 3, []string{"emacs", "-nw", "+3", "main.go"}},
+		{"vscode gets wait and goto", "code", 12, []string{"code", "--wait", "--goto", "main.go:12"}},
+		{"vscode keeps its wait flag", "/usr/bin/code --wait", 0, []string{"/usr/bin/code", "--wait", "main.go"}},
+		{"sublime takes file:line", "subl", 7, []string{"subl", "--wait", "main.go:7"}},
+		{"helix takes file:line without wait", "hx", 7, []string{"hx", "main.go:7"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := editorArgs(tt.editor, "main.go", tt.line)
+			if err != nil {
+				t.Fatalf("editorArgs() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("editorArgs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := editorArgs("  ", "main.go", 1); err == nil {
+		t.Error("editorArgs() with a blank editor should fail")
+	}
+}
+
+func TestExpandEditorTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		line     int
+		want     []string
+	}{
+		{"file and line", "code --wait --goto {file}:{line}", 42, []string{"code", "--wait", "--goto", "main.go:42"}},
+		{"line defaults to 1", "idea --line {line} {file}", 0, []string{"idea", "--line", "1", "main.go"}},
+		{"file appended without placeholder", "nano -l", 5, []string{"nano", "-l", "main.go"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandEditorTemplate(tt.template, "main.go", tt.line)
+			if err != nil {
+				t.Fatalf("expandEditorTemplate() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expandEditorTemplate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEditorCommandPrefersTemplate(t *testing.T) {
+	t.Setenv("EDITOR", "vim")
+	t.Setenv(editorTemplateEnv, "myeditor {file}@{line}")
+
+	cmd, err := EditorCommand("main.go", 9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"myeditor", "main.go@9"}; !reflect.DeepEqual(cmd.Args, want) {
+		t.Errorf("EditorCommand() args = %q, want %q", cmd.Args, want)
+	}
+}
@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/alecthomas/chroma"
+	"github.com/alecthomas/chroma/formatters"
+	"github.com/alecthomas/chroma/lexers"
+	"github.com/alecthomas/chroma/styles"
+)
+
+// codeHighlightStyle matches the chroma theme of the glamour dark style used
+// for markdown, so code looks the same in comments and suggestions
+const codeHighlightStyle = "monokai"
+
+// HighlightCode syntax highlights code for the terminal using chroma. The
+// language is a name as returned by CodeFenceLanguageFromPath; when it is
+// empty or unknown, or colors are disabled, the code is painted in the
+// theme's diff-add color as ColorizeCode does.
+func HighlightCode(code, language string) string {
+	if !colorEnabled || language == "" {
+		return Colorize(theme.DiffAdd, code)
+	}
+	lexer := lexers.Get(language)
+	if lexer == nil {
+		return Colorize(theme.DiffAdd, code)
+	}
+
+	iterator, err := chroma.Coalesce(lexer).Tokenise(nil, code)
+	if err != nil {
+		return Colorize(theme.DiffAdd, code)
+	}
+	var buf bytes.Buffer
+	if err := formatters.Get("terminal256").Format(&buf, styles.Get(codeHighlightStyle), iterator); err != nil {
+		return Colorize(theme.DiffAdd, code)
+	}
+
+	highlighted := buf.String()
+	if !strings.HasSuffix(code, "\n") {
+		// Lexers ensure a trailing newline, drop the one that was added
+		if i := strings.LastIndex(highlighted, "\n"); i >= 0 && codeWidth(highlighted[i+1:]) == 0 {
+			highlighted = highlighted[:i] + highlighted[i+1:]
+		}
+	}
+	return highlighted
+}
@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHighlightCode(t *testing.T) {
+	originalEnabled := colorEnabled
+	defer func() { colorEnabled = originalEnabled }()
+
+	code := "x := 1\nfmt.Println(x)"
+
+	colorEnabled = true
+	got := HighlightCode(code, "go")
+	if got == Colorize(ColorGreen, code) {
+		t.Fatalf("HighlightCode() for go should not fall back to plain green")
+	}
+	if plain := stripEscapes(got); plain != code {
+		t.Errorf("HighlightCode() without escapes = %q, want the original code %q", plain, code)
+	}
+
+	fallbacks := []struct {
+		name     string
+		language string
+		enabled  bool
+		want     string
+	}{
+		{"no language", "", true, Colorize(ColorGreen, code)},
+		{"unknown language", "not-a-language", true, Colorize(ColorGreen, code)},
+		{"colors disabled", "go", false, code},
+	}
+	for _, tt := range fallbacks {
+		t.Run(tt.name, func(t *testing.T) {
+			colorEnabled = tt.enabled
+			if got := HighlightCode(code, tt.language); got != tt.want {
+				t.Errorf("HighlightCode(%q) = %q, want %q", tt.language, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrapCodeIgnoresEscapes(t *testing.T) {
+	colored := ColorGreen + "abcdefghijkl" + ColorReset
+	got := WrapCode(colored, 8)
+	if plain := stripEscapes(got); plain != "abcdefg↩\nhijkl" {
+		t.Errorf("WrapCode() on colored code = %q, want escapes not to count toward the width", got)
+	}
+}
+
+// stripEscapes removes ANSI CSI sequences from s
+func stripEscapes(s string) string {
+	var b strings.Builder
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		if n := escapeLength(runes[i:]); n > 0 {
+			i += n - 1
+			continue
+		}
+		b.WriteRune(runes[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,30 @@
+package ui
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// ErrNotInteractive is returned by the selectors when they cannot take over
+// the terminal, either because of --plain or because stdin or stdout is not
+// a terminal. Callers fall back to plain output or ask for explicit arguments.
+var ErrNotInteractive = errors.New("interactive selection needs a terminal (stdin and stdout)")
+
+// plainMode is set with --plain
+var plainMode bool
+
+// SetPlain disables the full-screen selectors, as set with --plain
+func SetPlain(plain bool) {
+	plainMode = plain
+}
+
+// Interactive reports whether the full-screen selectors can be used: --plain
+// is not set and both stdin and stdout are terminals
+func Interactive() bool {
+	if plainMode {
+		return false
+	}
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd()))
+}
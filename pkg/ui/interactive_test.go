@@ -0,0 +1,17 @@
+package ui
+
+import "testing"
+
+func TestInteractive(t *testing.T) {
+	t.Cleanup(func() { SetPlain(false) })
+
+	// Tests do not run on a terminal
+	if Interactive() {
+		t.Error("Interactive() without a terminal = true, want false")
+	}
+
+	SetPlain(true)
+	if Interactive() {
+		t.Error("Interactive() with --plain = true, want false")
+	}
+}
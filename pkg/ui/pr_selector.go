@@ -29,6 +29,10 @@ func (r *prItemRenderer) Description(pr *github.PullRequest) string {
 		parts = append(parts, Colorize(ColorGray, "[Draft]"))
 	}
 
+	if pr.StateLabel() != "" {
+		parts = append(parts, Colorize(ColorGray, "["+formatPRState(pr.State)+"]"))
+	}
+
 	description := strings.Join(parts, " • ")
 	return "  " + Colorize(ColorGray, description)
 }
@@ -53,6 +57,10 @@ func (r *prItemRenderer) PreviewWithHighlight(pr *github.PullRequest, highlightI
 		preview.WriteString(Colorize(ColorYellow, "\nStatus: Draft\n"))
 	}
 
+	if pr.StateLabel() != "" {
+		preview.WriteString(Colorize(ColorYellow, fmt.Sprintf("\nStatus: %s\n", formatPRState(pr.State))))
+	}
+
 	if pr.ReviewDecision != "" {
 		preview.WriteString(fmt.Sprintf("\nReview Status: %s\n", formatReviewStatus(pr.ReviewDecision)))
 	}
@@ -105,6 +113,18 @@ func formatReviewStatus(decision string) string {
 	}
 }
 
+// formatPRState names the state of a closed or merged pull request
+func formatPRState(state string) string {
+	switch state {
+	case "MERGED":
+		return "Merged"
+	case "CLOSED":
+		return "Closed"
+	default:
+		return state
+	}
+}
+
 // SelectPR displays an interactive selector for choosing a pull request
 func SelectPR(prs []*github.PullRequest) (*github.PullRequest, error) {
 	renderer := &prItemRenderer{}
@@ -77,7 +77,7 @@ type SelectorOptions[T any] struct {
 	Renderer ItemRenderer[T]
 
 	// Core callbacks
-	OnSelect       CustomAction[T]     // Called when Enter is pressed
+	OnSelect       CustomAction[T]     // Called when Enter is pressed; a status message keeps the list view
 	OnOpen         CustomAction[T]     // Called when 'o' is pressed
 	FilterFunc     func(T, bool) bool  // Filter items based on state
 	IsItemResolved func(T) bool        // For dynamic key display (r vs u)
@@ -108,14 +108,40 @@ type SelectorOptions[T any] struct {
 	AgentAction CustomAction[T]
 	AgentKey    string // e.g., "a agent"
 
+	// Action: A (apply the item's suggestion, detail view only)
+	ApplyAction CustomAction[T]
+	ApplyKey    string // e.g., "A apply"
+
 	// Action: e (edit file)
 	EditAction CustomAction[T]
 	EditKey    string // e.g., "e edit"
 
+	// Action: y/Y (copy the item's link/ID)
+	CopyLinkAction CustomAction[T]
+	CopyLinkKey    string // e.g., "y copy link"
+	CopyIDAction   CustomAction[T]
+	CopyIDKey      string // e.g., "Y copy ID"
+
 	// Action: x (add reaction)
 	ReactionAction   func(T) (int64, error)                       // Returns comment ID to react to
 	ReactionComplete func(commentID int64, emoji string) (string, error) // Applies reaction, returns confirmation message
 	ReactionKey      string                                       // e.g., "x react"
+
+	// Action: -/+ (collapse/expand all groups). Called with every item; the
+	// visible items are recomputed through FilterFunc afterwards.
+	CollapseAllAction func(items []T, collapse bool) string // Returns a status message
+	CollapseAllKey    string                                // e.g., "-/+ collapse/expand all"
+
+	// Navigation: n/N move to the next/previous item matching JumpTarget
+	JumpTarget func(T) bool
+	JumpKey    string // e.g., "n/N next/prev unresolved"
+
+	// Multi-select: space marks items, enter returns the marked ones (see SelectMany)
+	MultiSelect bool
+
+	// Session state
+	InitialSelect func(T) bool // Places the cursor on the first matching item at startup
+	OnExit        func(T)      // Called with the highlighted item when the selector exits
 }
 
 // SelectionModel is the tea.Model for interactive selection
@@ -154,6 +180,10 @@ type SelectionModel[T any] struct {
 	commentSelectStatus   string      // status message to display during selection
 	commentSelectInDetail bool        // true if selection was triggered from detail view
 
+	// Items marked in multi-select mode, by index in items. Shared with the
+	// delegate so marks show up in the list.
+	marked map[int]bool
+
 	// Reaction mode state (for cycling through emoji reactions)
 	reactionMode      bool  // true when cycling through reactions
 	reactionIdx       int   // current emoji index (0-7)
@@ -164,6 +194,7 @@ type SelectionModel[T any] struct {
 type listItem[T any] struct {
 	value T
 	item  ItemRenderer[T]
+	index int // position in the selector's items, stable across filtering
 }
 
 func (i listItem[T]) FilterValue() string {
@@ -178,6 +209,17 @@ func (i listItem[T]) Description() string {
 	return i.item.Description(i.value)
 }
 
+// markedItems returns the marked items in their original order
+func markedItems[T any](items []T, marked map[int]bool) []T {
+	var result []T
+	for i, item := range items {
+		if marked[i] {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
 // SanitizeEditorContent strips trailing lines starting with # and trims whitespace.
 // This preserves Markdown headings in the body while removing the instruction
 // template that is appended at the end of editor content.
@@ -195,6 +237,23 @@ func SanitizeEditorContent(raw string) string {
 	return strings.TrimSpace(strings.Join(lines, "\n"))
 }
 
+// nextMatch returns the index of the first item after from (before it when
+// backward) for which match is true, wrapping around the n items, or -1 when
+// none matches
+func nextMatch(n, from int, backward bool, match func(int) bool) int {
+	step := 1
+	if backward {
+		step = -1
+	}
+	for i := 1; i <= n; i++ {
+		idx := ((from+step*i)%n + n) % n
+		if idx != from && match(idx) {
+			return idx
+		}
+	}
+	return -1
+}
+
 // splitActionKey splits an action key like "r resolve" into key and description
 func splitActionKey(actionKey string) (string, string) {
 	parts := strings.Fields(actionKey)
@@ -20,3 +20,13 @@ func SelectFromListWithAction[T any](items []T, renderer ItemRenderer[T], custom
 	var zero T
 	return zero, ErrNoSelection
 }
+
+// SelectMany is a stub for coverage builds.
+func SelectMany[T any](opts SelectorOptions[T]) ([]T, error) {
+	return nil, ErrNoSelection
+}
+
+// SelectManyFromList is a stub for coverage builds.
+func SelectManyFromList[T any](items []T, renderer ItemRenderer[T]) ([]T, error) {
+	return nil, ErrNoSelection
+}
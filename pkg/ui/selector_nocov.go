@@ -39,6 +39,15 @@ func SelectFromList[T any](items []T, renderer ItemRenderer[T]) (T, error) {
 	})
 }
 
+// SelectManyFromList creates an interactive selector where several items can
+// be marked with space; enter returns the marked items.
+func SelectManyFromList[T any](items []T, renderer ItemRenderer[T]) ([]T, error) {
+	return SelectMany(SelectorOptions[T]{
+		Items:    items,
+		Renderer: renderer,
+	})
+}
+
 // SelectFromListWithAction creates an interactive selector with a custom action.
 // Deprecated: Use Select() with SelectorOptions for new code.
 func SelectFromListWithAction[T any](items []T, renderer ItemRenderer[T], customAction CustomAction[T], actionKey string, onOpen CustomAction[T], filterFunc func(T, bool) bool, onSelect CustomAction[T], customActionSecond CustomAction[T], actionKeySecond string) (T, error) {
@@ -60,15 +69,43 @@ func SelectFromListWithAction[T any](items []T, renderer ItemRenderer[T], custom
 // Select creates an interactive selector with the given options.
 // This is the primary API for creating selectors.
 func Select[T any](opts SelectorOptions[T]) (T, error) {
+	result, err := runSelector(opts)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return result[0], nil
+}
+
+// SelectMany creates an interactive selector in multi-select mode: space marks
+// and unmarks items, enter returns the marked items in list order, or the
+// highlighted item when nothing is marked and OnSelect doesn't handle it.
+func SelectMany[T any](opts SelectorOptions[T]) ([]T, error) {
+	opts.MultiSelect = true
+	return runSelector(opts)
+}
+
+// runSelector runs the selector program and returns the selected items
+func runSelector[T any](opts SelectorOptions[T]) ([]T, error) {
+	if !Interactive() {
+		return nil, ErrNotInteractive
+	}
+
 	// Convert items to list items
 	listItems := make([]list.Item, len(opts.Items))
 	for i, item := range opts.Items {
-		listItems[i] = listItem[T]{value: item, item: opts.Renderer}
+		listItems[i] = listItem[T]{value: item, item: opts.Renderer, index: i}
 	}
 
-	delegate := itemDelegate[T]{renderer: opts.Renderer}
+	var marked map[int]bool
+	if opts.MultiSelect {
+		marked = make(map[int]bool)
+	}
+
+	delegate := itemDelegate[T]{renderer: opts.Renderer, marked: marked}
 	l := list.New(listItems, delegate, 0, 0)
 	l.SetShowStatusBar(true)
+	l.SetShowPagination(true)
 	l.SetFilteringEnabled(true)
 	l.SetShowHelp(false)
 	l.Styles.Title = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("205"))
@@ -80,21 +117,37 @@ func Select[T any](opts SelectorOptions[T]) (T, error) {
 		items:  opts.Items,
 		opts:   opts,
 		result: nil,
+		marked: marked,
+	}
+	if opts.FilterFunc != nil {
+		// Items may start hidden, e.g. comments of a restored collapsed file
+		m.updateVisibleItems()
+	}
+	if opts.InitialSelect != nil {
+		for i, visible := range m.list.Items() {
+			if opts.InitialSelect(visible.(listItem[T]).value) {
+				m.list.Select(i)
+				break
+			}
+		}
 	}
 
 	p := tea.NewProgram(m, tea.WithAltScreen())
 	finalModel, err := p.Run()
 	if err != nil {
-		var zero T
-		return zero, err
+		return nil, err
 	}
 
 	final := finalModel.(SelectionModel[T])
+	if opts.OnExit != nil {
+		if selected := final.list.SelectedItem(); selected != nil {
+			opts.OnExit(selected.(listItem[T]).value)
+		}
+	}
 	if len(final.result) == 0 {
-		var zero T
-		return zero, ErrNoSelection
+		return nil, ErrNoSelection
 	}
-	return final.result[0], nil
+	return final.result, nil
 }
 
 // Init initializes the model
@@ -136,9 +189,10 @@ func (m SelectionModel[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		if items, ok := msg.items.([]T); ok {
 			m.items = items
+			clear(m.marked)
 			listItems := make([]list.Item, len(items))
 			for i, item := range items {
-				listItems[i] = listItem[T]{value: item, item: m.opts.Renderer}
+				listItems[i] = listItem[T]{value: item, item: m.opts.Renderer, index: i}
 			}
 			cmd := m.list.SetItems(listItems)
 			return m, tea.Batch(cmd, m.list.NewStatusMessage(Colorize(ColorGreen, fmt.Sprintf("Refreshed: %d items", len(items)))))
@@ -298,7 +352,24 @@ func (m SelectionModel[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case "a":
 				// Launch agent from detail view
 				return m.handleAgentKey(true)
-			case "e":
+			case "A":
+				// Apply the suggestion from detail view
+				if m.opts.ApplyAction != nil {
+					selected := m.list.SelectedItem()
+					if selected != nil {
+						item := selected.(listItem[T])
+						statusMsg, err := m.opts.ApplyAction(item.value)
+						m.showDetail = false
+						if err != nil {
+							return m, m.list.NewStatusMessage(Colorize(ColorRed, err.Error()))
+						}
+						if statusMsg != "" {
+							return m, m.list.NewStatusMessage(statusMsg)
+						}
+					}
+				}
+				return m, nil
+			case "e", "ctrl+e":
 				// Edit file from detail view
 				if m.opts.EditAction != nil {
 					selected := m.list.SelectedItem()
@@ -325,6 +396,10 @@ func (m SelectionModel[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case "x":
 				// Add reaction from detail view
 				return m.handleReactionKey(true)
+			case "y":
+				return m.handleItemAction(m.opts.CopyLinkAction)
+			case "Y":
+				return m.handleItemAction(m.opts.CopyIDAction)
 			case "o":
 				// Open in browser from detail view
 				if m.opts.OnOpen != nil {
@@ -359,16 +434,53 @@ func (m SelectionModel[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "q":
 			m.result = nil
 			return m, tea.Quit
+		case " ":
+			if m.opts.MultiSelect {
+				selected := m.list.SelectedItem()
+				if selected != nil {
+					item := selected.(listItem[T])
+					if m.marked[item.index] {
+						delete(m.marked, item.index)
+					} else {
+						m.marked[item.index] = true
+					}
+					m.list.CursorDown()
+					return m, m.list.NewStatusMessage(fmt.Sprintf("%d marked", len(m.marked)))
+				}
+			}
+			return m, nil
 		case "enter", "right", "l":
 			selected := m.list.SelectedItem()
 			if selected != nil {
 				item := selected.(listItem[T])
+				if m.opts.MultiSelect && msg.String() == "enter" {
+					m.result = markedItems(m.items, m.marked)
+					if len(m.result) == 0 {
+						// With nothing marked, OnSelect may handle the item
+						// itself, e.g. by folding a group header
+						if m.opts.OnSelect != nil {
+							statusMsg, err := m.opts.OnSelect(item.value)
+							if err != nil {
+								return m, m.list.NewStatusMessage(Colorize(ColorRed, err.Error()))
+							}
+							if statusMsg != "" {
+								m.updateVisibleItems()
+								return m, m.list.NewStatusMessage(statusMsg)
+							}
+						}
+						m.result = []T{item.value}
+					}
+					return m, tea.Quit
+				}
 				if m.opts.OnSelect != nil {
 					statusMsg, err := m.opts.OnSelect(item.value)
 					if err != nil {
 						return m, m.list.NewStatusMessage(Colorize(ColorRed, err.Error()))
 					}
 					if statusMsg != "" {
+						// The action may change what the filter hides, e.g.
+						// by collapsing a file
+						m.updateVisibleItems()
 						return m, m.list.NewStatusMessage(statusMsg)
 					}
 				}
@@ -393,6 +505,28 @@ func (m SelectionModel[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 			return m, nil
+		case "-", "+":
+			if m.opts.CollapseAllAction != nil {
+				statusMsg := m.opts.CollapseAllAction(m.items, msg.String() == "-")
+				m.updateVisibleItems()
+				if visible := len(m.list.VisibleItems()); m.list.Index() >= visible {
+					m.list.Select(max(visible-1, 0))
+				}
+				return m, m.list.NewStatusMessage(statusMsg)
+			}
+			return m, nil
+		case "n", "N":
+			if m.opts.JumpTarget != nil {
+				visible := m.list.VisibleItems()
+				idx := nextMatch(len(visible), m.list.Index(), msg.String() == "N", func(i int) bool {
+					return m.opts.JumpTarget(visible[i].(listItem[T]).value)
+				})
+				if idx < 0 {
+					return m, m.list.NewStatusMessage("No other item to jump to")
+				}
+				m.list.Select(idx)
+			}
+			return m, nil
 		case "tab":
 			if m.opts.FilterFunc != nil {
 				m.filterActive = !m.filterActive
@@ -450,7 +584,7 @@ func (m SelectionModel[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "a":
 			// Execute agent action
 			return m.handleAgentKey(false)
-		case "e":
+		case "e", "ctrl+e":
 			// Execute edit action
 			if m.opts.EditAction != nil {
 				selected := m.list.SelectedItem()
@@ -478,6 +612,18 @@ func (m SelectionModel[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "x":
 			// Add reaction
 			return m.handleReactionKey(false)
+		case "y":
+			// Copy the link of the item
+			return m.handleItemAction(m.opts.CopyLinkAction)
+		case "Y":
+			// Copy the ID of the item
+			return m.handleItemAction(m.opts.CopyIDAction)
+		case "pgup":
+			m.list.PrevPage()
+			return m, nil
+		case "pgdown":
+			m.list.NextPage()
+			return m, nil
 		}
 	}
 
@@ -489,17 +635,9 @@ func (m SelectionModel[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // editInEditor opens the given file path in the user's editor at the specified line
 func (m *SelectionModel[T]) editInEditor(filePath string, line int) tea.Cmd {
-	editor := os.Getenv("EDITOR")
-	if editor == "" {
-		editor = "vim"
-	}
-
-	// Format: editor +line filepath (most editors support this)
-	var c *exec.Cmd
-	if line > 0 {
-		c = exec.Command(editor, fmt.Sprintf("+%d", line), filePath)
-	} else {
-		c = exec.Command(editor, filePath)
+	c, err := EditorCommand(filePath, line)
+	if err != nil {
+		return m.list.NewStatusMessage(Colorize(ColorRed, err.Error()))
 	}
 	return tea.ExecProcess(c, func(err error) tea.Msg {
 		return editorFinishedMsg{err: err}
@@ -538,15 +676,15 @@ func (m *SelectionModel[T]) startEditorForAction(item T, action int) tea.Cmd {
 	}
 	_ = tmpFile.Close()
 
+	c, err := EditorCommand(tmpFile.Name(), 0)
+	if err != nil {
+		_ = os.Remove(tmpFile.Name())
+		return m.list.NewStatusMessage(Colorize(ColorRed, err.Error()))
+	}
+
 	m.pendingEditorItem = item
 	m.pendingEditorTmpFile = tmpFile.Name()
 	m.pendingEditorAction = action
-
-	editor := os.Getenv("EDITOR")
-	if editor == "" {
-		editor = "vim"
-	}
-	c := exec.Command(editor, tmpFile.Name())
 	return tea.ExecProcess(c, func(err error) tea.Msg {
 		return editorFinishedMsg{err: err}
 	})
@@ -626,9 +764,9 @@ func (m *SelectionModel[T]) launchAgent(prompt string) tea.Cmd {
 // updateVisibleItems applies filter and updates the list
 func (m *SelectionModel[T]) updateVisibleItems() {
 	listItems := make([]list.Item, 0, len(m.items))
-	for _, item := range m.items {
+	for i, item := range m.items {
 		if m.opts.FilterFunc == nil || m.opts.FilterFunc(item, m.filterActive) {
-			listItems = append(listItems, listItem[T]{value: item, item: m.opts.Renderer})
+			listItems = append(listItems, listItem[T]{value: item, item: m.opts.Renderer, index: i})
 		}
 	}
 	m.list.SetItems(listItems)
@@ -700,6 +838,10 @@ func (m SelectionModel[T]) View() string {
 			key, _ := splitActionKey(m.opts.AgentKey)
 			actions = append(actions, key+":agent")
 		}
+		if m.opts.ApplyAction != nil {
+			key, _ := splitActionKey(m.opts.ApplyKey)
+			actions = append(actions, key+":apply")
+		}
 		if m.opts.EditAction != nil {
 			key, _ := splitActionKey(m.opts.EditKey)
 			actions = append(actions, key+":edit")
@@ -708,6 +850,9 @@ func (m SelectionModel[T]) View() string {
 			key, _ := splitActionKey(m.opts.ReactionKey)
 			actions = append(actions, key+":react")
 		}
+		if copyKeys := m.copyKeys(); copyKeys != "" {
+			actions = append(actions, copyKeys+":copy")
+		}
 		if m.opts.OnOpen != nil {
 			actions = append(actions, "o:open")
 		}
@@ -751,7 +896,11 @@ func (m SelectionModel[T]) View() string {
 
 	// Build sticky footer with action hints
 	var actions []string
-	actions = append(actions, "enter:view")
+	if m.opts.MultiSelect {
+		actions = append(actions, "space:mark", "enter:select", "l:view")
+	} else {
+		actions = append(actions, "enter:view")
+	}
 	if m.opts.ResolveAction != nil {
 		key, _ := splitActionKey(m.getResolveActionKey())
 		actions = append(actions, key+":resolve")
@@ -780,6 +929,9 @@ func (m SelectionModel[T]) View() string {
 		key, _ := splitActionKey(m.opts.ReactionKey)
 		actions = append(actions, key+":react")
 	}
+	if copyKeys := m.copyKeys(); copyKeys != "" {
+		actions = append(actions, copyKeys+":copy")
+	}
 	if m.opts.OnOpen != nil {
 		actions = append(actions, "o:open")
 	}
@@ -789,6 +941,17 @@ func (m SelectionModel[T]) View() string {
 	if m.opts.FilterFunc != nil {
 		actions = append(actions, "tab:filter")
 	}
+	if m.opts.JumpTarget != nil {
+		key, _ := splitActionKey(m.opts.JumpKey)
+		actions = append(actions, key+":jump")
+	}
+	if m.opts.CollapseAllAction != nil {
+		key, _ := splitActionKey(m.opts.CollapseAllKey)
+		actions = append(actions, key+":fold all")
+	}
+	if m.list.Paginator.TotalPages > 1 {
+		actions = append(actions, "pgup/pgdn:page")
+	}
 	actions = append(actions, "?:help")
 	actions = append(actions, "q:quit")
 
@@ -880,6 +1043,7 @@ func (m SelectionModel[T]) renderHelpOverlay() string {
 
 Navigation:
   ↑/↓, j/k     Move up/down
+  pgup/pgdn    Previous/next page
   enter, l, →  View detail / select
   h, ←, esc    Go back
   q            Quit (list) / Back (detail)
@@ -888,6 +1052,10 @@ Navigation:
 
 Actions:`
 
+	if m.opts.MultiSelect {
+		helpText += fmt.Sprintf("\n  %-12s %s", "space", "mark/unmark item")
+		helpText += fmt.Sprintf("\n  %-12s %s", "enter", "select marked items (or the current one)")
+	}
 	// Add dynamic action help
 	if m.opts.ResolveAction != nil {
 		key, desc := splitActionKey(m.getResolveActionKey())
@@ -917,9 +1085,25 @@ Actions:`
 		key, desc := splitActionKey(m.opts.ReactionKey)
 		helpText += fmt.Sprintf("\n  %-12s %s", key, desc)
 	}
+	if m.opts.CopyLinkAction != nil {
+		key, desc := splitActionKey(m.opts.CopyLinkKey)
+		helpText += fmt.Sprintf("\n  %-12s %s", key, desc)
+	}
+	if m.opts.CopyIDAction != nil {
+		key, desc := splitActionKey(m.opts.CopyIDKey)
+		helpText += fmt.Sprintf("\n  %-12s %s", key, desc)
+	}
 	if m.opts.OnOpen != nil {
 		helpText += fmt.Sprintf("\n  %-12s %s", "o", "open in browser")
 	}
+	if m.opts.JumpTarget != nil {
+		key, desc := splitActionKey(m.opts.JumpKey)
+		helpText += fmt.Sprintf("\n  %-12s %s", key, desc)
+	}
+	if m.opts.CollapseAllAction != nil {
+		key, desc := splitActionKey(m.opts.CollapseAllKey)
+		helpText += fmt.Sprintf("\n  %-12s %s", key, desc)
+	}
 	if m.opts.RefreshItems != nil {
 		helpText += fmt.Sprintf("\n  %-12s %s", "i", "refresh")
 	}
@@ -928,7 +1112,14 @@ Actions:`
 
 Detail View:
   ctrl+f       Page down
-  ctrl+b       Page up
+  ctrl+b       Page up`
+
+	if m.opts.ApplyAction != nil {
+		key, desc := splitActionKey(m.opts.ApplyKey)
+		helpText += fmt.Sprintf("\n  %-12s %s", key, desc)
+	}
+
+	helpText += `
 
 Press any key to close this help...`
 
@@ -970,6 +1161,7 @@ Press any key to close this help...`
 // itemDelegate renders individual list items
 type itemDelegate[T any] struct {
 	renderer ItemRenderer[T]
+	marked   map[int]bool // nil unless in multi-select mode
 }
 
 func (d itemDelegate[T]) Height() int {
@@ -999,12 +1191,8 @@ func (d itemDelegate[T]) Render(w io.Writer, m list.Model, index int, item list.
 	// Truncate if needed
 	maxWidth := m.Width() - 4
 	if maxWidth > 0 {
-		if len(title) > maxWidth {
-			title = title[:maxWidth-3] + "..."
-		}
-		if len(desc) > maxWidth {
-			desc = desc[:maxWidth-3] + "..."
-		}
+		title = Truncate(title, maxWidth)
+		desc = Truncate(desc, maxWidth)
 	}
 
 	var line string
@@ -1014,6 +1202,14 @@ func (d itemDelegate[T]) Render(w io.Writer, m list.Model, index int, item list.
 		line = title
 	}
 
+	if d.marked != nil {
+		if d.marked[i.index] {
+			line = EmojiText("✓ ", "* ") + line
+		} else {
+			line = "  " + line
+		}
+	}
+
 	// Style based on selection and skippable state
 	if index == m.Index() {
 		style := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("205"))
@@ -1127,6 +1323,43 @@ func (m *SelectionModel[T]) handleQuoteContextKey(inDetailView bool) (tea.Model,
 	return m, m.startEditorForAction(item.value, 4)
 }
 
+// handleItemAction runs a simple action on the highlighted item and shows its
+// result as a status message, used by both list and detail views
+func (m *SelectionModel[T]) handleItemAction(action CustomAction[T]) (tea.Model, tea.Cmd) {
+	if action == nil {
+		return m, nil
+	}
+	selected := m.list.SelectedItem()
+	if selected == nil {
+		return m, nil
+	}
+
+	item := selected.(listItem[T])
+	statusMsg, err := action(item.value)
+	if err != nil {
+		return m, m.list.NewStatusMessage(Colorize(ColorRed, err.Error()))
+	}
+	if statusMsg != "" {
+		return m, m.list.NewStatusMessage(statusMsg)
+	}
+	return m, nil
+}
+
+// copyKeys returns the keys of the configured copy actions for the footer,
+// e.g. "y/Y"
+func (m *SelectionModel[T]) copyKeys() string {
+	var keys []string
+	if m.opts.CopyLinkAction != nil {
+		key, _ := splitActionKey(m.opts.CopyLinkKey)
+		keys = append(keys, key)
+	}
+	if m.opts.CopyIDAction != nil {
+		key, _ := splitActionKey(m.opts.CopyIDKey)
+		keys = append(keys, key)
+	}
+	return strings.Join(keys, "/")
+}
+
 // handleAgentKey handles the 'a' key for agent action, used by both list and detail views
 func (m *SelectionModel[T]) handleAgentKey(inDetailView bool) (tea.Model, tea.Cmd) {
 	if m.opts.AgentAction == nil {
@@ -733,3 +733,60 @@ func TestReactionConfirmationMessageAllEmojis(t *testing.T) {
 		})
 	}
 }
+
+func TestMarkedItems(t *testing.T) {
+	items := []string{"a", "b", "c", "d"}
+
+	tests := []struct {
+		name   string
+		marked map[int]bool
+		want   []string
+	}{
+		{name: "none marked", marked: map[int]bool{}, want: nil},
+		{name: "nil map", marked: nil, want: nil},
+		{name: "kept in list order", marked: map[int]bool{3: true, 0: true}, want: []string{"a", "d"}},
+		{name: "unmarked entries ignored", marked: map[int]bool{1: true, 2: false}, want: []string{"b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := markedItems(items, tt.marked)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") || len(got) != len(tt.want) {
+				t.Errorf("markedItems() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextMatch(t *testing.T) {
+	// Items 1 and 4 match, out of 6
+	match := func(i int) bool { return i == 1 || i == 4 }
+
+	tests := []struct {
+		name     string
+		from     int
+		backward bool
+		want     int
+	}{
+		{"next", 1, false, 4},
+		{"next wraps around", 4, false, 1},
+		{"previous", 4, true, 1},
+		{"previous wraps around", 0, true, 4},
+		{"from a non-matching item", 2, false, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextMatch(6, tt.from, tt.backward, match); got != tt.want {
+				t.Errorf("nextMatch(6, %d, %v) = %d, want %d", tt.from, tt.backward, got, tt.want)
+			}
+		})
+	}
+
+	if got := nextMatch(6, 1, false, func(i int) bool { return i == 1 }); got != -1 {
+		t.Errorf("nextMatch() with only the current item matching = %d, want -1", got)
+	}
+	if got := nextMatch(0, 0, false, match); got != -1 {
+		t.Errorf("nextMatch() on an empty list = %d, want -1", got)
+	}
+}
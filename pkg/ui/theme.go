@@ -0,0 +1,208 @@
+package ui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/chmouel/gh-prreview/pkg/config"
+)
+
+// themeEnvPrefix prefixes the environment variables overriding theme roles,
+// e.g. GH_PRREVIEW_THEME_DIFF_ADD=blue
+const themeEnvPrefix = "GH_PRREVIEW_THEME_"
+
+// Theme maps the semantic roles of the UI to ANSI color codes
+type Theme struct {
+	Resolved   string
+	Unresolved string
+	Author     string
+	Bot        string
+	DiffAdd    string
+	DiffRemove string
+	Header     string // diff hunk headers
+	Muted      string // diff context lines
+}
+
+// DefaultTheme returns the built-in colors, tuned for dark terminals
+func DefaultTheme() Theme {
+	return Theme{
+		Resolved:   ColorGreen,
+		Unresolved: ColorYellow,
+		Author:     ColorCyan,
+		Bot:        ColorYellow,
+		DiffAdd:    ColorGreen,
+		DiffRemove: ColorRed,
+		Header:     ColorCyan,
+		Muted:      ColorGray,
+	}
+}
+
+// theme holds the colors in use, see SetColorEnabled
+var theme = DefaultTheme()
+
+// roles maps the role names used in theme files to the fields of t
+func (t *Theme) roles() map[string]*string {
+	return map[string]*string{
+		"resolved":    &t.Resolved,
+		"unresolved":  &t.Unresolved,
+		"author":      &t.Author,
+		"bot":         &t.Bot,
+		"diff-add":    &t.DiffAdd,
+		"diff-remove": &t.DiffRemove,
+		"header":      &t.Header,
+		"muted":       &t.Muted,
+	}
+}
+
+// namedColors are the color names accepted in themes, besides 256-color
+// palette numbers
+var namedColors = map[string]string{
+	"black":          "\033[30m",
+	"red":            ColorRed,
+	"green":          ColorGreen,
+	"yellow":         ColorYellow,
+	"blue":           "\033[34m",
+	"magenta":        ColorMagenta,
+	"cyan":           ColorCyan,
+	"white":          "\033[37m",
+	"gray":           ColorGray,
+	"grey":           ColorGray,
+	"bright-red":     "\033[91m",
+	"bright-green":   "\033[92m",
+	"bright-yellow":  "\033[93m",
+	"bright-blue":    "\033[94m",
+	"bright-magenta": "\033[95m",
+	"bright-cyan":    "\033[96m",
+	"bright-white":   "\033[97m",
+}
+
+// parseColor turns a theme color, a name such as "blue", a 256-color
+// palette number such as "244" or a hex RGB value such as "#ff8800", into
+// its ANSI code
+func parseColor(value string) (string, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if code, ok := namedColors[value]; ok {
+		return code, nil
+	}
+	if n, err := strconv.Atoi(value); err == nil && n >= 0 && n <= 255 {
+		return fmt.Sprintf("\033[38;5;%dm", n), nil
+	}
+	if hex, ok := strings.CutPrefix(value, "#"); ok && len(hex) == 6 {
+		if rgb, err := strconv.ParseUint(hex, 16, 32); err == nil {
+			return fmt.Sprintf("\033[38;2;%d;%d;%dm", rgb>>16, rgb>>8&0xff, rgb&0xff), nil
+		}
+	}
+	return "", fmt.Errorf("unknown color %q", value)
+}
+
+// setRole sets the color of the named role
+func (t *Theme) setRole(role, value string) error {
+	field, ok := t.roles()[role]
+	if !ok {
+		names := make([]string, 0, len(t.roles()))
+		for name := range t.roles() {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return fmt.Errorf("unknown role %q (known roles: %s)", role, strings.Join(names, ", "))
+	}
+	code, err := parseColor(value)
+	if err != nil {
+		return fmt.Errorf("%s: %w", role, err)
+	}
+	*field = code
+	return nil
+}
+
+// ParseTheme reads `role = "color"` lines over the defaults. Blank lines,
+// # comments and [section] headers are ignored, so a theme can be kept in a
+// small TOML file.
+func ParseTheme(content string) (Theme, error) {
+	t := DefaultTheme()
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "[") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return t, fmt.Errorf("line %d: expected role = \"color\"", lineNo)
+		}
+		if err := t.setRole(strings.TrimSpace(key), themeValue(value)); err != nil {
+			return t, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+	}
+	return t, scanner.Err()
+}
+
+// themeValue returns the color of the value part of a theme line: the quoted
+// string, or else the first word, so that a trailing # comment is dropped
+// without cutting a hex color
+func themeValue(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if raw != "" && (raw[0] == '"' || raw[0] == '\'') {
+		if end := strings.IndexByte(raw[1:], raw[0]); end >= 0 {
+			return raw[1 : end+1]
+		}
+		return raw[1:]
+	}
+	if fields := strings.Fields(raw); len(fields) > 0 {
+		return fields[0]
+	}
+	return ""
+}
+
+// applyThemeEnv overrides roles from GH_PRREVIEW_THEME_<ROLE> variables, with
+// the dashes of the role name written as underscores. A bad variable only
+// leaves its own role alone; the errors are returned together.
+func (t *Theme) applyThemeEnv() error {
+	var errs []error
+	for role := range t.roles() {
+		name := themeEnvPrefix + strings.ToUpper(strings.ReplaceAll(role, "-", "_"))
+		if value := os.Getenv(name); value != "" {
+			if err := t.setRole(role, value); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// LoadTheme reads the theme file at path, if any, then applies the
+// environment overrides. With a bad override, the theme is returned with the
+// others applied, along with the error.
+func LoadTheme(path string) (Theme, error) {
+	t := DefaultTheme()
+	content, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return t, fmt.Errorf("failed to read %s: %w", path, err)
+	default:
+		if t, err = ParseTheme(string(content)); err != nil {
+			return DefaultTheme(), fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+	}
+	return t, t.applyThemeEnv()
+}
+
+// loadUserTheme makes the theme from the user's theme file and environment
+// the one in use. A broken theme file is reported and the defaults are kept;
+// a bad environment override only leaves its role alone.
+func loadUserTheme() {
+	path, err := config.ThemePath()
+	if err != nil {
+		return
+	}
+	t, err := LoadTheme(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: color theme: %v\n", err)
+	}
+	theme = t
+}
@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseTheme(t *testing.T) {
+	content := `# light terminal
+[colors]
+muted = "black"
+diff-add = 28 # dark green
+bot = 'bright-magenta'
+header = "#ff8800" # orange
+author = #00ff00
+`
+	got, err := ParseTheme(content)
+	if err != nil {
+		t.Fatalf("ParseTheme() error = %v", err)
+	}
+
+	want := DefaultTheme()
+	want.Muted = "\033[30m"
+	want.DiffAdd = "\033[38;5;28m"
+	want.Bot = "\033[95m"
+	want.Header = "\033[38;2;255;136;0m"
+	want.Author = "\033[38;2;0;255;0m"
+	if got != want {
+		t.Errorf("ParseTheme() = %q, want %q", got, want)
+	}
+}
+
+func TestParseThemeErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"unknown role", `title = "red"`, `line 1: unknown role "title"`},
+		{"unknown color", "\nheader = \"pink\"", `line 2: header: unknown color "pink"`},
+		{"out of palette", `header = 256`, `unknown color "256"`},
+		{"bad hex", `header = "#ff00"`, `unknown color "#ff00"`},
+		{"not an assignment", `header`, `line 1: expected role = "color"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseTheme(tt.content)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseTheme() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadTheme(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("missing file keeps the defaults", func(t *testing.T) {
+		got, err := LoadTheme(filepath.Join(dir, "missing.toml"))
+		if err != nil || got != DefaultTheme() {
+			t.Errorf("LoadTheme() = %q, %v, want the defaults", got, err)
+		}
+	})
+
+	t.Run("environment overrides the file", func(t *testing.T) {
+		path := filepath.Join(dir, "theme.toml")
+		if err := os.WriteFile(path, []byte("diff-add = \"blue\"\nheader = \"magenta\"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		t.Setenv("GH_PRREVIEW_THEME_DIFF_ADD", "white")
+
+		got, err := LoadTheme(path)
+		if err != nil {
+			t.Fatalf("LoadTheme() error = %v", err)
+		}
+		if got.DiffAdd != "\033[37m" || got.Header != ColorMagenta {
+			t.Errorf("LoadTheme() diff-add = %q, header = %q", got.DiffAdd, got.Header)
+		}
+	})
+
+	t.Run("bad override only skips its role", func(t *testing.T) {
+		t.Setenv("GH_PRREVIEW_THEME_BOT", "nope")
+		t.Setenv("GH_PRREVIEW_THEME_MUTED", "black")
+		got, err := LoadTheme(filepath.Join(dir, "theme.toml"))
+		if err == nil || !strings.Contains(err.Error(), "GH_PRREVIEW_THEME_BOT") {
+			t.Errorf("LoadTheme() error = %v, want it to name the variable", err)
+		}
+		if got.Bot != DefaultTheme().Bot || got.Muted != "\033[30m" || got.Header != ColorMagenta {
+			t.Errorf("LoadTheme() bot = %q, muted = %q, header = %q, want the default, the override and the file",
+				got.Bot, got.Muted, got.Header)
+		}
+	})
+}
@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// truncateEllipsis ends text shortened by Truncate
+const truncateEllipsis = "..."
+
+// Truncate shortens s to at most width terminal columns, ending it with "..."
+// when anything was cut. It works on whole runes and counts wide characters
+// (CJK, most emoji) as two columns, so multi-byte text is never split in the
+// middle of a character. ANSI color sequences take no room; a color left open
+// by the cut is reset.
+func Truncate(s string, width int) string {
+	if displayWidth(s) <= width {
+		return s
+	}
+
+	limit := width - len(truncateEllipsis)
+	if limit < 0 {
+		return truncateEllipsis[:max(width, 0)]
+	}
+
+	var b strings.Builder
+	used := 0
+	colored := false
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		if n := escapeLength(runes[i:]); n > 0 {
+			b.WriteString(string(runes[i : i+n]))
+			colored = true
+			i += n - 1
+			continue
+		}
+		w := runewidth.RuneWidth(runes[i])
+		if used+w > limit {
+			break
+		}
+		b.WriteRune(runes[i])
+		used += w
+	}
+	if colored {
+		b.WriteString(ColorReset)
+	}
+	return b.String() + truncateEllipsis
+}
+
+// displayWidth returns the display width of s, ANSI color sequences excluded
+func displayWidth(s string) int {
+	width := 0
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		if n := escapeLength(runes[i:]); n > 0 {
+			i += n - 1
+			continue
+		}
+		width += runewidth.RuneWidth(runes[i])
+	}
+	return width
+}
@@ -0,0 +1,43 @@
+package ui
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name  string
+		s     string
+		width int
+		want  string
+	}{
+		{"short text unchanged", "hello", 10, "hello"},
+		{"exact width unchanged", "0123456789", 10, "0123456789"},
+		{"ascii cut with ellipsis", "hello world", 8, "hello..."},
+		{"accents are one column", "café crème brûlée", 10, "café cr..."},
+		{"CJK counts two columns", "日本語のテキスト", 9, "日本語..."},
+		{"wide rune not split across the limit", "日本語のテキスト", 8, "日本..."},
+		{"emoji counts two columns", "🚀🚀🚀🚀🚀", 7, "🚀🚀..."},
+		{"emoji mixed with text", "ok 👍 thanks", 8, "ok 👍..."},
+		{"width smaller than the ellipsis", "hello", 2, ".."},
+		{"zero width", "hello", 0, ""},
+		{"color sequences take no room", "\033[36mhello\033[0m", 5, "\033[36mhello\033[0m"},
+		{"open color reset after the cut", "\033[36mhello world\033[0m", 8, "\033[36mhello\033[0m..."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Truncate(tt.s, tt.width)
+			if got != tt.want {
+				t.Errorf("Truncate(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("Truncate(%q, %d) = %q is not valid UTF-8", tt.s, tt.width, got)
+			}
+			if w := displayWidth(got); w > tt.width {
+				t.Errorf("Truncate(%q, %d) is %d columns wide", tt.s, tt.width, w)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package ui
+
+// defaultTextWidth is the width prose is wrapped to when the terminal width
+// cannot be detected
+const defaultTextWidth = 80
+
+// minTextWidth keeps indented text readable on very narrow terminals
+const minTextWidth = 20
+
+// textWidth, when set, is the --width override
+var textWidth int
+
+// SetTextWidth makes TextWidth return width, as set with --width. Zero
+// restores following the terminal.
+func SetTextWidth(width int) {
+	textWidth = width
+}
+
+// TextWidth returns the width comment bodies and other prose are wrapped to:
+// the --width override, else the terminal width, else 80 columns
+func TextWidth() int {
+	if textWidth > 0 {
+		return textWidth
+	}
+	if width := TerminalWidth(); width > 0 {
+		return width
+	}
+	return defaultTextWidth
+}
+
+// IndentedTextWidth returns the width left for prose printed after indent
+// columns
+func IndentedTextWidth(indent int) int {
+	return max(TextWidth()-indent, minTextWidth)
+}
@@ -0,0 +1,31 @@
+package ui
+
+import "testing"
+
+func TestTextWidth(t *testing.T) {
+	t.Cleanup(func() {
+		SetTextWidth(0)
+		SetTerminalWidth(0)
+	})
+
+	// Tests do not run on a terminal
+	if got := TextWidth(); got != defaultTextWidth {
+		t.Errorf("TextWidth() without a terminal = %d, want %d", got, defaultTextWidth)
+	}
+
+	SetTerminalWidth(132)
+	if got := TextWidth(); got != 132 {
+		t.Errorf("TextWidth() on a 132 column terminal = %d, want 132", got)
+	}
+
+	SetTextWidth(60)
+	if got := TextWidth(); got != 60 {
+		t.Errorf("TextWidth() with --width 60 = %d, want 60", got)
+	}
+	if got := IndentedTextWidth(5); got != 55 {
+		t.Errorf("IndentedTextWidth(5) = %d, want 55", got)
+	}
+	if got := IndentedTextWidth(50); got != minTextWidth {
+		t.Errorf("IndentedTextWidth(50) = %d, want the minimum %d", got, minTextWidth)
+	}
+}
@@ -0,0 +1,180 @@
+package ui
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	styleDim     = "\033[2m"
+	styleChanged = "\033[1;7m"
+)
+
+// maxWordDiffTokens bounds the token-level LCS on very long lines; longer
+// pairs are shown as fully changed
+const maxWordDiffTokens = 500
+
+// diffSpan is a piece of a removed or added line, marking whether it differs
+// from the paired line
+type diffSpan struct {
+	Text    string
+	Changed bool
+}
+
+// ColorizeDiffWords colors a diff like ColorizeDiff, but for each removed line
+// followed by an added line it highlights only the tokens that changed
+// between the two, dimming the parts they have in common. Runs of removed
+// lines followed by runs of added lines are paired up in order; unpaired lines
+// are colored as a whole. Without colors the diff is returned as is.
+func ColorizeDiffWords(diff string) string {
+	if !colorEnabled {
+		return ColorizeDiff(diff)
+	}
+
+	lines := strings.Split(diff, "\n")
+	colored := make([]string, 0, len(lines))
+	for i := 0; i < len(lines); {
+		if !isRemovedLine(lines[i]) {
+			colored = append(colored, ColorizeDiff(lines[i]))
+			i++
+			continue
+		}
+
+		start := i
+		for i < len(lines) && isRemovedLine(lines[i]) {
+			i++
+		}
+		removed := lines[start:i]
+		start = i
+		for i < len(lines) && isAddedLine(lines[i]) {
+			i++
+		}
+		added := lines[start:i]
+
+		for j, line := range removed {
+			if j >= len(added) {
+				colored = append(colored, ColorizeDiff(line))
+				continue
+			}
+			oldSpans, _ := wordDiff(line[1:], added[j][1:])
+			colored = append(colored, renderSpans(theme.DiffRemove, "-", oldSpans))
+		}
+		for j, line := range added {
+			if j >= len(removed) {
+				colored = append(colored, ColorizeDiff(line))
+				continue
+			}
+			_, newSpans := wordDiff(removed[j][1:], line[1:])
+			colored = append(colored, renderSpans(theme.DiffAdd, "+", newSpans))
+		}
+	}
+	return strings.Join(colored, "\n")
+}
+
+func isRemovedLine(line string) bool {
+	return strings.HasPrefix(line, "-") && !strings.HasPrefix(line, "--- ")
+}
+
+func isAddedLine(line string) bool {
+	return strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "+++ ")
+}
+
+// renderSpans draws a diff line with changed spans in bold inverse and the
+// rest dimmed
+func renderSpans(color, prefix string, spans []diffSpan) string {
+	var b strings.Builder
+	b.WriteString(color + prefix + ColorReset)
+	for _, span := range spans {
+		style := styleDim
+		if span.Changed {
+			style = styleChanged
+		}
+		b.WriteString(color + style + span.Text + ColorReset)
+	}
+	return b.String()
+}
+
+// wordDiff compares two lines token by token and returns the spans of each,
+// with the tokens not common to both (per longest common subsequence) marked
+// as changed
+func wordDiff(oldLine, newLine string) ([]diffSpan, []diffSpan) {
+	oldTokens := tokenize(oldLine)
+	newTokens := tokenize(newLine)
+	if len(oldTokens) > maxWordDiffTokens || len(newTokens) > maxWordDiffTokens {
+		return mergeSpans(oldTokens, nil), mergeSpans(newTokens, nil)
+	}
+
+	// lcs[i][j] is the LCS length of oldTokens[i:] and newTokens[j:]
+	lcs := make([][]int, len(oldTokens)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(newTokens)+1)
+	}
+	for i := len(oldTokens) - 1; i >= 0; i-- {
+		for j := len(newTokens) - 1; j >= 0; j-- {
+			if oldTokens[i] == newTokens[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	oldCommon := make([]bool, len(oldTokens))
+	newCommon := make([]bool, len(newTokens))
+	for i, j := 0, 0; i < len(oldTokens) && j < len(newTokens); {
+		switch {
+		case oldTokens[i] == newTokens[j]:
+			oldCommon[i] = true
+			newCommon[j] = true
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return mergeSpans(oldTokens, oldCommon), mergeSpans(newTokens, newCommon)
+}
+
+// mergeSpans joins consecutive tokens with the same changed state. A nil
+// common slice marks every token as changed.
+func mergeSpans(tokens []string, common []bool) []diffSpan {
+	var spans []diffSpan
+	for i, token := range tokens {
+		changed := common == nil || !common[i]
+		if len(spans) > 0 && spans[len(spans)-1].Changed == changed {
+			spans[len(spans)-1].Text += token
+			continue
+		}
+		spans = append(spans, diffSpan{Text: token, Changed: changed})
+	}
+	return spans
+}
+
+// tokenize splits a line into identifiers/numbers, runs of whitespace, and
+// single punctuation characters
+func tokenize(line string) []string {
+	var tokens []string
+	runes := []rune(line)
+	for i := 0; i < len(runes); {
+		j := i + 1
+		switch {
+		case isWordRune(runes[i]):
+			for j < len(runes) && isWordRune(runes[j]) {
+				j++
+			}
+		case unicode.IsSpace(runes[i]):
+			for j < len(runes) && unicode.IsSpace(runes[j]) {
+				j++
+			}
+		}
+		tokens = append(tokens, string(runes[i:j]))
+		i = j
+	}
+	return tokens
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
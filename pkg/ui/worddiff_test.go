@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	got := tokenize("if err != nil {  return x_1")
+	want := []string{"if", " ", "err", " ", "!", "=", " ", "nil", " ", "{", "  ", "return", " ", "x_1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("tokenize() = %q, want %q", got, want)
+	}
+}
+
+func TestWordDiff(t *testing.T) {
+	tests := []struct {
+		name    string
+		oldLine string
+		newLine string
+		wantOld []diffSpan
+		wantNew []diffSpan
+	}{
+		{
+			name:    "single token changed",
+			oldLine: "retries := 3",
+			newLine: "retries := 5",
+			wantOld: []diffSpan{{"retries := ", false}, {"3", true}},
+			wantNew: []diffSpan{{"retries := ", false}, {"5", true}},
+		},
+		{
+			name:    "token inserted",
+			oldLine: "foo(a)",
+			newLine: "foo(a, b)",
+			wantOld: []diffSpan{{"foo(a)", false}},
+			wantNew: []diffSpan{{"foo(a", false}, {", b", true}, {")", false}},
+		},
+		{
+			name:    "identical lines",
+			oldLine: "x := 1",
+			newLine: "x := 1",
+			wantOld: []diffSpan{{"x := 1", false}},
+			wantNew: []diffSpan{{"x := 1", false}},
+		},
+		{
+			name:    "completely different",
+			oldLine: "alpha",
+			newLine: "beta",
+			wantOld: []diffSpan{{"alpha", true}},
+			wantNew: []diffSpan{{"beta", true}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotOld, gotNew := wordDiff(tt.oldLine, tt.newLine)
+			if !reflect.DeepEqual(gotOld, tt.wantOld) {
+				t.Errorf("wordDiff() old spans = %+v, want %+v", gotOld, tt.wantOld)
+			}
+			if !reflect.DeepEqual(gotNew, tt.wantNew) {
+				t.Errorf("wordDiff() new spans = %+v, want %+v", gotNew, tt.wantNew)
+			}
+		})
+	}
+}
+
+func TestColorizeDiffWords(t *testing.T) {
+	originalEnabled := colorEnabled
+	defer func() { colorEnabled = originalEnabled }()
+
+	diff := "@@ -1,3 +1,3 @@\n context\n-retries := 3\n+retries := 5\n+extra line"
+
+	colorEnabled = true
+	got := strings.Split(ColorizeDiffWords(diff), "\n")
+	want := []string{
+		ColorizeDiff("@@ -1,3 +1,3 @@"),
+		ColorizeDiff(" context"),
+		ColorRed + "-" + ColorReset + ColorRed + styleDim + "retries := " + ColorReset + ColorRed + styleChanged + "3" + ColorReset,
+		ColorGreen + "+" + ColorReset + ColorGreen + styleDim + "retries := " + ColorReset + ColorGreen + styleChanged + "5" + ColorReset,
+		ColorizeDiff("+extra line"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ColorizeDiffWords() =\n%q\nwant\n%q", got, want)
+	}
+
+	colorEnabled = false
+	if got := ColorizeDiffWords(diff); got != diff {
+		t.Errorf("ColorizeDiffWords() without colors = %q, want unchanged diff", got)
+	}
+}
+
+func TestColorizeDiffWordsSkipsFileHeaders(t *testing.T) {
+	originalEnabled := colorEnabled
+	colorEnabled = true
+	defer func() { colorEnabled = originalEnabled }()
+
+	diff := "--- a/main.go\n+++ b/main.go"
+	if got, want := ColorizeDiffWords(diff), ColorizeDiff(diff); got != want {
+		t.Errorf("ColorizeDiffWords() = %q, want file headers colored as in ColorizeDiff %q", got, want)
+	}
+}